@@ -49,6 +49,11 @@ var (
 	showVersion            = false
 	conversionHealthzURL   = ""
 	enableDynamicDiscovery = false
+	topologyLabels         = []string{"topology.kubernetes.io/zone", "topology.kubernetes.io/region"}
+
+	cordonOnCriticalWarning = true
+	mediaErrorThreshold     = int64(10)
+	ioErrorThreshold        = int64(100)
 )
 
 var driverCmd = &cobra.Command{
@@ -101,12 +106,16 @@ func init() {
 	driverCmd.Flags().StringVarP(&rack, "rack", "", rack, "identity of the rack in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&zone, "zone", "", zone, "identity of the zone in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&region, "region", "", region, "identity of the region in which this direct-csi is running")
+	driverCmd.Flags().StringSliceVarP(&topologyLabels, "topology-labels", "", topologyLabels, "node label keys to be used as topology segments of this direct-csi")
 	driverCmd.Flags().StringVarP(&procfs, "procfs", "", procfs, "path to host /proc for accessing mount information")
 	driverCmd.Flags().BoolVarP(&controller, "controller", "", controller, "running in controller mode")
 	driverCmd.Flags().BoolVarP(&driver, "driver", "", driver, "run in driver mode")
 	driverCmd.Flags().BoolVarP(&loopBackOnly, "loopback-only", "", loopBackOnly, "Create and uses loopback devices only")
 	driverCmd.Flags().StringVarP(&conversionHealthzURL, "conversion-healthz-url", "", conversionHealthzURL, "The URL of the conversion webhook healthz endpoint")
 	driverCmd.Flags().BoolVarP(&enableDynamicDiscovery, "enable-dynamic-discovery", "", enableDynamicDiscovery, "Enable dynamic drive discovery")
+	driverCmd.Flags().BoolVarP(&cordonOnCriticalWarning, "cordon-on-critical-warning", "", cordonOnCriticalWarning, "cordon drives raising S.M.A.R.T. or NVMe critical warnings")
+	driverCmd.Flags().Int64VarP(&mediaErrorThreshold, "media-error-threshold", "", mediaErrorThreshold, "cordon drives having this many media errors; 0 disables")
+	driverCmd.Flags().Int64VarP(&ioErrorThreshold, "io-error-threshold", "", ioErrorThreshold, "cordon drives having this many I/O errors in kernel log; 0 disables")
 
 	driverCmd.PersistentFlags().MarkHidden("alsologtostderr")
 	driverCmd.PersistentFlags().MarkHidden("log_backtrace_at")
@@ -29,6 +29,7 @@ import (
 
 	ctrl "github.com/minio/direct-csi/pkg/controller"
 	"github.com/minio/direct-csi/pkg/converter"
+	"github.com/minio/direct-csi/pkg/drive"
 	id "github.com/minio/direct-csi/pkg/identity"
 	"github.com/minio/direct-csi/pkg/node"
 	"github.com/minio/direct-csi/pkg/node/discovery"
@@ -157,7 +158,11 @@ func run(ctx context.Context, args []string) error {
 		volume.SyncVolumes(ctx, nodeID)
 		klog.V(3).Infof("Volumes sync completed")
 
-		nodeSrv, err = node.NewNodeServer(ctx, identity, nodeID, rack, zone, region, enableDynamicDiscovery)
+		nodeSrv, err = node.NewNodeServer(ctx, identity, nodeID, rack, zone, region, topologyLabels, enableDynamicDiscovery, drive.HealthPolicy{
+			CriticalWarnings: cordonOnCriticalWarning,
+			MediaErrors:      mediaErrorThreshold,
+			IOErrors:         ioErrorThreshold,
+		})
 		if err != nil {
 			return err
 		}
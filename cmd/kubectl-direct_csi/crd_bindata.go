@@ -41,7 +41,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _config_crd_direct_csi_min_io_directcsidrives_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6d\x73\xdb\xc6\xf1\x7f\xaf\x4f\xb1\xc3\xff\x7f\xc6\x92\x4b\x40\x96\xdd\x71\x13\xce\x78\x3c\xae\x5c\x75\x3c\x89\x63\x8f\xa5\xe4\x45\x45\xb5\x59\x02\x4b\xf2\x22\xe0\x0e\xb9\x3b\x50\x62\x3a\xfd\xee\x9d\xbd\x03\x48\x90\x04\x20\x52\xad\x93\x34\xb9\x7b\x45\xde\xc3\xde\xee\xde\x3e\xdd\xfd\x70\x14\x45\xd1\x11\x16\xe2\x3b\xd2\x46\x28\x39\x02\x2c\x04\xdd\x5b\x92\xfc\xcf\xc4\xb7\x5f\x98\x58\xa8\xd3\xc5\xd9\xd1\xad\x90\xe9\x08\xce\x4b\x63\x55\xfe\x89\x8c\x2a\x75\x42\x6f\x69\x2a\xa4\xb0\x42\xc9\xa3\x9c\x2c\xa6\x68\x71\x74\x04\x80\x52\x2a\x8b\xdc\x6d\xf8\x2f\x40\xa2\xa4\xd5\x2a\xcb\x48\x47\x33\x92\xf1\x6d\x39\xa1\x49\x29\xb2\x94\xb4\x23\x5e\x6f\xbd\x78\x16\xbf\x8c\xcf\x8e\x00\x12\x4d\x6e\xf9\x95\xc8\xc9\x58\xcc\x8b\x11\xc8\x32\xcb\x8e\x00\x24\xe6\x34\x82\x54\x68\x4a\x6c\x62\x44\xaa\xc5\x82\x4c\xec\xff\xc7\x89\x11\x71\x2e\x64\x2c\xd4\x91\x29\x28\xe1\xbd\x67\x5a\x95\x45\xbd\xa0\x39\xc1\x93\xaa\xf8\xf3\xb2\xbd\x75\x93\xce\x2f\xdf\xbd\x65\xaa\x6e\x20\x13\xc6\x7e\xd5\x32\xf8\xb5\x30\xd6\x4d\x28\xb2\x52\x63\xb6\xc3\x91\x1b\x33\x42\xce\xca\x0c\xf5\xf6\xe8\x11\x80\x49\x54\x41\x23\x38\xcf\x4a\x63\x49\x1f\x01\x54\x3a\x70\xfc\x44\x95\x94\x8b\x33\xcc\x8a\x39\x9e\x79\x62\xc9\x9c\x72\xf4\xec\x02\xa8\x82\xe4\x9b\x8f\xef\xbe\x7b\x71\xb9\xd1\x0d\x90\x92\x49\xb4\x28\xac\xd3\xe7\x26\xcf\x90\x92\x54\x96\x0c\x38\x26\xe0\xfc\xd3\x5b\x50\x93\x1f\x58\x2d\xab\xd5\x85\x56\x05\x69\x2b\x6a\xbd\xf8\xd6\xb0\x8e\x46\xef\xd6\x5e\x4f\x98\x1d\x3f\x0b\x52\x36\x0b\x32\x60\xe7\x54\x0b\x46\x69\x25\x01\xa8\x29\xd8\xb9\x30\xa0\xa9\xd0\x64\x48\x7a\x43\xd9\x20\x0c\x3c\x09\x65\xcd\x1e\x5c\x92\x66\x32\x60\xe6\xaa\xcc\x52\xb6\xa6\x05\x69\x0b\x9a\x12\x35\x93\xe2\xa7\x15\x6d\x03\x56\xb9\x4d\x33\xb4\x54\x1d\xd0\xba\x09\x69\x49\x4b\xcc\x60\x81\x59\x49\x43\x40\x99\x42\x8e\x4b\xd0\xc4\xbb\x40\x29\x1b\xf4\xdc\x14\x13\xc3\x7b\xa5\x09\x84\x9c\xaa\x11\xcc\xad\x2d\xcc\xe8\xf4\x74\x26\x6c\xed\x15\x89\xca\xf3\x52\x0a\xbb\x3c\x75\x06\x2e\x26\xa5\x55\xda\x9c\xa6\xb4\xa0\xec\xd4\x88\x59\x84\x3a\x99\x0b\x4b\x89\x2d\x35\x9d\x62\x21\x22\xc7\xba\x74\x9e\x11\xe7\xe9\xff\xe9\xca\x8f\xcc\x93\x0d\x5e\xed\x92\x8d\xc3\x58\x2d\xe4\xac\x31\xe0\xac\xb4\xe7\x04\xd8\x50\x41\x18\xc0\x6a\xa9\x97\x62\xad\x68\xee\x62\xed\x7c\xfa\xcb\xe5\x15\xd4\x5b\xbb\xc3\xd8\xd6\xbe\xd3\xfb\x7a\xa1\x59\x1f\x01\x2b\x4c\xc8\x29\x69\x7f\x88\x53\xad\x72\x47\x93\x64\x5a\x28\x21\xad\xfb\x93\x64\x82\xe4\xb6\xfa\x4d\x39\xc9\x85\xe5\x73\xff\xb1\x24\x63\xf9\xac\x62\x38\x77\xa1\x02\x26\x04\x65\x91\xa2\xa5\x34\x86\x77\x12\xce\x31\xa7\xec\x1c\x0d\x7d\xf6\x03\x60\x4d\x9b\x88\x15\xbb\xdf\x11\x34\xa3\xdc\xf6\x64\xaf\xb5\xc6\x40\x1d\x83\x3a\xce\x6b\xd3\x3b\x2f\x0b\x4a\xb6\x3c\x94\xd7\x8b\xa9\x48\x9c\x83\xc4\x1b\x84\xda\x1d\xd5\x6d\x51\x53\xfd\x70\x27\x29\xdd\x1e\xdd\x62\x81\xcf\x42\x68\x4a\x77\x66\x79\x89\x26\x4a\x65\x84\xdb\xbe\xe9\x98\xbb\x42\x21\xed\x2e\x75\x4c\x53\x97\x0e\x30\xfb\xd8\xc9\x61\x8f\x7a\x7b\xd5\xc9\xad\x32\x1e\x4a\x2f\x94\xce\xb1\x85\x81\x0d\xf1\x3e\x6d\xce\xde\x52\xef\xd4\x77\x56\x24\x9d\x91\x71\xc7\x8e\xae\xfb\xf5\xcd\x6d\x2a\x32\x32\x4b\x63\x29\x6f\x1b\x7d\x40\x5a\x60\x46\x12\xea\x5b\xd9\x7e\x0e\xdc\x72\x55\x4a\xfb\xa1\x68\xa4\xda\xed\x26\x2c\xe5\x1d\x43\x0f\x32\x56\x4f\x40\xad\x71\xd9\x3a\x7e\x1f\x71\x2e\xd7\x92\x2c\x99\x88\x93\x65\x54\xad\xb0\x2a\x17\x49\x17\xc3\x2e\x52\x3c\x4a\x55\x45\xa9\x67\x8f\x52\x55\xa7\x4d\xd5\x2e\xb0\x49\x34\xda\xf2\xa3\xbd\xdc\xdd\xa2\x2d\xcd\xfe\x0e\xef\xa6\x6f\xd9\x64\xa7\x11\x76\x1b\x20\x66\x99\x4a\x38\x74\x9e\x63\x81\x89\xb0\xcb\x5d\xf5\x78\x9a\x23\xce\x80\x2f\xff\xd8\xa1\x1a\xce\x8e\x33\x57\x8a\x34\x5b\xa2\xa4\x77\xe8\x16\x13\xea\xb4\xac\x0d\xa1\x07\xe7\x35\x09\x57\x05\xa2\x90\x2c\xb3\x45\x91\x19\xe6\x0b\x94\x24\x40\x8e\x74\xd6\x57\x06\x04\x49\xa9\xf5\x6e\xfa\x58\xeb\x98\x56\x25\xc4\x9b\x8f\xef\xa0\x2e\x45\x63\x88\xa2\x08\xae\xb8\xdb\x58\x5d\x26\x96\x33\x21\x0b\x25\x53\x4a\xdd\x4e\xfe\x44\x5b\xc9\x96\x86\x99\xe0\x92\xc3\x99\x3a\xa0\xcf\x63\x53\x41\x59\x0a\x05\xda\x39\xc4\xfe\x74\xe3\xb5\x42\x62\x80\x0b\xa5\x81\xee\x31\x2f\x32\x1a\x76\xda\x24\x5c\x28\x55\x9d\xb5\x67\xec\x9f\x6e\xe8\xf4\x14\x3e\xad\xf2\xab\xdb\x4d\x4d\x0c\xe9\x85\x2f\x9b\x5d\x01\xd4\x4a\x72\xaa\xd4\x13\x53\xeb\xc8\xeb\x23\xae\x09\x7e\x25\xd5\x9d\x6c\x63\xd5\xf1\x81\xba\xc3\x73\xc6\x83\x37\x0b\x14\x19\x4e\x32\x1a\x0f\x86\x30\x1e\x7c\xd4\x6a\xa6\xc9\x70\xfd\xca\x1d\x5c\x28\x8d\x07\x6f\x69\xa6\x31\xa5\x74\x3c\xa8\xb7\xfb\x43\x81\x36\x99\xbf\x27\x3d\xa3\xaf\x68\xf9\x8a\x37\x69\xa7\xbf\x31\xff\xd2\x6a\xb4\x34\x5b\xbe\xca\x79\xe1\x8a\x16\x07\x8f\xab\x65\x41\xaf\x72\x2c\x36\x3a\xdf\x63\xf1\x30\xf5\x95\x91\x19\xb8\xbe\xe1\x24\xbd\x38\x8b\xd7\x86\xf7\xfd\x0f\x46\xc9\xd1\x78\xb0\xd6\xc8\x50\xe5\x6c\xbe\x85\x5d\x8e\x07\xad\x54\x37\x58\x1d\x8d\x07\x8e\xd9\xf1\x00\x36\x44\x1e\x8d\x07\xcc\x16\x77\x6b\x65\xd5\xa4\x9c\x8e\xc6\x83\xc9\xd2\x92\x19\x9e\x0d\x35\x15\x43\xae\xe3\x5f\xad\x77\x1d\x0f\xbe\x6f\x17\x41\xd6\x12\x2b\x3b\x27\xed\xed\xce\xc0\xbf\xda\x58\xeb\xcf\x44\x00\x19\x1a\x7b\xa5\x51\x1a\x51\x5f\xa0\xba\x82\xff\x86\x9b\xee\x2e\x63\xff\xf1\xb5\xb4\xb1\x60\xb9\xc3\x39\x67\x2d\x4c\x07\x51\x00\xbb\xa2\xc2\x7e\xc7\xf5\x21\xbb\xb8\xb7\x49\xae\xcf\x51\x3a\x21\xe3\xca\x57\x7d\x49\x3f\x21\xb8\x9b\x53\x0f\xd1\x39\x41\x29\x53\xd2\xd9\x92\xab\xd8\x64\x1d\x53\xe6\x28\x67\x5c\x36\xc2\x3b\x0e\x0a\xe8\xdc\x9e\x4b\xca\x5b\xf6\x85\x21\x2f\xec\xa6\x5a\x9a\xba\x24\x76\xf2\x31\x07\xee\x1f\xc7\x15\xef\xfb\x15\x79\x57\x55\x27\x09\x15\x96\x9d\x64\xb7\x3c\xf0\xad\x0e\xb3\x5c\xc8\x46\x4c\xf1\xb1\x59\x37\x27\x63\xb0\x2b\xcf\x6d\x1d\x5c\x35\xd7\xd7\xfd\xf3\x32\x47\x09\x9a\x30\x65\x3e\xd7\x63\x32\x75\x55\x64\xc7\x76\x9e\xa6\x0f\xc9\x38\x51\xa5\x0f\x7e\xeb\x73\xac\x8e\x8a\x4b\xff\x09\x71\x90\x74\x8e\x53\x09\xd0\xa5\x8c\x1c\xef\xbf\x26\x39\xb3\xf3\x11\xbc\x78\xfe\xa7\x97\x5f\x3c\x56\x17\x3e\x2a\x52\xfa\x57\x92\xa4\x5d\x70\xdc\x4b\x2d\xbb\xcb\x1a\xd7\x19\x27\x5f\x5c\xd7\xf2\xf1\x6c\x35\xa7\xc7\xfe\xaa\x94\xb0\xb6\xbc\x3b\x34\x60\xc8\xc2\x04\x0d\xa5\x50\x16\xac\x27\x4e\x08\x42\x1a\x8b\x32\xa1\x21\x88\xe9\x61\x9b\x88\x55\x5c\xcf\x96\x70\xf6\x7c\x08\x93\xea\x28\x76\x23\xfa\xf5\xfd\x4d\xbc\x2b\x62\x1f\xe5\x2f\x87\x5b\xfc\x0b\x03\x7c\xd4\x6a\xea\xec\x15\xee\x84\x9d\xf3\xa5\xd0\x65\xe2\xea\x1a\xdd\x97\x89\x61\x33\x1b\xd3\x4a\xee\x87\xbc\xa3\xbd\x08\xf1\x2d\x17\x52\xe4\x65\x3e\x82\x67\xbd\xe6\xd2\x5e\xab\xf8\xa6\x09\xcd\x9e\x36\xe2\xa7\xae\xcb\x12\xe4\xe0\x3a\xd3\x98\x73\x01\x96\x80\x48\xf9\xa2\x38\x15\xa4\xf7\x71\x20\x56\x41\x45\x90\x8b\x8d\x0d\x5d\x3f\x31\x55\x14\x6d\xb8\xd4\x47\xad\xd2\x32\x21\xbd\x7d\xf7\x5e\x37\x35\x5d\xdd\x00\x1b\xc7\xe6\x6e\xac\xce\x17\xfd\x2b\x0b\xd0\x3d\x1f\xd9\xea\xcd\x82\xb3\x75\x27\xc9\x9c\x50\x0a\x39\x33\x15\x8b\x7c\x81\xe7\x30\xe7\x53\xfc\xdd\x9c\x5c\xf6\x71\xaf\x36\x15\x2d\xed\xa4\x30\x22\xa5\xb6\x5b\x62\xdd\x10\x66\x25\x6a\x94\x96\x28\xe5\xe0\xc9\x01\xa3\xa2\xd1\x08\xf0\xb8\xbe\xd7\x3f\x10\x3b\xc0\x07\x1c\x1f\x82\x59\xd4\xea\x8d\xc0\xc5\x9d\x3d\x02\xce\xd9\xb3\xe7\x3d\x16\xb6\x9a\xd5\x31\xa5\x40\x6b\x49\xcb\x11\xfc\xfd\xfa\x4d\xf4\x37\x8c\x7e\xba\x39\xae\x7e\x3c\x8b\xbe\xfc\xc7\x70\x74\xf3\xb4\xf1\xf7\xe6\xe4\xf5\xff\x3f\x36\xb4\xb5\x5d\x18\xd6\x6d\xc3\x54\xab\xf4\x59\x57\xc8\xb5\x35\x0c\x5d\x6e\x55\x53\xb8\xd2\x25\x0d\xe1\x02\x33\x43\x43\xf8\x56\xba\xe4\xd7\xa5\x28\x92\x65\xc7\x3d\x95\xef\x3d\x03\x26\xd5\x5e\x13\xb9\x61\xb7\x47\xf7\x78\xb5\xf7\x7f\x74\xdf\xdc\x47\x21\xae\xa2\x55\xd3\x66\x3c\x6b\xbc\x1b\x81\x8b\xc3\x5c\x2b\xc7\x55\x7d\x1e\x27\x2a\x3f\x5d\xbf\x2b\x75\x1a\x1e\x5f\x22\xde\xa3\x5c\xc2\x3a\xd8\xfa\xea\x79\xdb\x23\x8c\xe5\xfa\x1b\x13\xad\x8c\x59\x3d\xa6\x75\x3b\x73\x26\x6e\x09\x56\x65\xb6\x0f\xed\x13\x4a\xd0\xdd\x3c\xf4\x44\x58\x8d\x7a\xd9\xb8\x6e\x41\x82\xd2\x3d\x8b\x19\x9a\x96\x59\x27\xd9\x63\x43\x04\xb1\x54\x29\xed\xe6\x88\x13\x1f\xf1\x71\x22\x32\x61\x97\x1c\xd3\x53\x4a\x94\x9c\x66\xc2\x5d\x8e\xba\x93\x45\x5e\x28\x6d\x51\x5a\xef\xc6\x9a\x66\x74\x0f\xc2\x42\xce\xa5\x2f\x19\x4e\x1c\xc7\xa9\x34\x67\x67\xcf\x5f\x5c\x96\x93\x54\xe5\x28\xe4\x45\x6e\x4f\x4f\x5e\x1f\xff\x58\x62\xc6\x11\x33\xfd\x06\x73\xba\xc8\xed\xc9\x1e\xc5\xc1\xd9\xcb\x07\xfd\xf0\xf8\xda\x7b\xdb\xcd\xf1\x75\x54\xfd\x7a\x5a\x77\x9d\xbc\x3e\x1e\xc7\xbd\xe3\x27\x4f\x99\xb5\x86\x0f\xdf\x5c\x47\x6b\x07\x8e\x6f\x9e\x9e\xbc\x6e\x8c\x9d\x3c\xd2\x9d\xdb\xdf\x11\x7c\x8b\x5a\xca\xeb\xd6\x69\x55\xc1\xd6\x3a\xe6\x93\x4b\xeb\x90\x3f\xfa\xd6\xa1\x8e\x6b\x53\xcf\x13\x5b\xff\xa3\xcf\xee\x83\x4f\x8e\x45\x74\x4b\xcb\x96\x38\xd6\xb1\x7b\xd7\x9b\x51\x8e\x45\xdb\x4b\xe3\x65\x47\x94\xdc\x7c\x5a\xe9\x7c\x51\xa9\xdc\xa2\x43\xc8\xd6\xe3\xec\x7b\xce\xeb\x5b\xa6\x89\x3e\xc7\x1b\x4c\xa6\x66\x22\xc1\xec\xcf\x99\x4a\x6e\x2f\xc5\x4f\x2d\xf1\xf1\xf1\xb4\x73\x95\x52\xf6\x4d\x99\x4f\x48\x1f\x24\x6b\xff\xbb\x63\xe7\xcb\xd0\x1e\xcf\xbe\xfb\x9a\x5d\xcf\x3b\x63\xdf\x1b\x63\x0f\x07\x1c\x45\x39\x6e\x1d\xb4\xa8\x40\x6d\x9d\x4f\x7f\xd3\x96\x54\xfb\x54\x5f\xa0\x9d\x1f\xb6\xd5\x7c\x69\x3e\x9b\x21\x68\xa5\xec\xc7\x5a\x96\x83\xd8\x32\xa4\x05\x3e\xc6\x86\xac\x2a\x54\xa6\x66\x2d\xbe\xf2\xb9\x51\x04\xab\x2c\x66\xff\x7d\x57\xed\x7a\x4a\xe6\x93\x7e\xf8\x01\x79\x77\x75\xb4\x82\x9b\x1a\x5d\x7c\x25\x38\xea\x24\xe4\x6f\x84\x23\xb0\xba\xf4\x81\xd7\x58\xa5\x71\x46\x23\x98\x72\xdd\xb6\x01\x2e\x4f\xc8\x06\x6c\x39\x60\xcb\xbe\x05\x6c\x39\x60\xcb\x55\x0b\xd8\x72\xc0\x96\x21\x60\xcb\xbb\x2b\x7f\x87\xd8\x72\x92\x90\x31\x57\xa2\xad\xb2\xdb\xd8\xfe\xcd\x6a\xe2\x6a\x53\xbf\x16\xac\x20\x7d\xd0\xed\x2b\xe0\xd9\x01\xcf\x86\x80\x67\x07\x3c\xdb\xb7\x80\x67\x07\x3c\x3b\xe0\xd9\x01\xcf\xde\xa6\x1c\xf0\x6c\x08\x78\x76\xc0\xb3\x03\x9e\x1d\xf0\xec\x80\x67\x07\x3c\x3b\xe0\xd9\x01\xcf\x0e\x78\x76\xc0\xb3\x1b\x2d\xe0\xd9\x01\xcf\x76\xed\x77\x84\x67\x3f\xf7\x93\x02\x9e\x1d\xf0\xec\x80\x67\x07\x3c\xdb\xb5\x80\x67\x07\x3c\x1b\x02\x9e\xbd\xbb\x32\xe0\xd9\x9d\xdb\x07\x3c\x3b\xe0\xd9\x01\xcf\x0e\x78\x76\x25\x42\xc0\xb3\x03\x9e\xdd\x68\x01\xcf\x5e\xb7\x80\x67\x07\x3c\xbb\x71\x0e\x01\xcf\x0e\x78\x76\x87\x6c\x01\xcf\x0e\x78\x76\xc0\xb3\x03\x9e\x1d\xf0\xec\xc6\x50\xc0\xb3\x7f\x3e\x3c\x7b\xb5\xec\xdb\x6f\xdf\xbd\xfd\xed\x43\xe1\xf8\x83\xd2\x5d\x30\x66\x83\xec\x8b\xe7\x87\x91\x15\xf2\xb3\x90\x0d\xc0\xfd\xaa\xfd\xec\xc0\x7d\xb5\xf2\x60\xb7\x08\x90\x7f\x80\xfc\x7f\x71\xc8\xff\x85\x9f\x14\x20\xff\x00\xf9\x07\xc8\x3f\x40\xfe\xae\x05\xc8\x3f\x40\xfe\x10\x20\xff\xdd\x95\x01\xf2\xef\xdc\x3e\x40\xfe\x01\xf2\x0f\x90\x7f\x80\xfc\x2b\x11\x02\xe4\x1f\x20\xff\x46\x0b\x90\xff\xba\x05\xc8\x3f\x40\xfe\x8d\x73\x08\x90\x7f\x80\xfc\x3b\x64\x0b\x90\x7f\x80\xfc\x03\xe4\x1f\x20\xff\x00\xf9\x37\x86\xfe\xb7\x21\xff\xfc\x60\x64\x32\x3d\x1c\x6f\x0f\x1f\x16\xf8\xd1\xdf\xd5\x87\x05\x68\xec\xa1\xe0\x7f\x7a\xb0\xc2\xc3\xe7\x0b\xbf\xde\xcf\x17\xae\x38\x0f\x5f\xb5\x96\x1b\xfb\xac\x7c\xc4\xe7\x0b\xbf\xc4\x27\x13\xd5\xca\xb6\xb4\xd4\xf7\x46\xff\x2b\xfb\xd6\x82\x30\xfd\x20\xb3\x96\x98\xd5\x27\xc3\x2f\xf0\x85\x86\xb9\xc3\xe2\x43\xe7\x5e\xed\x6c\xfe\xe6\xbe\xea\x00\x28\x69\x41\xd2\x5e\x5c\x1e\x6c\xaf\x7e\xe1\xa5\x53\xff\x41\x0b\x17\x24\x53\x75\xd8\x59\x2d\x84\xb6\x65\xf7\x36\xed\x87\x75\x77\x27\x3a\x3d\xa9\x65\x97\x5f\xf5\x07\x2e\xae\x67\x7d\x05\xf5\xcf\x9b\xbe\x72\xaf\xcc\xcc\x7d\xe3\x00\x03\x7f\xd9\x2b\xb2\x52\x63\x56\xfd\x6d\xc0\x42\x70\x7d\x73\xe4\xa9\x52\x5a\x7d\x73\xe2\x3b\xff\x1d\x00\x00\xff\xff\x2c\x61\x20\x6f\xec\x81\x00\x00")
+var _config_crd_direct_csi_min_io_directcsidrives_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x5d\x6d\x73\xdb\x38\x92\xfe\xae\x5f\xf1\x94\xee\xaa\x12\xe7\x2c\x3a\x4e\xae\x72\x33\xba\x4a\xa5\x32\xce\x78\x27\x35\x93\x97\x8a\x9d\x6c\xd5\xda\xde\x1d\x88\x6c\x49\x18\x93\x00\x07\x00\x6d\x6b\xb6\xf6\xbf\x6f\x01\x20\xa9\x37\x92\x92\x9c\x38\xc9\x66\xa0\x4f\x21\x5e\x1a\x8d\x46\x77\xa3\xd1\x4f\xbb\xd2\x1b\x0c\x06\x3d\x96\xf3\x0f\xa4\x34\x97\x62\x08\x96\x73\xba\x31\x24\xec\x97\x8e\x2e\xbf\xd3\x11\x97\x07\x57\x87\xbd\x4b\x2e\x92\x21\x8e\x0a\x6d\x64\xf6\x8e\xb4\x2c\x54\x4c\x2f\x68\xcc\x05\x37\x5c\x8a\x5e\x46\x86\x25\xcc\xb0\x61\x0f\x60\x42\x48\xc3\x6c\xb3\xb6\x9f\x40\x2c\x85\x51\x32\x4d\x49\x0d\x26\x24\xa2\xcb\x62\x44\xa3\x82\xa7\x09\x29\x47\xbc\x5a\xfa\xea\x61\xf4\x24\x3a\xec\x01\xb1\x22\x37\xfd\x94\x67\xa4\x0d\xcb\xf2\x21\x44\x91\xa6\x3d\x40\xb0\x8c\x86\x48\xb8\xa2\xd8\xc4\x9a\x27\x8a\x5f\x91\x8e\xfc\x77\x14\x6b\x1e\x65\x5c\x44\x5c\xf6\x74\x4e\xb1\x5d\x7b\xa2\x64\x91\x0f\xb1\x3e\xc0\x93\x2a\xf9\xf3\x7b\x7b\xe1\x06\x1d\x9d\xbc\x7c\x61\xa9\xf6\x00\x20\xe5\xda\xfc\xdc\xd0\xf9\x0b\xd7\xa6\x07\x00\x79\x5a\x28\x96\xae\x71\xd4\x03\x00\xcd\xc5\xa4\x48\x99\x5a\xed\xed\x01\x3a\x96\x39\x0d\x71\x94\x16\xda\x90\xea\x01\xa5\x0c\x1c\x3f\x83\x72\x97\x57\x87\x2c\xcd\xa7\xec\xd0\x13\x8b\xa7\x94\x39\xe9\x02\x80\xcc\x49\x3c\x7f\xfb\xf2\xc3\xe3\x93\xa5\x66\x20\x21\x1d\x2b\x9e\x1b\x27\xcf\x65\x9e\x91\x90\x90\x86\x34\x1c\x13\x38\x7a\xf7\x02\x72\xf4\x9b\x15\x4b\x3d\x3b\x57\x32\x27\x65\x78\x25\x17\x00\x00\x16\xb4\x63\xa1\x75\x65\xad\x7b\x96\x1d\x3f\x0a\x89\x55\x0b\xd2\x30\x53\xaa\x36\x46\x49\xb9\x03\xc8\x31\xcc\x94\x6b\x28\xca\x15\x69\x12\x5e\x51\x96\x08\xc3\x0e\x62\xa2\x62\x0f\x27\xa4\x2c\x19\xe8\xa9\x2c\xd2\xc4\x6a\xd3\x15\x29\x03\x45\xb1\x9c\x08\xfe\x47\x4d\x5b\xc3\x48\xb7\x68\xca\x0c\x95\x07\x34\xff\x71\x61\x48\x09\x96\xe2\x8a\xa5\x05\xed\x83\x89\x04\x19\x9b\x41\x91\x5d\x05\x85\x58\xa0\xe7\x86\xe8\x08\xaf\xa4\x22\x70\x31\x96\x43\x4c\x8d\xc9\xf5\xf0\xe0\x60\xc2\x4d\x65\x15\xb1\xcc\xb2\x42\x70\x33\x3b\x70\x0a\xce\x47\x85\x91\x4a\x1f\x24\x74\x45\xe9\x81\xe6\x93\x01\x53\xf1\x94\x1b\x8a\x4d\xa1\xe8\x80\xe5\x7c\xe0\x58\x17\xce\x32\xa2\x2c\xf9\x2f\x55\xda\x91\xbe\xb7\xc4\xab\x99\x59\xe5\xd0\x46\x71\x31\x59\xe8\x70\x5a\xda\x71\x02\x56\x51\xc1\x35\x58\x39\xd5\xef\x62\x2e\x68\xdb\x64\xa5\xf3\xee\xc7\x93\x53\x54\x4b\xbb\xc3\x58\x95\xbe\x93\xfb\x7c\xa2\x9e\x1f\x81\x15\x18\x17\x63\x52\x6e\x1e\xc6\x4a\x66\x8e\x26\x89\x24\x97\x5c\x18\xf7\x11\xa7\x9c\xc4\xaa\xf8\x75\x31\xca\xb8\xb1\xe7\xfe\x7b\x41\xda\xd8\xb3\x8a\x70\xe4\x5c\x05\x46\x84\x22\x4f\x98\xa1\x24\xc2\x4b\x81\x23\x96\x51\x7a\xc4\x34\xdd\xf9\x01\x58\x49\xeb\x81\x15\xec\x76\x47\xb0\xe8\xe5\x56\x07\x7b\xa9\x2d\x74\x54\x3e\x08\xd8\xc2\x3a\x4f\x72\x8a\x57\x2c\xd4\xce\xe7\x63\x1e\x3b\x03\x89\x96\x08\x35\x1b\x2a\x80\xd2\xd5\x1c\x9d\xbc\x7c\x73\x2d\x28\x59\xed\x5d\x61\xc1\x9e\x05\x57\x94\xac\x8d\xf2\x3b\x1a\x49\x99\x12\x5b\xb5\x4d\xc7\xdc\x29\xe3\xc2\xac\x53\x67\x49\xe2\xae\x03\x96\xbe\x6d\xe5\xb0\x43\xbc\x9d\xe2\x04\x50\x29\x0f\x25\xc7\x52\x65\xcc\x6c\xd8\xde\xbb\xe5\xd1\x2b\xe2\x1d\xfb\xc6\x92\xa4\x53\x32\x95\x35\xc8\xba\x5b\xde\x00\x30\xe6\x29\xe9\x99\x36\x94\x35\xf5\x6e\xd8\x2d\x2c\x23\x31\x75\xcd\x6c\x3e\x07\x00\xc8\x64\x21\xcc\x9b\x7c\xe1\xaa\x5d\xfd\x71\x43\x59\x4b\xd7\x46\xc6\xaa\x01\x4c\x29\x36\x6b\xec\xbf\x19\xd8\xbb\x5c\x09\x32\xa4\x07\xf6\xb2\x1c\x94\x33\x8c\xcc\x78\xdc\xc6\xb0\xf3\x14\xb7\x12\x55\x5e\xa8\xc9\xad\x44\xd5\xaa\x53\x95\x09\x2c\x13\x1d\xac\xd8\xd1\x56\xe6\x6e\x98\x29\xf4\xf6\x06\xef\x86\xaf\xe8\x64\xab\x12\xb6\x2b\x20\x4b\x53\x19\x5b\xd7\x79\xc4\x72\x16\x73\x33\x1b\xf6\x1a\x14\xcc\x1a\x0b\xb8\x30\x4f\xfe\xb7\x45\x34\xf6\x76\x9c\x90\x5a\xe9\x8d\xa5\xf0\x06\xad\x87\xbd\xad\x35\x6b\x69\xd3\xfd\xa3\x8a\x84\x25\x66\x18\x17\x76\xcf\x86\xf1\x54\x5b\xbe\x20\x05\x81\x59\x4f\x67\x7c\x64\x40\x88\x0b\xa5\xd6\xaf\x8f\xb9\x8c\xa9\x0e\x21\x9e\xbf\x7d\x89\x2a\x14\x8d\x30\x18\x0c\x70\x6a\x9b\xb5\x51\x45\x6c\xc0\xb5\xdb\x94\x48\x28\x71\x2b\xf9\x13\x6d\x24\x5b\x68\xcb\x04\x98\xf0\xaa\x0e\xe6\xef\xb1\x31\xa7\x34\x41\xce\xcc\x14\x91\x3f\xdd\x68\x2e\x90\x08\x38\x96\x0a\x74\xc3\xb2\x3c\xa5\xfd\x56\x9d\xc4\xb1\x94\xe5\x59\x7b\xc6\xfe\x09\x00\x38\x38\xc0\xbb\xfa\x7e\x75\xab\xc9\x91\x26\x75\xe5\xc3\x66\x17\x00\x35\x92\x1c\x4b\x79\x4f\x57\x32\xf2\xf2\x88\x2a\x82\x3f\x0b\x79\x2d\x9a\x58\x75\x7c\x30\xd5\x62\x39\xe7\xfd\xe7\x57\x8c\xa7\x6c\x94\xd2\x79\x7f\x1f\xe7\xfd\xb7\x4a\x4e\x14\x69\x1b\xbf\x9e\xf7\x7d\xa0\x74\xde\x7f\x41\x13\xc5\x12\x4a\xce\xfb\xd5\x72\xff\x93\x33\x13\x4f\x5f\x91\x9a\xd0\xcf\x34\x7b\x6a\x17\x69\xa6\xbf\x34\xfe\xc4\x28\x66\x68\x32\x7b\x9a\xd9\x89\x35\x2d\xeb\x3c\x4e\x67\x39\x3d\xcd\x58\xbe\xd4\xf8\x8a\xe5\x9b\xa9\xd7\x4a\xa6\x71\x76\x61\x2f\xe9\xab\xc3\xa8\x6e\xc3\xaf\xbf\x69\x29\x86\xe7\xfd\xb9\x44\xf6\x65\x66\xd5\x37\x37\xb3\xf3\x7e\x23\xd5\x25\x56\x87\xe7\x7d\xc7\xec\x79\x1f\x4b\x5b\x1e\x9e\xf7\x2d\x5b\xb6\x59\x49\x23\x47\xc5\x78\x78\xde\x1f\xcd\x0c\xe9\xfd\xc3\x7d\x45\xf9\xbe\x8d\xe3\x9f\xce\x57\x3d\xef\xff\xda\xbc\x05\x51\xed\x58\x9a\x29\x29\xaf\x77\x1a\xff\x6a\x62\xad\xfb\x26\x02\x52\xa6\xcd\xa9\x62\x42\xf3\xea\x01\xd5\x3c\x6e\xc5\x4c\xd7\xa7\x81\xeb\x32\x96\xd6\x06\xc6\x36\xd8\xaf\x7a\x33\x2d\x44\x01\x53\x53\xa1\xc4\xc7\x87\x52\x50\xe9\x1c\x61\x24\x98\x70\x9b\x8c\x4a\x5b\xf5\x21\xfd\x88\x70\x3d\xa5\x0e\xa2\x53\x42\x21\x12\x52\xe9\xcc\x46\xb1\xf1\xdc\xa7\x4c\x99\x98\xd8\xb0\x11\x2f\xad\x53\x60\xce\xec\x85\x34\xb8\xb4\xb6\xb0\x0f\xd3\x45\xb5\xd0\x55\x48\xec\xf6\x67\x39\x70\x5f\xd6\xaf\xb8\x33\xa8\xc8\x83\x6b\xb0\x38\xa6\xdc\x58\x23\x89\x5a\x08\x56\x6e\xd6\x06\xb2\x03\x4b\xf1\xb6\xb7\x6e\x46\x5a\xb3\xc9\x76\x07\x57\x8e\x75\x1c\x62\x5a\x64\x4c\x40\x11\x4b\x2c\x9f\xf3\x3e\x91\xb8\x28\xb2\x65\x39\x4f\xd3\xbb\x64\x36\x92\x85\x77\x7e\xf3\x73\x2c\x8f\xca\x86\xfe\x23\x02\x13\x70\x86\x53\x6e\xa0\x4d\x18\x19\xbb\xf9\x85\xc4\xc4\x4c\x87\x78\xfc\xe8\xff\x9e\x7c\x77\x5b\x59\x78\xaf\x48\xc9\x5f\x48\x90\x72\xce\x71\x2b\xb1\xac\x4f\x5b\x78\xce\xb8\xfd\x45\x55\x2c\x1f\x4d\xea\x31\x1d\xfa\x57\x5e\x09\x73\xcd\xbb\x66\x1a\x9a\x0c\x46\x4c\x53\x82\x22\xb7\x72\xb2\x17\x02\x17\xda\x30\x11\xd3\x3e\xf8\x78\xb7\x45\x78\xed\xd7\xd3\x19\x0e\x1f\xed\x63\x54\x1e\xc5\xba\x47\x3f\xbb\xb9\x88\xd6\xb7\xd8\x45\xf9\xfb\xfd\x15\xfe\xb9\x86\x3d\x6a\x39\x76\xfa\x8a\x6b\x6e\xa6\x50\xe4\x6f\xe2\xf2\x19\xdd\x75\x13\xaf\xdc\xc6\x54\xef\x7b\x93\x75\x34\x07\x21\x00\x00\x64\x5c\xf0\xac\xc8\x86\x78\xd8\xa9\x2e\xcd\xb1\x0a\x00\x00\x8a\x98\xde\x52\x47\xfc\xd0\x79\x58\xc2\xac\x73\x9d\x28\x96\xd9\x00\x2c\x06\x4f\xec\x43\x71\xcc\x49\x6d\x63\x40\x56\x04\x25\x41\x1b\x6c\x2c\xc9\xfa\x9e\x2e\xbd\xe8\x82\x49\xbd\x55\x32\x29\x62\x52\xba\x95\xa2\x1c\xd7\x2f\xc0\x39\x29\x27\x01\x6f\x8b\x3e\xcb\x02\xba\xb1\x47\x56\xe7\x2c\xec\x6d\xdd\x4a\x32\x23\x26\xb8\x98\xe8\x92\x45\xae\xbd\x9b\xf3\x57\xfc\xf5\x94\xdc\xed\xe3\xb2\x36\x25\x2d\xe5\x76\xa1\x79\x42\x4d\xaf\xc4\xea\xc7\x30\x29\x98\x62\xc2\x10\x25\xd6\x79\x5a\x87\x51\xd2\x58\x70\xf0\x6c\xfe\xae\xdf\xe0\x3b\x80\xd3\x9a\x37\xb7\xd5\x32\x47\xe0\xfc\xce\x16\x0e\xe7\xf0\xe1\xa3\x0e\x0d\xab\x47\xb5\x0c\xc9\x99\xb1\x89\xa2\x21\xfe\x7e\xf6\x7c\xf0\x37\x36\xf8\xe3\xe2\x7e\xf9\x8f\x87\x83\xef\xff\xb1\x3f\xbc\x78\xb0\xf0\x79\xb1\xf7\xec\xbf\x6f\xeb\xda\x9a\x1e\x0c\x2d\xaa\xea\x87\xd6\x11\x72\xa5\x0d\xfb\x90\xc2\x19\xe0\xa9\xb2\x19\xad\x63\x96\x6a\xda\xc7\x7b\xe1\x2e\xbf\x36\x41\x91\x28\xb2\xb6\x45\x07\xe8\x5b\x52\xfd\xf6\x6e\xb7\x46\x7b\x7f\xb9\xf6\x47\xbd\x37\xb7\x11\x88\x1d\x68\x37\xbe\xe0\xcf\x16\xf2\x46\x70\x7e\xd8\xc6\xca\x51\x19\x9f\x47\xb1\xcc\x0e\xea\xfe\x76\xc5\xb3\x8f\x88\x57\x4c\xcc\x30\x77\xb6\x3e\x7a\x5e\xb5\x08\x6d\x48\x18\xb0\x58\x49\xad\xeb\x64\x5a\xbb\x31\xa7\xfc\x92\x50\x87\xd9\xde\xb5\x8f\x28\x66\xee\xe5\xa1\x46\xdc\x28\xa6\x66\xf3\xdd\x68\xc4\x4c\xb8\xb4\x98\xa6\x71\x91\xb6\x92\xbd\xaf\x89\x10\x09\x99\xd0\xfa\x1d\xb1\xe7\x3d\x3e\x1b\xf1\x94\x9b\x19\x8c\x44\x42\xb1\x14\xe3\x94\xbb\xc7\x51\xfb\x65\x91\xe5\x52\x19\x26\x8c\x37\x63\x45\x13\xba\x01\x37\xc8\x6c\xe8\x4b\x1a\x5c\xe3\x7e\x22\xf4\xe1\xe1\xa3\xc7\x27\xc5\x28\x91\x19\xe3\xe2\x38\x33\x07\x7b\xcf\xee\xff\x5e\xb0\xd4\x7a\xcc\xe4\x35\xcb\xe8\x38\x33\x7b\x5b\x04\x07\x87\x4f\x36\xda\xe1\xfd\x33\x6f\x6d\x17\xf7\xcf\x06\xe5\xbf\x1e\x54\x4d\x7b\xcf\xee\x9f\x47\x9d\xfd\x7b\x0f\x2c\x6b\x0b\x36\x7c\x71\x36\x98\x1b\x70\x74\xf1\x60\xef\xd9\x42\xdf\xde\x2d\xcd\xb9\x39\x8f\x00\x00\xc0\xa0\x21\xbc\x6e\x1c\x56\x06\x6c\x8d\x7d\xfe\x72\x69\xec\xf2\x47\xdf\xd8\xd5\xf2\x6c\xea\x48\xb1\x75\x27\x7d\xd6\x13\x3e\x19\xcb\x07\x97\x34\x6b\xf0\x63\x2d\xab\xb7\xe5\x8c\x32\x96\x37\x65\x1a\x4f\x5a\xbc\xe4\x72\x6a\xa5\x35\xa3\x52\x9a\x45\x6f\x87\xe3\xec\x4a\xe7\x75\x4d\x53\x44\x77\x91\x83\x49\xe5\x84\xc7\x2c\xfd\x21\x95\xf1\xe5\x09\xff\x83\x3e\x25\xed\x4c\x26\x94\xbe\x2e\xb2\x11\xa9\x9d\xf6\xda\x9d\x77\x6c\xcd\x0c\x6d\x91\xf6\xdd\x56\xed\x3a\xf2\x8c\x5d\x39\xc6\x0e\x0e\xac\x17\xb5\x7e\x6b\xa7\x49\x39\x53\xc6\xd9\xf4\xeb\x22\x1b\xee\x24\x7a\x9b\x56\xda\x6d\xa9\xe9\x4c\xdf\x99\x22\x28\x29\xcd\xdb\x6a\x2f\x3b\xb1\xa5\x49\x71\x76\x1b\x1d\x32\x32\x97\xa9\x9c\xcc\x3e\x3f\x8a\x60\xa4\x61\xe9\xa7\x37\xd5\xb6\x54\xb2\x3d\xe9\xcd\x09\xe4\xf5\xd9\x83\x1a\x6e\x5a\x68\xb2\x4f\x82\x5e\x2b\x21\xff\x22\x1c\xc2\xa8\x82\x7c\x83\x91\xca\xa6\x12\x30\xb6\x71\xdb\x12\xb8\x3c\x22\x13\xb0\xe5\x80\x2d\x03\x08\xd8\x72\xc0\x96\x81\x6e\x43\x45\xc0\x96\x03\xb6\xbc\x6d\x9c\x87\x80\x2d\xe3\x9b\xc0\x96\xe3\x98\xb4\x3e\xe5\x4d\x91\xdd\xd2\xf2\xcf\xeb\x81\xf5\xa2\x7e\x2e\x0c\x27\xb5\xd3\xeb\x2b\xe0\xd9\x01\xcf\x46\xc0\xb3\x03\x9e\x0d\x20\xe0\xd9\x01\xcf\x0e\x78\x76\xc0\xb3\x81\x80\x67\x6f\xd6\x91\x80\x67\x07\x3c\xbb\xf9\xea\x0f\x78\x76\x6b\x77\xc0\xb3\x03\x9e\x1d\xf0\xec\xa6\x7b\x27\xe0\xd9\xdb\xad\x1e\xf0\xec\x80\x67\x07\x3c\x3b\xe0\xd9\x01\xcf\xfe\xf2\x78\xf6\xa3\x80\x67\x07\x3c\x1b\x01\xcf\x0e\x78\x36\xd0\x6d\xa8\x08\x78\x76\xc0\xb3\xb7\x8d\xf3\x10\xf0\x6c\x04\x3c\x3b\xe0\xd9\x01\xcf\x0e\x78\x76\xc0\xb3\x03\x9e\xbd\xf6\x0b\x78\x76\xdb\xc1\x05\x3c\xbb\x51\x2c\x01\xcf\x06\x02\x9e\xdd\xad\x23\x01\xcf\x0e\x78\x76\xf3\xd5\x1f\xf0\xec\xd6\xee\x80\x67\x07\x3c\x3b\xe0\xd9\x4d\xf7\x4e\xc0\xb3\xb7\x5b\xfd\x9b\xc5\xb3\xeb\x69\xef\xdf\xbf\x7c\xf1\xed\x43\xe1\xec\x37\xa9\xda\x60\xcc\x05\xb2\x8f\x1f\xed\x46\x96\x8b\x3b\x21\x1b\x80\xfb\xfa\xf7\xd9\x81\xfb\x72\xe6\xce\x66\x11\x20\xff\x00\xf9\x7f\x71\xc8\xff\x71\x80\xfc\x03\xe4\x8f\x00\xf9\x07\xc8\x1f\xe8\x36\x54\x7c\xf3\x90\x3f\x5d\xb1\xb8\x60\x86\x86\x3b\x71\x9c\xb1\x9b\x0f\x32\x2d\x32\xd2\x9d\x17\xc9\x8e\x11\xdd\xd7\x59\x7e\x30\xb2\x91\xc7\x2b\x99\xdc\xb2\x86\xe0\xcb\x55\x2f\xf8\x1d\x77\x96\x2f\x2c\x09\xf4\x78\x71\x7c\x2d\x4e\x59\x7e\x1b\x89\x8c\x5d\x52\x89\xa2\x56\x7b\x6a\xa4\x0a\x97\x7a\xfe\x7f\x14\x42\x93\x29\x09\xc0\xb0\xcb\xf2\x02\x4b\x68\xcc\x8a\xd4\x44\x8d\x73\xbb\xcf\x02\x00\xd8\xe4\xc8\x06\xc9\x6d\xdd\x9b\x14\x70\x1b\x45\x5c\x3a\xfa\xe6\xa0\x73\xbb\xb0\x69\x97\xc5\x62\x15\x0f\x37\x10\x68\x3f\x6b\xf8\xa7\xe1\xe7\xe2\x55\xd1\x38\xe5\xe2\xf2\xe3\xf8\xd5\x14\x1b\xa9\x3e\x0f\xcb\x1d\x1e\x10\xa1\xd0\x67\xfb\x93\xeb\x90\x63\x21\x6c\xcc\x98\x14\x2e\x1d\xba\xcb\x75\x12\x6a\x84\xbe\xb6\x1a\x21\xe7\xf7\x7e\xb4\xff\xa5\x97\xb9\x75\x95\xd0\x0f\x73\x1a\xf5\x66\x1d\xa2\x66\xe1\x77\xf7\x1c\x29\x05\xae\xc8\xbf\xdf\x5a\x6a\x63\x3c\x7a\x55\x67\x1c\xfc\x4c\xc7\x20\xae\x5c\x08\x12\xdd\xa2\xa4\x42\x8e\xc7\x9a\xcc\x56\xb0\xc4\x1b\x37\xb4\x2a\x9d\xb0\xa5\x20\xe5\xec\x0a\xba\x99\xb3\x66\x63\xf7\x4e\x34\x51\x1b\xa6\xea\x79\x6e\xf7\x1f\x03\xad\x6e\xf6\x7a\xba\xc3\xb7\x7e\x9a\x15\xfc\x11\x0c\xef\x22\xc1\xee\xa5\xdc\xd8\x65\xf7\xd5\xd8\xe1\xd9\xf9\x74\xd9\xf1\x8e\xd8\xaf\xcb\x4f\x86\x1a\xbb\x50\x63\x17\x6a\xec\x42\x8d\x5d\xa8\xb1\x0b\x35\x76\xa1\xc6\x2e\xd4\xd8\xb5\xde\xc6\xa1\xc6\x2e\xd4\xd8\x85\x1a\xbb\xdd\x5c\x5b\xa8\xb1\x6b\x1e\x10\x6a\xec\x42\x8d\x5d\xa8\xb1\x5b\xed\xfb\x33\xd6\xd8\x65\x3b\x97\x02\x25\xbb\x17\xb8\xfd\x79\x2a\xf9\xba\x61\xb4\xbb\x80\xd0\x6e\x07\x9f\x75\xbf\x71\x3b\x61\xb3\xcd\x90\xd9\xa6\x10\x72\x03\x54\xb6\x39\x8c\xdd\xb4\x40\x2b\x3c\xb6\x09\x6a\xea\x84\xc5\x3e\x9e\xaf\x4e\x28\x6c\x13\x6f\x9b\x20\xb0\x8f\x65\xaf\xc3\x61\xdd\x55\x81\xea\x94\x58\x6a\xa6\x1b\xec\xc4\xb9\x84\x9f\xdc\xc8\x15\x97\xe0\xa7\xbb\xc7\xb8\x4f\x83\x9c\x44\xaf\xa2\xe7\xd1\xbb\xe8\x74\x5d\xe1\x01\xa9\xf0\xfa\xc3\xab\x7a\x56\x2a\x27\xbb\x9a\x45\xac\xb8\xb1\xf5\x85\x7f\x65\xca\xc5\xf8\xff\x19\xe8\x1f\x25\x9c\xfd\xa8\x94\x54\xfa\xae\xd4\x3a\x27\x91\x70\x31\x39\x71\x0a\x7a\x87\xab\xa8\x98\x84\x61\x13\x7a\xaf\x29\xb9\xb3\x55\xe4\x35\xa9\x37\xe2\x27\x59\xdc\xdd\x4e\x14\xd5\x88\xdc\x1d\xcb\xcc\x26\x60\x6d\xe6\xa2\x50\x9f\xdf\x69\x70\xd9\xa6\x75\x7f\xae\x8a\x76\xa6\xcd\xae\x55\xe7\xc9\xce\x81\x47\xa8\x9b\xff\x7a\xeb\xe6\x4f\xed\x7b\xf4\x74\x96\xdf\x72\xe6\x2d\xea\xe6\xbf\x44\xad\x7e\x39\x93\x92\xb6\x79\xcd\x91\xcd\x57\x56\xe4\x4f\x2c\x79\x23\xd2\xd9\x6e\x7b\xf8\x02\x7f\x1a\xa0\xaf\x59\xfe\x46\xec\xc6\xe6\x37\xf7\xe7\x04\x40\x41\xb6\x9e\xf8\xf8\x64\x67\x7d\xf5\x13\x4f\x9c\xf8\x77\x9a\x78\x45\x22\x91\xbb\x9d\xd5\x15\x57\xa6\x60\xe9\x6e\x87\x75\x7d\xcd\x93\x1d\x56\xf9\xaa\xff\xb2\xc2\xb5\xcc\x53\xb1\x1e\xe6\xf3\x19\x2c\xd7\x50\x16\xd7\xa3\xef\x93\x9e\x79\x5a\x28\x96\x96\x9f\x0b\xe5\x11\x38\xbb\xe8\x79\xaa\x94\x94\x7f\xec\xe0\x1b\xff\x3d\x00\x87\xf2\xc2\xc9\x65\x90\x00\x00")
 
 func config_crd_direct_csi_min_io_directcsidrives_yaml() ([]byte, error) {
 	return bindata_read(
@@ -50,7 +50,16 @@ func config_crd_direct_csi_min_io_directcsidrives_yaml() ([]byte, error) {
 	)
 }
 
-var _config_crd_direct_csi_min_io_directcsivolumes_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5c\x5d\x8f\xdb\xb8\xd5\xbe\xf7\xaf\x38\xf0\xfb\x02\x99\x49\x2d\x4d\x9c\x14\xe9\xae\x81\x20\x08\x26\x4d\x11\x64\x53\x04\x99\x69\x2e\x3a\x9e\x76\x8f\xa4\x63\x99\x3b\x12\xa9\x90\x94\x33\xde\xa2\xff\xbd\x38\xa4\x64\xc9\xb6\xe4\x4c\x82\xee\x45\x17\xe4\xd5\x98\xa4\x1e\x1e\x9e\x6f\x3e\x17\x33\x89\xa2\x68\x82\x95\xf8\x44\xda\x08\x25\x17\x80\x95\xa0\x7b\x4b\x92\x7f\x99\xf8\xee\x07\x13\x0b\x75\xb1\x99\x4f\xee\x84\xcc\x16\x70\x59\x1b\xab\xca\x8f\x64\x54\xad\x53\x7a\x4d\x2b\x21\x85\x15\x4a\x4e\x4a\xb2\x98\xa1\xc5\xc5\x04\x00\xa5\x54\x16\x79\xda\xf0\x4f\x80\x54\x49\xab\x55\x51\x90\x8e\x72\x92\xf1\x5d\x9d\x50\x52\x8b\x22\x23\xed\xc0\xdb\xa3\x37\x4f\xe2\xe7\xf1\x7c\x02\x90\x6a\x72\x9f\x5f\x8b\x92\x8c\xc5\xb2\x5a\x80\xac\x8b\x62\x02\x20\xb1\xa4\x05\x64\x42\x53\x6a\x53\x23\x36\xaa\xa8\x4b\x32\xb1\x9f\x88\x53\x23\xe2\x52\xc8\x58\xa8\x89\xa9\x28\xe5\xc3\x73\xad\xea\xaa\xfd\xa2\xbf\xc1\x63\x35\x02\xfa\xcb\xbd\x76\x9b\x2e\xaf\xde\x7e\x72\xb0\x6e\xa5\x10\xc6\xbe\x1b\x5a\xfd\x49\x18\xeb\x76\x54\x45\xad\xb1\x38\x16\xca\x2d\x1a\x21\xf3\xba\x40\x7d\xb4\x3c\x01\x30\xa9\xaa\x68\x01\x97\x45\x6d\x2c\xe9\x09\x40\xa3\x08\x27\x53\xd4\x5c\x75\x33\xc7\xa2\x5a\xe3\xdc\xa3\xa5\x6b\x2a\xd1\x8b\x0c\xa0\x2a\x92\xaf\x3e\xbc\xfd\xf4\xec\x6a\x6f\x1a\x20\x23\x93\x6a\x51\x59\xa7\xd4\x03\xb1\x21\x23\xa9\x2c\x19\xf0\x62\xc0\xe5\xc7\xd7\xa0\x92\x5f\x58\x39\xbb\xef\x2b\xad\x2a\xd2\x56\xb4\xda\xf1\xa3\xe7\x24\xbd\xd9\x83\xd3\x1e\xb1\x40\x7e\x17\x64\xec\x1d\x64\xc0\xae\xa9\xbd\x1a\x65\xcd\x1d\x40\xad\xc0\xae\x85\x01\x4d\x95\x26\x43\xd2\xfb\xcb\x1e\x30\xf0\x26\x94\xad\x78\x70\x45\x9a\x61\xc0\xac\x55\x5d\x64\xec\x54\x1b\xd2\x16\x34\xa5\x2a\x97\xe2\xd7\x1d\xb6\x01\xab\xdc\xa1\x05\x5a\x6a\x8c\xd4\x0d\x21\x2d\x69\x89\x05\x6c\xb0\xa8\x69\x06\x28\x33\x28\x71\x0b\x9a\xf8\x14\xa8\x65\x0f\xcf\x6d\x31\x31\xbc\x57\x9a\x40\xc8\x95\x5a\xc0\xda\xda\xca\x2c\x2e\x2e\x72\x61\xdb\xe0\x48\x55\x59\xd6\x52\xd8\xed\x85\xf3\x73\x91\xd4\x56\x69\x73\x91\xd1\x86\x8a\x0b\x23\xf2\x08\x75\xba\x16\x96\x52\x5b\x6b\xba\xc0\x4a\x44\x4e\x74\xe9\x02\x24\x2e\xb3\xff\xd3\x4d\x38\x99\x47\x7b\xb2\xda\x2d\xbb\x87\xb1\x5a\xc8\xbc\xb7\xe0\x7c\xf5\x84\x05\xd8\x5b\x41\x18\xc0\xe6\x53\x7f\x8b\x4e\xd1\x3c\xc5\xda\xf9\xf8\xe7\xab\x6b\x68\x8f\x76\xc6\x38\xd4\xbe\xd3\x7b\xf7\xa1\xe9\x4c\xc0\x0a\x13\x72\x45\xda\x1b\x71\xa5\x55\xe9\x30\x49\x66\x95\x12\xd2\xba\x1f\x69\x21\x48\x1e\xaa\xdf\xd4\x49\x29\x2c\xdb\xfd\x73\x4d\xc6\xb2\xad\x62\xb8\x74\x19\x03\x12\x82\xba\xca\xd0\x52\x16\xc3\x5b\x09\x97\x58\x52\x71\x89\x86\x7e\x73\x03\xb0\xa6\x4d\xc4\x8a\x7d\x98\x09\xfa\xc9\xee\x70\xb3\xd7\x5a\x6f\xc1\x58\xb4\xb5\x39\x61\xb1\x83\x08\xbd\x72\xfb\x0f\xe3\x94\x2f\xaf\x4b\x17\x24\xf1\x1e\xd4\x70\xb0\xf2\xc0\x0d\x8a\x02\x93\x82\x2e\xb1\xc2\x54\xd8\xed\xe1\x06\x00\x8f\xb9\xe0\xa0\x78\xfe\xc7\xa3\x55\x7f\x21\x0e\x98\xdc\xe5\xa7\xfe\x48\x95\xcc\x44\x2f\xc5\xf7\x87\xb0\x54\x0e\x4c\x1f\x5c\x7b\x7a\xd9\x42\xb8\xfa\x80\x42\xf2\xa5\x2d\x8a\xc2\xb0\x5c\xa0\x24\x01\x72\x1a\xb7\x3e\x59\x10\xa4\xb5\xd6\xc7\x1e\xd5\x69\x99\x76\x59\xe5\xd5\x87\xb7\xd0\x16\xa9\x18\xa2\x28\x82\x6b\x9e\x36\x56\xd7\xa9\xe5\xe0\xe0\x4b\xc9\x8c\x32\x77\x92\x4f\xcd\x83\xb0\xb5\x61\x21\x38\x0b\xa1\xd6\xb8\x05\xf4\xae\xbd\x12\x54\x64\x50\xa1\x5d\x43\xec\xed\x1b\x77\x0a\x89\x01\xde\x28\x0d\x74\x8f\x65\x55\xd0\x6c\x10\x97\x55\x0b\x6f\x94\x6a\x8c\xed\x05\xfb\x97\x5b\xba\xb8\x80\x8f\xbb\x90\x73\xa7\xa9\xc4\x90\xde\xf8\x82\xea\x72\xe2\x20\xe4\x4a\xa9\x47\xa6\xd5\x91\xd7\x47\xdc\x02\xbe\x93\xea\x8b\x1c\x12\xd5\xc9\x81\x9a\x86\xac\x05\xb0\x9c\xbe\x6a\x7d\x68\x39\x9d\xc1\x72\xfa\x41\xab\x5c\x93\xe1\xaa\xc6\x13\x9c\x3b\x97\xd3\xd7\x94\x6b\xcc\x28\x5b\x4e\xdb\xe3\xfe\x50\xa1\x4d\xd7\xef\x49\xe7\xf4\x8e\xb6\x2f\xf8\x90\x61\xfc\xbd\xfd\x57\x56\xa3\xa5\x7c\xfb\xa2\xe4\x0f\x77\x58\x5c\x81\xaf\xb7\x15\xbd\x28\xb1\xda\x9b\x7c\x8f\xd5\xd7\xd1\x77\x4e\x66\xe0\xe6\x96\xe3\x76\x33\x8f\x3b\xc7\xfb\xf9\x17\xa3\xe4\x62\x39\xed\x34\x32\x53\x25\xbb\x6f\x65\xb7\xcb\xe9\x20\xea\x9e\xa8\x8b\xe5\xd4\x09\xbb\x9c\xc2\xde\x95\x17\xcb\x29\x8b\xc5\xd3\x5a\x59\x95\xd4\xab\xc5\x72\x9a\x6c\x2d\x99\xd9\x7c\xa6\xa9\x9a\x71\x71\x7f\xd1\x9d\xba\x9c\xfe\x3c\x7c\x05\xd9\xde\x58\xd9\x35\x69\xef\x77\x06\xfe\x3d\x24\xda\x78\x22\xf0\xa3\x40\x63\xaf\x35\x4a\x23\xda\xd6\x6a\x78\xdf\x41\x98\x1e\x7f\xc6\xf1\xe3\xcb\xab\xb1\x60\x79\xc2\x05\x67\x7b\x99\x11\x50\x00\xbb\x43\xe1\xb8\xe3\x92\xc1\x21\xee\x7d\x92\x4b\x36\x4a\x77\xc9\xb8\x89\x55\x5f\xe5\x13\x82\x2f\x6b\x3a\x01\xba\x26\xa8\x65\x46\xba\xd8\x72\x61\x4b\xbb\x9c\xb2\x46\x99\x73\x25\x81\xb7\x9c\x14\xd0\x85\x3d\x57\x99\x3b\x8e\x85\x19\x7f\x38\x8e\x5a\x9b\xb6\x4a\xba\xfb\xb1\x04\xee\x17\xe7\x15\x1f\xfb\x0d\xbc\x2b\xb4\x69\x4a\x95\xe5\x20\x89\x47\x00\xdb\x34\xcb\xb5\x2d\x62\xc4\x91\x7d\x23\xe5\xa6\x1b\x25\x19\x83\xf9\xc3\x0c\xd7\xec\xf5\xad\xc0\xba\x2e\x51\x82\x26\xcc\x58\xce\x6e\x4d\x66\x22\x45\x3b\x76\x9c\xc7\xf4\x29\x19\x13\x55\xfb\xe4\xd7\xd9\xb1\x31\x15\x77\x03\x09\x71\x92\x74\x81\xd3\x5c\x60\x4c\x19\x25\xde\xff\x44\x32\xb7\xeb\x05\x3c\x7b\xfa\xa7\xe7\x3f\x7c\xaf\x2e\x7c\x56\xa4\xec\x2f\x24\x49\xbb\xe4\xf8\x20\xb5\x1c\x7f\xd6\xeb\x70\xdc\xfd\xe2\xb6\xbc\xc7\xf9\x6e\xcf\x09\xff\x6b\x4a\x42\xe7\x79\x5f\xd0\x80\x21\x0b\x09\x1a\xca\xa0\xae\x58\x4f\x5c\x10\x84\x34\x16\x65\x4a\x33\x10\xab\x6f\x3b\x44\xec\xf2\x7a\xb1\x85\xf9\xd3\x19\x24\x8d\x29\x8e\x33\xfa\xcd\xfd\x6d\x7c\x7c\xc5\x53\xc8\x3f\xce\x0e\xe4\x17\x06\xd8\xd4\x6a\xe5\xfc\x15\xbe\x08\xbb\xe6\x3e\xd1\x55\xe2\xa6\xb3\x3e\x55\x89\x61\xbf\x1a\xd3\xee\xde\x5f\x8b\x8e\xe1\x26\xc4\x8f\x52\x48\x51\xd6\xe5\x02\x9e\x9c\x74\x97\xe1\x5e\xc5\x0f\x4d\x68\x1e\xe8\x23\x7e\x6b\xd7\x96\x20\x27\xd7\x5c\x63\xc9\x0d\x58\x0a\x22\xe3\xde\x71\x25\x48\x3f\x24\x80\x58\x05\x0d\x20\x37\x1b\x7b\xba\x7e\x64\x9a\x2c\xda\x0b\xa9\x0f\x5a\x65\x75\x4a\xfa\xb0\x1d\xef\x86\x5a\x01\x5b\x43\xac\x44\xda\x33\x9b\x6b\x62\x5d\x2c\xfa\x87\x17\xd0\x3d\x9b\x6c\xf7\x8c\xe1\x6a\x3d\x0a\x59\x12\x4a\x21\x73\xd3\x88\xc8\x3d\x3d\xa7\x39\x5f\xe2\xbf\xac\xc9\x55\x1f\xf7\x90\x6b\xb0\xb4\xbb\x85\x11\x19\x69\x1a\x87\x45\xc8\x6b\xd4\x28\x2d\x51\xc6\xc9\x93\x13\x46\x83\xd1\x4b\xf0\xd8\xb5\xfa\x5f\xc9\x1d\xe0\x13\x8e\x4f\xc1\x7c\xd5\xe6\xd9\xe0\xf2\xce\x03\x12\xce\xfc\xc9\xd3\x13\x1e\xb6\xdb\x35\xb2\xa5\x42\xcb\x6f\xc7\x05\xfc\xe3\xe6\x55\xf4\x77\x8c\x7e\xbd\x3d\x6b\xfe\x78\x12\xfd\xf8\xcf\xd9\xe2\xf6\x71\xef\xe7\xed\xf9\xcb\xff\xff\xde\xd4\x36\xf4\x64\xe8\xc6\x9e\xab\x36\xe5\xb3\xed\x90\x5b\x6f\x98\xb9\xda\xaa\x56\x70\xad\xf9\x91\xfb\x06\x0b\x43\x33\xf8\x9b\x74\xc5\x6f\x4c\x51\x24\xeb\x72\xec\xd0\x08\xa6\x0c\x35\xdc\x13\xb9\x65\x77\xc6\xf8\x7a\x73\xf6\xf7\xaa\xc4\x6d\x78\x88\x42\x5c\x47\xab\x56\xfd\x7c\xd6\x7b\x4a\x82\xcb\xc3\xdc\x2b\xc7\x4d\x7f\x1e\xa7\xaa\xbc\xe8\x9e\x9a\xa3\x8e\xc7\x8f\x88\xf7\x28\xb7\xd0\x25\x5b\xdf\x3d\x1f\x46\x84\xb1\xdc\x7f\x63\xaa\x95\x31\xbb\xf7\xf5\x78\x30\x17\xe2\x8e\x60\xd7\x66\xfb\xd4\x9e\x50\x8a\xee\xe5\xa1\x13\x61\x35\xea\x6d\xef\xb9\x05\x29\x4a\xf7\x52\x36\xb4\xaa\x8b\x51\xd8\x33\x43\x04\xb1\x54\x19\x1d\xd7\x88\x73\x9f\xf1\x31\x11\x85\xb0\x5b\xce\xe9\x19\xa5\x4a\xae\x0a\xe1\x1e\x47\xe3\xc5\xa2\xac\x94\xb6\x28\xad\x0f\x63\x4d\x39\xdd\x83\xb0\x50\x72\xeb\x4b\x86\x0b\xc7\x59\x26\xcd\x7c\xfe\xf4\xd9\x55\x9d\x64\xaa\x44\x21\xdf\x94\xf6\xe2\xfc\xe5\xd9\xe7\x1a\x0b\xce\x98\xd9\x5f\xb1\xa4\x37\xa5\x3d\x7f\x40\x73\x30\x7f\xfe\xd5\x38\x3c\xbb\xf1\xd1\x76\x7b\x76\x13\x35\x7f\x3d\x6e\xa7\xce\x5f\x9e\x2d\xe3\x93\xeb\xe7\x8f\x59\xb4\x5e\x0c\xdf\xde\x44\x5d\x00\xc7\xb7\x8f\xcf\x5f\xf6\xd6\xce\xbf\x33\x9c\x35\x7d\xae\x85\xa6\x6c\xc8\x7b\xa3\x81\xf6\x7a\x70\x5b\xd3\xb0\x0d\xae\xf9\xe2\x32\xb8\xe4\x4d\x3f\xb8\x34\xf2\x6c\x1a\x21\x31\xfa\x8b\xee\x25\x7c\xb4\x76\x1f\xdd\xd5\x09\x69\x49\x96\x4c\xc4\xcf\xb3\xa8\xc4\x2a\xba\xa3\xed\x40\x1e\x1b\x39\xfd\x18\xc2\x1f\x58\x62\x75\xcc\x3e\x70\x65\x26\xfd\x01\xed\xfa\x18\xff\x84\x45\x32\x2d\x36\x03\x89\xe4\xc4\x17\x6b\x65\xec\x37\x1f\xc3\x81\xc7\xae\xfe\x4d\x1f\x19\x8b\xb9\x90\xf9\x37\x1f\x66\x95\xc5\xe2\xb7\x20\x79\x6a\x43\xd9\x7f\x1f\x77\xd0\xc5\x8e\xa3\x24\xda\xd1\x6c\x93\xd1\x2f\x7d\x9f\xbb\x00\xab\x6b\xef\x4e\xc6\x2a\xcd\x0f\x24\x58\x71\x35\xda\xe3\xd1\x13\xb2\x81\x46\x0f\x34\x7a\x3b\x02\x8d\x1e\x68\xf4\xde\x08\x34\xfa\x4e\xcb\x81\x46\x0f\x34\xfa\x21\x7a\xa0\xd1\xdb\x11\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x87\xc8\x81\x46\x87\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\xbd\x11\x68\xf4\xdf\x0f\x8d\xfe\x34\xd0\xe8\x81\x46\xf7\x23\xd0\xe8\x81\x46\xef\x8d\x40\xa3\xef\xb4\x1c\x68\xf4\x40\xa3\x1f\xa2\x07\x1a\xbd\x1d\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x7e\x88\x1c\x68\x74\x08\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\xdf\x1b\x81\x46\xff\xfd\xd0\xe8\xcf\x02\x8d\x1e\x68\x74\x3f\x02\x8d\x1e\x68\xf4\xde\x08\x34\xfa\x4e\xcb\x81\x46\x0f\x34\xfa\x21\x7a\xa0\xd1\xdb\x11\x68\xf4\x40\xa3\x07\x1a\x3d\xd0\xe8\x87\xc8\x81\x46\x87\x40\xa3\x07\x1a\x3d\xd0\xe8\x81\x46\x0f\x34\x7a\xa0\xd1\x03\x8d\x1e\x68\xf4\xbd\x11\x68\xf4\xff\x45\x1a\xdd\xcd\x74\x75\xd4\xbf\xd1\x7c\xfa\xd9\xfb\x47\xee\x53\x5f\xb1\xda\xff\xcc\xee\x7e\xf6\xb8\x2d\xb8\xb9\x9d\x78\x54\xca\x3e\xb5\xff\x73\x9d\x27\xff\x13\x00\x00\xff\xff\x4b\x42\xb7\x10\x0d\x5f\x00\x00")
+var _config_crd_direct_csi_min_io_directcsisnapshots_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x58\x6d\x8f\xdb\xb8\x11\xfe\xee\x5f\x31\x70\x0b\x64\x37\xb5\xe4\x38\x29\xd2\x3b\x01\x41\x10\x6c\x9a\x22\xb8\x4b\x11\x64\xb7\xf9\xd0\xf5\xb6\x37\x92\xc6\x32\x6f\x25\x52\xc7\x19\x39\xeb\x2b\xfa\xdf\x0b\x92\x92\xe5\x17\xc9\xd9\x04\xa8\x3f\x59\xe4\xf0\xe1\x70\x5e\x9e\x19\x72\x12\x45\xd1\x04\x6b\xf5\x99\x2c\x2b\xa3\x13\xc0\x5a\xd1\x83\x90\x76\x5f\x1c\xdf\xff\xc0\xb1\x32\xf3\xcd\x62\x72\xaf\x74\x9e\xc0\x55\xc3\x62\xaa\x4f\xc4\xa6\xb1\x19\xbd\xa5\x95\xd2\x4a\x94\xd1\x93\x8a\x04\x73\x14\x4c\x26\x00\xa8\xb5\x11\x74\xc3\xec\x3e\x01\x32\xa3\xc5\x9a\xb2\x24\x1b\x15\xa4\xe3\xfb\x26\xa5\xb4\x51\x65\x4e\xd6\x83\x77\x5b\x6f\x9e\xc5\x2f\xe3\xc5\x04\x20\xb3\xe4\x97\xdf\xa8\x8a\x58\xb0\xaa\x13\xd0\x4d\x59\x4e\x00\x34\x56\x94\x40\xae\x2c\x65\x92\xb1\x62\x8d\x35\xaf\x8d\x70\x1c\x86\xe2\x8c\x55\x5c\x29\x1d\x2b\x33\xe1\x9a\x32\xb7\x7d\x61\x4d\x53\x27\x70\x2a\x10\xd0\x5a\x15\xc3\xf1\xde\x7a\xa1\xab\xeb\xf7\xd7\x2d\xf0\x04\x00\xa0\x54\x2c\x3f\x0d\xcf\xff\xac\x38\xc8\xd4\x65\x63\xb1\x1c\x52\x6d\x02\x00\xc0\x4a\x17\x4d\x89\x76\x40\x60\x02\xc0\x99\xa9\x29\x81\xab\xb2\x61\x21\x3b\x01\x68\x4d\xe2\x75\x8b\xda\x43\x6f\x16\x29\x09\xbe\x08\x70\xd9\x9a\x2a\x6f\x6b\x00\x00\x53\x93\x7e\xf3\xf1\xfd\xe7\x17\xd7\x07\xc3\x00\x39\x71\x66\x55\x2d\xde\xba\x27\xba\x43\x4e\xda\x08\x31\x74\x9a\xc0\xd5\xa7\xb7\x60\xd2\x5f\x9d\x9d\x76\x18\xb5\x35\x35\x59\x51\x9d\xa1\x00\x00\x00\xf6\x22\x66\x6f\xf4\x68\xc7\x27\x4e\xa9\x20\x05\xb9\x0b\x15\x62\x90\x35\x75\xa7\xa3\xbc\x3d\x07\x98\x15\xc8\x5a\x31\x58\xaa\x2d\x31\xe9\x10\x3c\x07\xc0\xe0\x84\x50\x77\xea\xc1\x35\x59\x07\x03\xbc\x36\x4d\x99\xbb\x08\xdb\x90\x15\xb0\x94\x99\x42\xab\xdf\x77\xd8\x0c\x62\xfc\xa6\x25\x0a\xb5\xbe\xea\x7f\x4a\x0b\x59\x8d\x25\x6c\xb0\x6c\x68\x06\xa8\x73\xa8\x70\x0b\x96\xdc\x2e\xd0\xe8\x3d\x3c\x2f\xc2\x31\x7c\x30\x96\x40\xe9\x95\x49\x60\x2d\x52\x73\x32\x9f\x17\x4a\xba\x4c\xc9\x4c\x55\x35\x5a\xc9\x76\xee\x83\x5e\xa5\x8d\x18\xcb\xf3\x9c\x36\x54\xce\x59\x15\x11\xda\x6c\xad\x84\x32\x69\x2c\xcd\xb1\x56\x91\x57\x5d\xfb\x6c\x89\xab\xfc\x0f\xb6\xcd\x2d\x7e\x72\xa0\xab\x6c\x5d\x84\xb0\x58\xa5\x8b\xbd\x09\x1f\xb6\x67\x3c\xe0\xc2\x16\x14\x03\xb6\x4b\xc3\x29\x7a\x43\xbb\x21\x67\x9d\x4f\x7f\xbd\xbe\x81\x6e\x6b\xef\x8c\x63\xeb\x7b\xbb\xf7\x0b\xb9\x77\x81\x33\x98\xd2\x2b\xb2\x7e\x1d\xac\xac\xa9\x3c\x26\xe9\xbc\x36\x4a\x8b\xff\xc8\x4a\x45\xfa\xd8\xfc\xdc\xa4\x95\x12\xe7\xf7\xdf\x1a\x62\x71\xbe\x8a\xe1\xca\xd3\x07\xa4\x04\x4d\x9d\xa3\x50\x1e\xc3\x7b\x0d\x57\x58\x51\x79\x85\x4c\xff\x77\x07\x38\x4b\x73\xe4\x0c\xfb\x38\x17\xec\x33\xdf\xb1\x70\xb0\xda\xde\x04\x0b\x4a\xc3\x67\x3c\x76\x92\xa5\xd7\x7e\xc5\x69\xae\x3a\x03\xd8\xca\x27\x4a\x7c\x00\x37\x9c\xb0\x2d\x0d\xe7\x6a\x8f\x96\xf7\x7f\x4a\xa8\x1a\x18\x3e\xd2\x6e\x7a\xd5\x41\x78\x4e\x47\xa5\x9d\x66\x82\xaa\x64\x58\x19\x0b\x46\x13\xa0\x23\x5e\x09\x39\x4d\x90\x35\xd6\x9e\x3a\xbe\x37\x06\xed\x92\xff\xcd\xc7\xf7\xd0\x15\x96\x18\xa2\x28\x82\x1b\x37\xcc\x62\x9b\x4c\x40\xb1\x4f\x56\x9d\x53\xee\x77\x0a\x34\x3a\x08\xdb\xb0\x53\x02\x50\x03\x5a\x8b\x5b\xc0\x10\x81\x2b\x45\x65\x0e\x35\xca\x1a\xe2\xe0\x86\xb8\x37\x48\x0c\xf0\xce\x58\xa0\x07\xac\xea\x92\x66\x83\xb8\xce\xa5\xf0\xce\x98\xd6\x23\x41\xb1\xff\x00\x00\xc0\x7c\x0e\x9f\x76\x99\xe1\x77\x33\x29\x93\xdd\x84\x22\xe8\xa9\x6b\x10\x72\x65\xcc\x13\xee\x6c\x14\xec\x11\x77\x80\x3f\x69\xf3\x45\x0f\xa9\xea\xf5\x40\x4b\xc9\x20\xe4\x72\xfa\x66\x83\xaa\xc4\xb4\xa4\xe5\x74\x06\xcb\xe9\x47\x6b\x0a\x4b\xec\x2a\xd0\x72\x1a\x28\x6e\x39\x7d\x4b\x85\xc5\x9c\xf2\xe5\xb4\xdb\xee\x4f\x35\x4a\xb6\xfe\x40\xb6\xa0\x9f\x68\xfb\xca\x6d\x32\x8c\x7f\x20\x7f\x2d\x16\x85\x8a\xed\xab\xca\x2d\xdc\x61\xb9\x8a\x79\xb3\xad\xe9\x55\x85\xf5\xc1\xe0\x07\xac\xbf\x8e\xbe\x0b\x32\x86\xdb\x3b\x97\x5e\x9b\x45\xbc\x1b\x83\x5f\x7e\x65\xa3\x93\xe5\xb4\xb7\xc8\xcc\x54\x2e\x7c\x6b\xd9\x2e\xa7\x83\xa8\x07\xaa\x26\xcb\xa9\x57\x76\x39\x85\x83\x23\x27\xcb\xa9\x53\xcb\x0d\x5b\x23\x26\x6d\x56\xc9\x72\x9a\x6e\x85\x78\xb6\x98\x59\xaa\x67\xae\x0c\xbf\xea\x77\x5d\x4e\x7f\x19\x3e\x82\xee\x4e\x6c\x64\x4d\x36\xc4\x1d\xc3\x7f\x87\x54\x1b\xcf\x55\x00\x00\x80\x12\x59\x6e\x2c\x6a\x56\x5d\x3b\x34\x2c\x77\x94\xa6\xa7\xcb\x40\x71\x5b\x05\x59\x40\xdc\x80\xfb\xda\x1d\x66\x04\x14\x40\x76\x28\x94\x07\x66\x37\x9a\x5a\x16\x03\x31\x80\xda\x1f\x32\x6e\x73\x35\x14\xe3\x94\xe0\xcb\x9a\xce\x80\xae\x09\x1a\x9d\x93\x2d\xb7\xae\xfe\x64\x3d\xa7\xac\x51\x17\x8e\xf0\xe1\xbd\x23\x05\xf4\x69\xaf\x8d\xc0\xbd\xcb\x85\x19\xc8\x39\xd4\x86\xbb\x62\xe6\xcf\xe7\x34\xf0\x5f\x8e\x57\xbc\x0f\x3a\x78\x5f\x0f\xb3\x8c\x6a\x71\x49\x12\x8f\x00\x06\x76\x4d\xc0\x95\xa0\xc8\x21\x8e\xc8\x8d\x54\x85\xfe\x57\x11\x33\x16\x8f\x73\x5c\x2b\xeb\x35\x84\x75\x53\xa1\x06\x4b\x98\x3b\x3d\xfb\x39\x9d\xab\x0c\x65\x6c\xbb\x80\x19\x28\x19\x53\xd3\x04\xf2\xeb\xfd\xd8\xba\xca\x15\xed\x94\x00\x35\xf8\xc4\x69\x0f\x30\x66\x8c\x0a\x1f\x7e\x26\x5d\xc8\x3a\x81\x17\xcf\xff\xf2\xf2\x87\xef\xb5\x45\x60\x45\xca\xff\x46\x9a\xac\x27\xc7\x47\x99\xe5\x74\xd9\x5e\x23\xe2\xcf\x17\x77\x55\x38\x2e\x76\x32\x67\xe2\xaf\x2d\x09\x7d\xe4\x7d\x41\x06\x26\x81\x14\x99\x72\x68\x6a\x67\x27\x57\x10\x94\x66\x41\x9d\xd1\x0c\xd4\xea\xdb\x36\x51\x3b\x5e\x2f\xb7\xb0\x78\x3e\x83\xb4\x75\xc5\x29\xa3\xdf\x3e\xdc\xc5\xa7\x47\x3c\x87\xfc\xe3\xec\x48\x7f\xc5\xe0\x5c\x6d\x56\x3e\x5e\xe1\x8b\x92\x35\x58\x0a\x95\xb8\x6d\x80\xcf\x55\xe2\xa3\x6a\x4c\xbb\x73\x7f\x2d\x3b\x94\x96\x97\x7f\x1e\x0b\x1a\xa5\x55\xd5\x54\x09\x3c\x3b\x1b\x2e\xae\xac\x17\x64\x07\x65\x2c\x21\x3f\x32\x46\x82\x68\xdf\x96\xa0\x23\xd7\xc2\x62\xe5\x7a\xa4\x0c\x54\xee\x5a\xbc\x95\x22\xfb\x98\x04\x72\x26\x68\x01\x5d\xb3\x71\x60\xeb\x27\xdc\xb2\xe8\x5e\x4a\x7d\xb4\x26\x6f\x32\xb2\x3c\x8a\x68\x56\xe0\xbc\xa1\x56\x2a\xeb\xa1\xbc\x05\x42\x2e\x86\xfb\x11\xd0\x83\x73\xd9\xee\xb6\xe1\xaa\xf5\x28\x64\x45\xa8\x95\x2e\xb8\x55\x51\x71\xa0\xb9\x50\xe2\xbf\xac\xc9\x57\x1f\x7f\xdf\x6a\xb1\xac\x3f\x05\xab\x9c\x2c\x8d\xc3\x22\x14\x0d\x5a\xd4\x42\x94\x3b\xf2\x74\x84\xd1\x62\xec\x11\x3c\xf6\x1d\xf9\x57\xb8\x03\xe0\x66\xa7\x9b\x3f\x6a\xdb\xdd\x7b\xde\x79\x04\xe1\x2c\x9e\x3d\x3f\x13\x61\x3b\xa9\x11\x91\x1a\xc5\x5d\xf1\x12\xf8\xd7\xed\x9b\xe8\x9f\x18\xfd\x7e\x77\xd1\xfe\x79\x16\xfd\xf8\xef\x59\x72\xf7\x74\xef\xf3\xee\xf2\xf5\x1f\xbf\x97\xda\x86\x3a\xfb\x91\x50\x0d\xa2\xbb\x0e\xb9\x8b\x86\x19\x18\xed\x13\xf0\xc6\xba\xbb\xe8\x3b\x2c\x99\x66\xf0\x0f\xed\x8b\xdf\x98\xa1\x48\x37\xd5\xd8\xa6\x11\x4c\x1d\xd4\x74\x7c\xda\xef\x31\x3e\xdf\xee\xfd\xbd\x26\xf1\x02\x8f\x31\x88\x13\x74\x07\xdf\xe3\xb3\xbd\x1b\x1f\x78\x1e\x76\xbd\x72\xdc\xf6\xe7\x71\x66\xaa\x79\x7f\x23\x1c\xd9\x02\xfc\x25\xe2\x03\xea\x2d\xf4\x64\x1b\xba\xe7\xe3\x8c\x60\x21\x2d\x80\x99\x35\xcc\xbb\x6b\xf0\x78\x32\x97\xea\x9e\x60\xd7\x66\x07\x6a\x4f\x29\x43\x7f\xf3\xb0\xa9\x12\x8b\x76\xdb\x9f\x86\x21\x43\xed\x2f\xb4\x4c\xab\xa6\x1c\x85\xbd\x60\x22\x88\xb5\xc9\xe9\xb4\x46\x5c\x06\xc6\xc7\x54\x95\x4a\xb6\x20\x06\x72\xca\x8c\x5e\x95\xca\x5f\x8e\xc6\x8b\x45\x55\x1b\x2b\xa8\x25\xa4\xb1\xa5\x82\x1e\x40\x09\x54\xae\xf5\x25\x06\xc5\x70\x91\x6b\x5e\x2c\x9e\xbf\xb8\x6e\xd2\xdc\x54\xa8\xf4\xbb\x4a\xe6\x97\xaf\x2f\x7e\x6b\xb0\x74\x8c\x99\xff\x1d\x2b\x7a\x57\xc9\xe5\x23\x9a\x83\xc5\xcb\xaf\xe6\xe1\xc5\x6d\xc8\xb6\xbb\x8b\xdb\xa8\xfd\xf7\xb4\x1b\xba\x7c\x7d\xb1\x8c\xcf\xce\x5f\x3e\x75\xaa\xed\xe5\xf0\xdd\x6d\xd4\x27\x70\x7c\xf7\xf4\xf2\xf5\xde\xdc\xe5\x77\xa6\xb3\x7b\x90\x50\x96\xf2\xa1\xe8\x8d\x06\xda\xeb\x41\xb1\xb6\x61\x1b\x9c\x0b\xc5\x65\x70\x2a\xb8\x7e\x70\x6a\xe4\xda\x34\xf2\xd6\xb0\x3f\xe9\x6f\xc2\x27\x73\x0f\x91\x7b\x8a\xb5\x9a\x84\x38\x72\xd7\xb3\xa8\xc2\x3a\xba\xa7\xed\x00\x8f\x8d\xec\x7e\x0a\x11\x36\xac\xb0\x3e\x92\xcd\xad\xda\x0c\xd0\xc1\x19\x4f\xac\x0d\xcb\x47\x94\xf5\x37\x2d\x72\xe9\xe3\x02\xf6\x9b\x16\x85\x84\xff\x6c\xca\xe6\x1b\x17\x8a\x11\x2c\xaf\xb0\xc6\x4c\xc9\x36\x99\x7c\x5b\x9b\x34\xde\xff\x0c\x7a\xf4\x34\x28\xa3\xdd\xe3\xd3\x64\x74\x65\x68\x2b\x13\x10\xdb\x50\x18\x10\x63\xdd\x7d\x24\x8c\xf4\x65\x2b\x5c\x89\x42\xb6\x1f\x3c\x8a\x4f\xa7\x07\x2f\xdc\xfe\x73\xef\x29\x09\x6e\xef\x26\x01\x95\xf2\xcf\xdd\xbb\xb5\x1b\xfc\xdf\x00\x47\xda\x93\x85\x5b\x18\x00\x00")
+
+func config_crd_direct_csi_min_io_directcsisnapshots_yaml() ([]byte, error) {
+	return bindata_read(
+		_config_crd_direct_csi_min_io_directcsisnapshots_yaml,
+		"config/crd/direct.csi.min.io_directcsisnapshots.yaml",
+	)
+}
+
+var _config_crd_direct_csi_min_io_directcsivolumes_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x5c\xed\x6f\xdb\xb8\x19\xff\xee\xbf\xe2\x07\x6f\x40\x93\xce\x52\x9a\x76\xe8\xee\x0c\x14\x45\x91\xae\x43\x71\xd7\x5b\xd1\x64\xfd\xb0\x38\xdb\x3d\x96\x1e\xdb\xbc\x48\xa4\x8e\xa4\xd2\xf8\x86\xfd\xef\x03\x49\xc9\xaf\x92\x1b\x17\xed\xb6\x0f\xd4\xa7\x88\xa4\x9e\xf7\x37\xfe\x02\x78\x90\x24\xc9\x80\x2a\xf1\x91\xb5\x11\x4a\x8e\x41\x95\xe0\x7b\xcb\xd2\xbd\x99\xf4\xf6\x3b\x93\x0a\x75\x76\x77\x3e\xb8\x15\x32\x1f\xe3\xa2\x36\x56\x95\x1f\xd8\xa8\x5a\x67\xfc\x9a\x67\x42\x0a\x2b\x94\x1c\x94\x6c\x29\x27\x4b\xe3\x01\x40\x52\x2a\x4b\x6e\xd9\xb8\x57\x20\x53\xd2\x6a\x55\x14\xac\x93\x39\xcb\xf4\xb6\x9e\xf2\xb4\x16\x45\xce\xda\x13\x6f\x59\xdf\x3d\x49\x9f\xa7\xe7\x03\x20\xd3\xec\x3f\xbf\x12\x25\x1b\x4b\x65\x35\x86\xac\x8b\x62\x00\x48\x2a\x79\x8c\x5c\x68\xce\x6c\x66\xc4\x9d\x2a\xea\x92\x4d\x1a\x16\xd2\xcc\x88\xb4\x14\x32\x15\x6a\x60\x2a\xce\x1c\xf3\xb9\x56\x75\x35\xc6\xfe\x81\x40\xab\x11\x30\x28\xf7\xda\x1f\xba\xb8\x7c\xfb\xd1\x93\x1d\x00\x40\x21\x8c\xfd\xa1\x6b\xf7\x47\x61\xec\x00\x00\xaa\xa2\xd6\x54\xec\x0b\x35\x00\x00\x23\xe4\xbc\x2e\x48\xef\x6d\x0f\x00\x93\xa9\x8a\xc7\xb8\x28\x6a\x63\x59\x0f\x80\xc6\x10\x5e\xa6\xa4\x51\xf5\xee\x9c\x8a\x6a\x41\xe7\x81\x5a\xb6\xe0\xd2\x9b\x18\x00\x54\xc5\xf2\xd5\xfb\xb7\x1f\x9f\x5d\x6e\x2d\x03\x39\x9b\x4c\x8b\xca\x7a\xa3\xee\x88\x8d\x9c\xa5\xb2\x6c\x10\xc4\xc0\xc5\x87\xd7\x50\xd3\x5f\x9c\x71\x56\xdf\x57\x5a\x55\xac\xad\x68\xad\x03\x00\xc0\x46\x90\x6c\xac\xee\x70\x7b\xe4\x04\x0a\xa7\x90\xbb\xe8\x60\x03\xbb\xe0\x56\x35\xce\x1b\x1d\xa0\x66\xb0\x0b\x61\xa0\xb9\xd2\x6c\x58\x86\x78\xd9\x22\x0c\x77\x88\x64\x2b\x1e\x2e\x59\x3b\x32\x30\x0b\x55\x17\xb9\x0b\xaa\x3b\xd6\x16\x9a\x33\x35\x97\xe2\xb7\x15\x6d\x03\xab\x3c\xd3\x82\x2c\x37\x4e\x5a\x3f\x42\x5a\xd6\x92\x0a\xdc\x51\x51\xf3\x08\x24\x73\x94\xb4\x84\x66\xc7\x05\xb5\xdc\xa0\xe7\x8f\x98\x14\xef\x94\x66\x08\x39\x53\x63\x2c\xac\xad\xcc\xf8\xec\x6c\x2e\x6c\x9b\x1c\x99\x2a\xcb\x5a\x0a\xbb\x3c\xf3\x71\x2e\xa6\xb5\x55\xda\x9c\xe5\x7c\xc7\xc5\x99\x11\xf3\x84\x74\xb6\x10\x96\x33\x5b\x6b\x3e\xa3\x4a\x24\x5e\x74\xe9\x13\x24\x2d\xf3\xdf\xe9\x26\x9d\xcc\xa3\x2d\x59\xed\xd2\x85\x87\xb1\x5a\xc8\xf9\xc6\x86\x8f\xd5\x03\x1e\x70\xd1\x0a\x61\x40\xcd\xa7\x41\x8b\xb5\xa1\xdd\x92\xb3\xce\x87\x3f\x5f\x5e\xa1\x65\xed\x9d\xb1\x6b\x7d\x6f\xf7\xf5\x87\x66\xed\x02\x67\x30\x21\x67\xac\xfd\x77\x98\x69\x55\x7a\x9a\x2c\xf3\x4a\x09\x69\xfd\x4b\x56\x08\x96\xbb\xe6\x37\xf5\xb4\x14\xd6\xf9\xfd\xd7\x9a\x8d\x75\xbe\x4a\x71\xe1\x2b\x06\xa6\x8c\xba\xca\xc9\x72\x9e\xe2\xad\xc4\x05\x95\x5c\x5c\x90\xe1\x6f\xee\x00\x67\x69\x93\x38\xc3\x3e\xcc\x05\x9b\xc5\x6e\xf7\x70\xb0\xda\xc6\x86\xb1\x64\x6b\x73\xc0\x63\x3b\x19\x7a\xe9\xcf\xef\xe6\xa9\x53\x5e\x97\x3e\x49\xd2\x2d\x52\xdd\xc9\x0a\x00\x74\x47\xa2\xa0\x69\xc1\x17\x54\x51\x26\xec\x72\xf7\x00\x10\x68\x8e\x5d\x52\x3c\xff\xe3\xde\x6e\x50\xc8\x25\xcc\xdc\xd7\xa7\xcd\x27\x53\x32\x17\x1b\x25\x7e\xf3\x11\x96\xcb\x8e\xe5\x1d\xb5\x87\x17\x2d\x09\xdf\x1f\x48\x48\xa7\xb4\x25\x51\x18\x27\x17\x94\x64\x90\x2b\xe3\x36\x14\x0b\x46\x56\x6b\xbd\x1f\x51\x6b\x2b\xf3\xaa\xaa\xbc\x7a\xff\x16\x6d\x93\x4a\x91\x24\x09\xae\xdc\xb2\xb1\xba\xce\x2c\x84\xf1\x4a\xc9\x9c\x73\xcf\x29\x94\xe6\x4e\xb2\xb5\x71\x42\x80\x24\x48\x6b\x5a\x82\x42\x68\xcf\x04\x17\x39\x2a\xb2\x0b\xa4\xc1\xbf\xe9\xda\x20\x29\xf0\x46\x69\xf0\x3d\x95\x55\xc1\xa3\x4e\xba\xce\xb4\x78\xa3\x54\xe3\xec\x20\xd8\xbf\x00\x00\x67\x67\xf8\xb0\x4a\x39\xcf\x4d\x4d\x0d\xeb\xbb\xd0\x50\x7d\x4d\xec\x24\x39\x53\xea\x91\x69\x6d\x14\xec\x91\xb6\x04\x7f\x90\xea\x93\xec\x12\xd5\xcb\x41\x9a\xc7\x9d\x24\x27\xc3\x57\x6d\x0c\x4d\x86\x23\x4c\x86\xef\xb5\x9a\x6b\x36\xae\xab\x4d\x86\xa1\x76\x4e\x86\xaf\x79\xae\x29\xe7\x7c\x32\x6c\xd9\xfd\xa1\x22\x9b\x2d\xde\xb1\x9e\xf3\x0f\xbc\x7c\xe1\x98\x74\xd3\xdf\x3a\x7f\x69\x35\x59\x9e\x2f\x5f\x94\xee\xc3\x15\x2d\xd7\x81\xaf\x96\x15\xbf\x28\xa9\xda\x5a\x7c\x47\xd5\xe7\xa9\xaf\x82\xcc\xe0\xfa\xc6\xe5\xed\xdd\x79\xba\x5a\xc3\xcf\xbf\x18\x25\xc7\x93\xe1\xda\x22\x23\x55\xba\xf0\xad\xec\x72\x32\xec\xa4\xba\x25\xea\x78\x32\xf4\xc2\x4e\x86\xd8\x52\x79\x3c\x19\x3a\xb1\xdc\xb2\x56\x56\x4d\xeb\xd9\x78\x32\x9c\x2e\x2d\x9b\xd1\xf9\x48\x73\x35\x72\xcd\xfd\xc5\x9a\xeb\x64\xf8\x73\xb7\x0a\xb2\xd5\x58\xd9\x05\xeb\x10\x77\x06\xff\xee\x12\xad\xbf\x10\x00\x00\x50\x90\xb1\x57\x9a\xa4\x11\xed\x68\xd5\x7d\x6e\x27\x4d\xf7\x3f\x83\x30\x4d\x7b\x35\x16\xd6\x2d\xb8\xb7\x95\x32\x3d\x44\x01\xbb\xa2\xc2\x79\x68\x19\x4a\x72\x53\x1e\x61\x15\x48\x7a\x25\xd3\x26\x57\x43\x97\x9f\x32\x3e\x2d\xf8\x00\xd1\x05\xa3\x96\x39\xeb\x62\xe9\x1a\x5b\xb6\xae\x29\x0b\x92\x73\xd7\x49\xf0\xd6\x15\x05\xf2\x69\x2f\x95\xc5\xad\xcb\x85\x11\xec\x21\xaa\xb5\x69\xbb\xa4\xd7\xcf\x49\xe0\xdf\x5c\x5d\xf1\x3e\x68\xc9\xfb\x46\x9b\x65\x5c\x59\x97\x24\x69\x0f\xc1\xb6\xcc\xba\xde\x96\x38\x8a\x3d\xe7\x7a\xda\xcd\xfa\x29\xd9\x18\x9a\x3f\xcc\x71\xcd\x59\x2f\x21\x16\x75\x49\x12\x9a\x29\x77\x72\xae\xf7\x64\x2e\x32\xb2\x7d\xec\x02\xcd\x50\x92\x69\xaa\xea\x50\xfc\xd6\x7e\x6c\x5c\xe5\xa6\x81\x29\x83\x24\x7c\xe2\x34\x0a\xf4\x19\xa3\xa4\xfb\x1f\x59\xce\xed\x62\x8c\x67\x4f\xff\xf4\xfc\xbb\x2f\xb5\x45\xa8\x8a\x9c\xff\x85\x25\x6b\x5f\x1c\x1f\x64\x96\xfd\xcf\x36\x26\x1c\xaf\x5f\xda\xb6\xf7\x74\xbe\x3a\x73\x20\xfe\x9a\x96\xb0\x8e\xbc\x4f\x64\x60\xd8\x62\x4a\x86\x73\xd4\x95\xb3\x93\x6b\x08\x42\x1a\x4b\x32\xe3\x11\xc4\xec\x38\x26\x62\x55\xd7\x8b\x25\xce\x9f\x8e\x30\x6d\x5c\xb1\x5f\xd1\xaf\xef\x6f\xd2\x7d\x15\x0f\x51\xfe\x7e\xb4\x23\xbf\x30\x70\xae\x56\x33\x1f\xaf\xf8\x24\xec\x02\x9a\x43\x27\x6e\x26\xeb\x43\x9d\x78\xa7\x1b\xf3\x4a\xef\xcf\x65\x47\xf7\x10\x02\x00\x40\x29\xa4\x28\xeb\x72\x8c\x27\x07\xc3\xa5\x7b\x56\x01\x00\x40\x33\x99\x07\xc6\x48\x38\xba\x1e\x4b\xc8\x15\xd7\xb9\xa6\xd2\x0d\x60\x19\x44\xee\x66\xc7\x99\x60\xfd\x90\x04\x72\x26\x68\x08\xba\x61\x63\xcb\xd6\x8f\x4c\x53\x45\x37\x52\xea\xbd\x56\x79\x9d\xb1\x36\xbd\x14\xd5\x0c\xce\x1b\x62\x26\xb2\x35\x29\x6f\x81\x90\x8b\xe1\xe2\x05\xbe\x77\x2e\x5b\x5d\x63\x5c\xb7\xee\x25\x59\x32\x49\x21\xe7\xa6\x11\x51\x98\x50\xe6\x42\x8b\xff\xb4\x60\xdf\x7d\xfc\x45\xae\xa1\xa5\xbd\x16\x46\xe4\xac\xb9\x9f\x2c\x61\x5e\x93\x26\x69\x99\x73\x57\x3c\x5d\xc1\x68\x68\x6c\x14\x78\x5a\x8f\xfa\x9f\xa9\x1d\xc0\xd5\x4a\x36\xaf\x6a\x73\x6d\xf0\x75\xe7\x01\x05\xe7\xfc\xc9\xd3\x03\x11\xb6\x3a\xd5\x73\xa4\x22\xeb\xee\x8e\x63\xfc\xe3\xfa\x55\xf2\x77\x4a\x7e\xbb\x39\x69\xfe\x78\x92\x7c\xff\xcf\xd1\xf8\xe6\xf1\xc6\xeb\xcd\xe9\xcb\xdf\x7f\x69\x69\xeb\xba\x32\xf4\x84\x6a\x38\xba\x9a\x90\xdb\x68\x18\x41\x49\x9f\x80\x57\xda\x5d\x72\xdf\x50\x61\x78\x84\xbf\x49\xdf\xfc\xfa\x0c\xc5\xb2\x2e\xfb\x98\x26\x18\x3a\x52\xc3\xfe\x6d\xcf\xa3\x7f\xbf\xe1\xfd\xa5\x26\xf1\x07\x1e\x62\x10\x77\xd0\x29\xbe\x51\xcf\x36\xae\x92\xf0\x75\xd8\xcd\xca\x69\x33\x9f\xa7\x99\x2a\xcf\xd6\x57\xcd\x1e\x16\xf0\x97\x88\x77\x24\x97\x58\x17\xdb\x30\x3d\xef\x66\x84\xb1\x2c\x2d\x28\xd3\xca\x98\xd5\xfd\xba\x3f\x99\x0b\x71\xcb\x58\x8d\xd9\xa1\xb4\x4f\x39\x23\x7f\xf3\xd0\x53\x61\x35\xe9\xe5\x5a\x1b\x83\x8c\xa4\xbf\x29\x1b\x9e\xd5\x45\x2f\xd9\x13\xc3\x8c\x54\xaa\x9c\xf7\x7b\xc4\x69\xa8\xf8\x34\x15\x85\xb0\x4b\x58\x85\x9c\x33\x25\x67\x85\xf0\x97\xa3\xfe\x66\x51\x56\x4a\x5b\x92\x36\xa4\xb1\xe6\x39\xdf\x43\x58\x94\x6e\xf4\x65\x03\x61\x70\x92\x4b\x73\x7e\xfe\xf4\xd9\x65\x3d\xcd\x55\x49\x42\xbe\x29\xed\xd9\xe9\xcb\x93\x5f\x6b\x2a\x5c\xc5\xcc\x7f\xa2\x92\xdf\x94\xf6\xf4\x01\xc3\xc1\xf9\xf3\xcf\xe6\xe1\xc9\x75\xc8\xb6\x9b\x93\xeb\xa4\xf9\xeb\x71\xbb\x74\xfa\xf2\x64\x92\x1e\xdc\x3f\x7d\xec\x44\xdb\xc8\xe1\x9b\xeb\x64\x9d\xc0\xe9\xcd\xe3\xd3\x97\x1b\x7b\xa7\x5f\x98\xce\x0e\xe9\x10\x9a\xf3\xae\xe8\x4d\x3a\xc6\xeb\xce\x63\xcd\xc0\xd6\xb9\x17\x9a\x4b\xe7\x56\x70\x7d\xe7\x56\xcf\xb5\xa9\x07\xc4\xd8\xdc\xf4\x37\xe1\xbd\xbd\xfb\xc4\xc1\xba\x5a\xb2\x65\x93\xb8\xeb\x59\x52\x52\x95\xdc\xf2\xb2\xa3\x8e\xf5\x70\xdf\x27\x11\x18\x96\x54\xed\xa3\x0f\xae\x33\xb3\x7e\x4f\x76\x31\x1e\x1c\xe1\x91\x5c\x8b\x3b\x3e\xea\x8b\x85\x32\xf6\x68\x36\x2e\xf1\x5c\xa8\x1f\xf5\x91\xb1\x34\x17\x72\x7e\x34\x33\xab\x2c\x15\xdf\x02\xe4\xa9\x0d\xe7\x5f\x9f\x6e\x67\x88\xed\x67\x49\xb2\x82\xd9\x06\xbd\x5f\x86\x39\x77\x0c\xab\x6b\x0e\x0b\x56\x69\x77\x41\xc2\xcc\x75\xa3\x2d\x1c\x7d\xca\x36\xc2\xe8\x11\x46\x6f\x9f\x08\xa3\x47\x18\x7d\xe3\x89\x30\xfa\xca\xca\x11\x46\x8f\x30\xfa\x2e\xf5\x08\xa3\xb7\x4f\x84\xd1\x23\x8c\x1e\x61\xf4\x08\xa3\x03\x11\x46\xff\x4c\x8c\x44\x18\x3d\xc2\xe8\xdd\xad\x3f\xc2\xe8\xbd\xdb\x11\x46\x8f\x30\x7a\x84\xd1\xbb\xfa\x4e\x84\xd1\x1f\xc6\x3d\xc2\xe8\x11\x46\xff\xb6\x30\xfa\xd3\x08\xa3\x47\x18\x1d\x40\x84\xd1\x23\x8c\x1e\x61\xf4\x9e\x9b\x47\x84\xd1\x23\x8c\xbe\x4b\x3d\xc2\xe8\xed\x13\x61\xf4\x08\xa3\x47\x18\x3d\xc2\xe8\x40\x84\xd1\x3f\x13\x23\x11\x46\x8f\x30\x7a\x77\xeb\x8f\x30\x7a\xef\x76\x84\xd1\x23\x8c\x1e\x61\xf4\xae\xbe\x13\x61\xf4\x87\x71\x8f\x30\x7a\x84\xd1\xbf\x2d\x8c\xfe\x2c\xc2\xe8\x11\x46\x07\x10\x61\xf4\x08\xa3\xff\x5f\xc0\xe8\xd3\x42\x65\xb7\xef\x54\xde\xdb\x28\xa6\x4a\x15\x4c\x32\xa2\xef\x11\x7d\x8f\xe8\x7b\x44\xdf\x23\xfa\x1e\xd1\xf7\x88\xbe\x6f\xc4\x5f\x44\xdf\x0f\x76\xe3\x88\xbe\x47\xf4\x3d\xa2\xef\xc7\x95\xb6\x88\xbe\x77\x1f\x88\xe8\x7b\x44\xdf\x23\xfa\xbe\xbb\x17\xd1\xf7\x23\x3c\xe2\xbe\x64\x69\xdf\xab\xaa\x76\xa8\x62\x7e\x1c\xea\xf1\x5f\xc2\xee\x4b\x55\x4b\xfb\xd7\xea\x58\x78\xe5\x00\xc5\x63\x7d\xd7\x9c\xb6\xaa\x14\xd9\xd7\xf8\xc7\x42\x45\xda\xfa\x08\xff\xa9\x2e\xfb\x3e\xec\x1e\xde\x2a\xad\x5c\x2c\xbe\x7d\xfd\x35\x41\xaf\x50\x94\x2f\x25\x55\x66\xa1\xec\x51\x9a\x84\x4f\x03\xc2\x17\xff\xb7\xf2\x3f\xfa\xdf\x8a\x5f\x59\x4f\x49\xe1\x06\x1e\x9a\xcb\xd6\xaf\xfb\x0f\x87\x5b\x3f\xd7\xef\x5f\x37\x90\x4b\x5c\xdf\x0c\x02\x55\xce\x3f\xb6\x3f\xc4\xef\x16\xff\x33\x00\xa3\xae\xd5\xa8\x22\x61\x00\x00")
 
 func config_crd_direct_csi_min_io_directcsivolumes_yaml() ([]byte, error) {
 	return bindata_read(
@@ -81,8 +90,9 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() ([]byte, error){
-	"config/crd/direct.csi.min.io_directcsidrives.yaml":  config_crd_direct_csi_min_io_directcsidrives_yaml,
-	"config/crd/direct.csi.min.io_directcsivolumes.yaml": config_crd_direct_csi_min_io_directcsivolumes_yaml,
+	"config/crd/direct.csi.min.io_directcsidrives.yaml":    config_crd_direct_csi_min_io_directcsidrives_yaml,
+	"config/crd/direct.csi.min.io_directcsisnapshots.yaml": config_crd_direct_csi_min_io_directcsisnapshots_yaml,
+	"config/crd/direct.csi.min.io_directcsivolumes.yaml":   config_crd_direct_csi_min_io_directcsivolumes_yaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -128,8 +138,9 @@ type _bintree_t struct {
 var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	"config": {nil, map[string]*_bintree_t{
 		"crd": {nil, map[string]*_bintree_t{
-			"direct.csi.min.io_directcsidrives.yaml":  {config_crd_direct_csi_min_io_directcsidrives_yaml, map[string]*_bintree_t{}},
-			"direct.csi.min.io_directcsivolumes.yaml": {config_crd_direct_csi_min_io_directcsivolumes_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsidrives.yaml":    {config_crd_direct_csi_min_io_directcsidrives_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsisnapshots.yaml": {config_crd_direct_csi_min_io_directcsisnapshots_yaml, map[string]*_bintree_t{}},
+			"direct.csi.min.io_directcsivolumes.yaml":   {config_crd_direct_csi_min_io_directcsivolumes_yaml, map[string]*_bintree_t{}},
 		}},
 	}},
 }}
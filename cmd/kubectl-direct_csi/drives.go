@@ -62,4 +62,9 @@ func init() {
 	drivesCmd.AddCommand(drivesAccessTierCmd)
 	drivesCmd.AddCommand(releaseDrivesCmd)
 	drivesCmd.AddCommand(unreleaseDrivesCmd)
+	drivesCmd.AddCommand(drivesTaintCmd)
+	drivesCmd.AddCommand(drivesUntaintCmd)
+	drivesCmd.AddCommand(drivesCordonCmd)
+	drivesCmd.AddCommand(drivesUncordonCmd)
+	drivesCmd.AddCommand(drivesEvacuateCmd)
 }
@@ -0,0 +1,101 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/ShayBenyo11/directcsi-minio/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/ShayBenyo11/directcsi-minio/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var drivesCordonCmd = &cobra.Command{
+	Use:   "cordon",
+	Short: "cordon DirectCSI drive(s) to stop placing new volumes on them",
+	Long:  "",
+	Example: `
+# Cordons all the DirectCSI drives
+$ kubectl direct-csi drives cordon --all
+
+# Cordons 'sdf' drives in all nodes
+$ kubectl direct-csi drives cordon --drives '/dev/sdf'
+
+# Cordons drives from selective nodes using ellipses notation for node names
+$ kubectl direct-csi drives cordon --nodes 'directcsi-{1...3}'
+
+# Cordons all the 'hot' tiered drives
+$ kubectl direct-csi drives cordon --access-tier hot
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return cordonDrives(c.Context())
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	drivesCordonCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	drivesCordonCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	drivesCordonCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "cordon all drives")
+	drivesCordonCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	drivesCordonCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func cordonDrives(ctx context.Context) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	directCSIClient := utils.GetDirectCSIClient()
+	return processFilteredDrives(
+		ctx,
+		directCSIClient.DirectCSIDrives(),
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			if len(driveStatusList) > 0 && !drive.MatchDriveStatus(driveStatusList) {
+				return false
+			}
+			return !drive.Spec.Unschedulable
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			drive.Spec.Unschedulable = true
+			return nil
+		},
+		defaultDriveUpdateFunc(directCSIClient),
+	)
+}
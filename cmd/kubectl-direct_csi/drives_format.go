@@ -25,15 +25,28 @@ import (
 	directcsi "github.com/ShayBenyo11/directcsi-minio/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/ShayBenyo11/directcsi-minio/pkg/utils"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	"k8s.io/klog/v2"
 )
 
-const xfs = "xfs"
+const (
+	xfs  = "xfs"
+	ext4 = "ext4"
+)
 
 var (
-	force = false
+	force     = false
+	blockMode = false
+	fsType    = xfs
+
+	blockSize  int64
+	sectorSize int64
+	agCount    int32
+	logSize    string
+	reflink    bool
+	crc        bool
 )
 
 var formatDrivesCmd = &cobra.Command{
@@ -73,6 +86,18 @@ $ kubectl direct-csi drives format <drive_id>
 
 # Format more than one drive by their drive-ids
 $ kubectl direct-csi drives format <drive_id_1> <drive_id_2>
+
+# Format the 'sdf' drives in all nodes with ext4 filesystem
+$ kubectl direct-csi drives format --drives '/dev/sdf' --fs-type ext4
+
+# Format the 'sdf' drives in all nodes for raw block volumes
+$ kubectl direct-csi drives format --drives '/dev/sdf' --block
+
+# Format the 'sdf' drives in all nodes with 8 allocation groups and 128MiB log
+$ kubectl direct-csi drives format --drives '/dev/sdf' --ag-count 8 --log-size 128MiB
+
+# Format the 'sdf' drives in all nodes with ext4 filesystem of 4KiB block size
+$ kubectl direct-csi drives format --drives '/dev/sdf' --fs-type ext4 --block-size 4096
 `,
 	RunE: func(c *cobra.Command, args []string) error {
 		if !all {
@@ -86,10 +111,25 @@ $ kubectl direct-csi drives format <drive_id_1> <drive_id_2>
 		if err := validateDriveSelectors(); err != nil {
 			return err
 		}
+		if fsType != xfs && fsType != ext4 {
+			return fmt.Errorf("unsupported filesystem type %s; supported types are %s and %s", utils.Bold(fsType), xfs, ext4)
+		}
+		formatOptions, err := getFormatOptions(c)
+		if err != nil {
+			return err
+		}
+		if formatOptions != nil {
+			if blockMode {
+				return fmt.Errorf("format options cannot be used with %s", utils.Bold("--block"))
+			}
+			if err := directcsi.ValidateFormatOptions(formatOptions, fsType, 0); err != nil {
+				return fmt.Errorf("invalid format options; %v", err)
+			}
+		}
 		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 {
 			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
 		}
-		return formatDrives(c.Context(), args)
+		return formatDrives(c.Context(), args, formatOptions)
 	},
 	Aliases: []string{},
 }
@@ -99,11 +139,59 @@ func init() {
 	formatDrivesCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
 	formatDrivesCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "format all available drives")
 	formatDrivesCmd.PersistentFlags().BoolVarP(&force, "force", "f", force, "force format a drive even if a FS is already present")
+	formatDrivesCmd.PersistentFlags().BoolVarP(&blockMode, "block", "", blockMode, "format with GPT partition table for raw block volumes instead of a filesystem")
+	formatDrivesCmd.PersistentFlags().StringVarP(&fsType, "fs-type", "", fsType, "filesystem to format the drives with. The possible values are xfs|ext4")
 	formatDrivesCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers,
 		"format based on access-tier set. The possible values are hot|cold|warm")
+	formatDrivesCmd.PersistentFlags().Int64VarP(&blockSize, "block-size", "", blockSize, "filesystem block size in bytes")
+	formatDrivesCmd.PersistentFlags().Int64VarP(&sectorSize, "sector-size", "", sectorSize, "filesystem sector size in bytes (xfs only)")
+	formatDrivesCmd.PersistentFlags().Int32VarP(&agCount, "ag-count", "", agCount, "number of allocation groups (xfs only)")
+	formatDrivesCmd.PersistentFlags().StringVarP(&logSize, "log-size", "", logSize, "size of the log or journal (e.g. 64MiB)")
+	formatDrivesCmd.PersistentFlags().BoolVarP(&reflink, "reflink", "", reflink, "enable reflink; enabled by default (xfs only)")
+	formatDrivesCmd.PersistentFlags().BoolVarP(&crc, "crc", "", crc, "enable metadata CRC; enabled by default (xfs only)")
 }
 
-func formatDrives(ctx context.Context, IDArgs []string) error {
+func getFormatOptions(c *cobra.Command) (*directcsi.FormatOptions, error) {
+	flags := c.Flags()
+	options := &directcsi.FormatOptions{}
+	changed := false
+	if flags.Changed("block-size") {
+		options.BlockSize = blockSize
+		changed = true
+	}
+	if flags.Changed("sector-size") {
+		options.SectorSize = sectorSize
+		changed = true
+	}
+	if flags.Changed("ag-count") {
+		options.AGCount = agCount
+		changed = true
+	}
+	if flags.Changed("log-size") {
+		size, err := humanize.ParseBytes(logSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log size %s; %v", utils.Bold(logSize), err)
+		}
+		options.LogSize = int64(size)
+		changed = true
+	}
+	if flags.Changed("reflink") {
+		value := reflink
+		options.Reflink = &value
+		changed = true
+	}
+	if flags.Changed("crc") {
+		value := crc
+		options.CRC = &value
+		changed = true
+	}
+	if !changed {
+		return nil, nil
+	}
+	return options, nil
+}
+
+func formatDrives(ctx context.Context, IDArgs []string, formatOptions *directcsi.FormatOptions) error {
 	directCSIClient := utils.GetDirectCSIClient()
 	return processFilteredDrives(
 		ctx,
@@ -143,8 +231,13 @@ func formatDrives(ctx context.Context, IDArgs []string) error {
 		func(drive *directcsi.DirectCSIDrive) error {
 			drive.Spec.DirectCSIOwned = true
 			drive.Spec.RequestedFormat = &directcsi.RequestedFormat{
-				Filesystem: xfs,
-				Force:      force,
+				Filesystem:    fsType,
+				Force:         force,
+				FormatOptions: formatOptions,
+			}
+			if blockMode {
+				drive.Spec.RequestedFormat.Filesystem = ""
+				drive.Spec.RequestedFormat.BlockMode = true
 			}
 			return nil
 		},
@@ -218,7 +218,7 @@ func TestFormatDrivesByAttributes(t1 *testing.T) {
 				t1.Fatalf("Test case name %s: validateDriveSelectors failed with %v", tt.name, err)
 			}
 
-			if err := formatDrives(ctx, []string{}); err != nil {
+			if err := formatDrives(ctx, []string{}, nil); err != nil {
 				t1.Errorf("Test case name %s: Failed with %v", tt.name, err)
 			}
 
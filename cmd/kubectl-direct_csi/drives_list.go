@@ -182,7 +182,7 @@ func listDrives(ctx context.Context, args []string) error {
 		"",
 	}
 	if wide {
-		headers = append(headers, "DRIVE ID", "MODEL")
+		headers = append(headers, "DRIVE ID", "MODEL", "TAINTS")
 	}
 
 	text.DisableColors()
@@ -202,9 +202,15 @@ func listDrives(ctx context.Context, args []string) error {
 			"",
 		)
 
+		volumeCount := 0
+		for _, finalizer := range d.Finalizers {
+			if strings.HasPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix) {
+				volumeCount++
+			}
+		}
 		volumes := "-"
-		if len(d.Finalizers) > 1 {
-			volumes = fmt.Sprintf("%v", len(d.Finalizers)-1)
+		if volumeCount > 0 {
+			volumes = fmt.Sprintf("%v", volumeCount)
 		}
 
 		accessTier := "-"
@@ -230,6 +236,13 @@ func listDrives(ctx context.Context, args []string) error {
 			}
 		}
 
+		if d.Spec.Unschedulable || utils.IsConditionStatus(d.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy), metav1.ConditionFalse) {
+			status += ",Cordoned"
+		}
+		if d.Spec.Evacuate {
+			status += ",Evacuating"
+		}
+
 		output := []interface{}{
 			drive,
 			printableBytes(d.Status.TotalCapacity),
@@ -243,7 +256,11 @@ func listDrives(ctx context.Context, args []string) error {
 		}
 
 		if wide {
-			output = append(output, d.Name, printableString(getModel(d)))
+			var taints []string
+			for _, taint := range d.GetTaints() {
+				taints = append(taints, taint.ToString())
+			}
+			output = append(output, d.Name, printableString(getModel(d)), printableString(strings.Join(taints, ",")))
 		}
 
 		t.AppendRow(output)
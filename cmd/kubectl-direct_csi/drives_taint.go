@@ -0,0 +1,112 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/ShayBenyo11/directcsi-minio/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/ShayBenyo11/directcsi-minio/pkg/utils"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+var drivesTaintCmd = &cobra.Command{
+	Use:   "taint KEY[=VALUE]:EFFECT",
+	Short: "taint DirectCSI drive(s) to repel volumes not tolerating the taint",
+	Long:  "",
+	Example: `
+# Taints all the 'Ready' DirectCSI drives for maintenance
+$ kubectl direct-csi drives taint maintenance=true:NoSchedule --status=ready
+
+# Taints 'sdf' drives in all nodes as degraded; such drives are used only if no other drive is available
+$ kubectl direct-csi drives taint degraded:PreferNoSchedule --drives '/dev/sdf'
+
+# Taints selective drives using ellipses notation for drive paths
+$ kubectl direct-csi drives taint maintenance=true:NoSchedule --drives '/dev/sd{a...z}'
+
+# Taints drives from selective nodes using ellipses notation for node names
+$ kubectl direct-csi drives taint maintenance=true:NoSchedule --nodes 'directcsi-{1...3}'
+
+# Combine multiple parameters using csv
+$ kubectl direct-csi drives taint maintenance=true:NoSchedule --nodes=directcsi-1,othernode-2 --status=ready
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("only one taint must be specified. please use '%s' for examples to taint drives", utils.Bold("--help"))
+		}
+		taint, err := directcsi.ParseDriveTaint(args[0])
+		if err != nil {
+			return err
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return taintDrives(c.Context(), taint)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	drivesTaintCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	drivesTaintCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	drivesTaintCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "taint all drives")
+	drivesTaintCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	drivesTaintCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func taintDrives(ctx context.Context, taint corev1.Taint) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	directCSIClient := utils.GetDirectCSIClient()
+	return processFilteredDrives(
+		ctx,
+		directCSIClient.DirectCSIDrives(),
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			if len(driveStatusList) > 0 && !drive.MatchDriveStatus(driveStatusList) {
+				return false
+			}
+			return drive.Status.DriveStatus != directcsi.DriveStatusUnavailable
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			drive.SetTaint(taint)
+			return nil
+		},
+		defaultDriveUpdateFunc(directCSIClient),
+	)
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/ShayBenyo11/directcsi-minio/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/ShayBenyo11/directcsi-minio/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var drivesUncordonCmd = &cobra.Command{
+	Use:   "uncordon",
+	Short: "uncordon DirectCSI drive(s) to allow placing new volumes on them",
+	Long:  "",
+	Example: `
+# Uncordons all the cordoned DirectCSI drives
+$ kubectl direct-csi drives uncordon --all
+
+# Uncordons 'sdf' drives in all nodes
+$ kubectl direct-csi drives uncordon --drives '/dev/sdf'
+
+# Uncordons drives from selective nodes using ellipses notation for node names
+$ kubectl direct-csi drives uncordon --nodes 'directcsi-{1...3}'
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return uncordonDrives(c.Context())
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	drivesUncordonCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	drivesUncordonCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	drivesUncordonCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "uncordon all drives")
+	drivesUncordonCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	drivesUncordonCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func uncordonDrives(ctx context.Context) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	directCSIClient := utils.GetDirectCSIClient()
+	return processFilteredDrives(
+		ctx,
+		directCSIClient.DirectCSIDrives(),
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			if len(driveStatusList) > 0 && !drive.MatchDriveStatus(driveStatusList) {
+				return false
+			}
+			return drive.Spec.Unschedulable
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			drive.Spec.Unschedulable = false
+			return nil
+		},
+		defaultDriveUpdateFunc(directCSIClient),
+	)
+}
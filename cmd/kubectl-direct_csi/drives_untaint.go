@@ -0,0 +1,113 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/ShayBenyo11/directcsi-minio/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/ShayBenyo11/directcsi-minio/pkg/utils"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+var drivesUntaintCmd = &cobra.Command{
+	Use:   "untaint KEY[:EFFECT]",
+	Short: "remove the taint from the DirectCSI drive(s)",
+	Long:  "",
+	Example: `
+# Removes the 'maintenance' taint from all the DirectCSI drives
+$ kubectl direct-csi drives untaint maintenance --all
+
+# Removes the 'degraded' taint of 'PreferNoSchedule' effect from 'sdf' drives in all nodes
+$ kubectl direct-csi drives untaint degraded:PreferNoSchedule --drives '/dev/sdf'
+
+# Removes the 'maintenance' taint from drives of selective nodes using ellipses notation for node names
+$ kubectl direct-csi drives untaint maintenance --nodes 'directcsi-{1...3}'
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		if !all {
+			if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(accessTiers) == 0 {
+				return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s' or '%s' must be specified",
+					utils.Bold("--all"),
+					utils.Bold("--drives"),
+					utils.Bold("--nodes"),
+					utils.Bold("--status"),
+					utils.Bold("--access-tier"))
+			}
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("only one taint key must be specified. please use '%s' for examples to untaint drives", utils.Bold("--help"))
+		}
+		tokens := strings.Split(args[0], ":")
+		if len(tokens) > 2 || tokens[0] == "" {
+			return fmt.Errorf("invalid taint %v; taint must be in KEY[:EFFECT] format", args[0])
+		}
+		var effect corev1.TaintEffect
+		if len(tokens) == 2 {
+			var err error
+			if effect, err = directcsi.ToDriveTaintEffect(tokens[1]); err != nil {
+				return err
+			}
+		}
+		if err := validateDriveSelectors(); err != nil {
+			return err
+		}
+		if len(driveGlobs) > 0 || len(nodeGlobs) > 0 || len(statusGlobs) > 0 {
+			klog.Warning("Glob matches will be deprecated soon. Please use ellipses instead")
+		}
+		return untaintDrives(c.Context(), tokens[0], effect)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	drivesUntaintCmd.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "filter by drive path(s) (also accepts ellipses range notations)")
+	drivesUntaintCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "filter by node name(s) (also accepts ellipses range notations)")
+	drivesUntaintCmd.PersistentFlags().BoolVarP(&all, "all", "a", all, "untaint all drives")
+	drivesUntaintCmd.PersistentFlags().StringSliceVarP(&status, "status", "s", status, fmt.Sprintf("match based on drive status [%s]", strings.Join(directcsi.SupportedStatusSelectorValues(), ", ")))
+	drivesUntaintCmd.PersistentFlags().StringSliceVarP(&accessTiers, "access-tier", "", accessTiers, "match based on access-tier set. The possible values are [hot,cold,warm] ")
+}
+
+func untaintDrives(ctx context.Context, key string, effect corev1.TaintEffect) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	directCSIClient := utils.GetDirectCSIClient()
+	return processFilteredDrives(
+		ctx,
+		directCSIClient.DirectCSIDrives(),
+		nil,
+		func(drive *directcsi.DirectCSIDrive) bool {
+			if len(driveStatusList) > 0 && !drive.MatchDriveStatus(driveStatusList) {
+				return false
+			}
+			return len(drive.Spec.DriveTaint) != 0
+		},
+		func(drive *directcsi.DirectCSIDrive) error {
+			drive.RemoveTaint(key, effect)
+			return nil
+		},
+		defaultDriveUpdateFunc(directCSIClient),
+	)
+}
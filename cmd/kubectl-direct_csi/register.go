@@ -41,6 +41,7 @@ const (
 	currentCRDStorageVersion = "v1beta3"
 	driveCRDName             = "directcsidrives.direct.csi.min.io"
 	volumeCRDName            = "directcsivolumes.direct.csi.min.io"
+	snapshotCRDName          = "directcsisnapshots.direct.csi.min.io"
 )
 
 func registerCRDs(ctx context.Context, identity string, writer io.Writer) error {
@@ -147,6 +148,13 @@ func syncCRD(ctx context.Context, existingCRD *apiextensions.CustomResourceDefin
 }
 
 func setConversionWebhook(ctx context.Context, crdObj *apiextensions.CustomResourceDefinition, identity string) error {
+	// snapshots are served only in the current storage version; no conversion required.
+	if crdObj.Name == snapshotCRDName {
+		crdObj.Spec.Conversion = &apiextensions.CustomResourceConversion{
+			Strategy: apiextensions.NoneConverter,
+		}
+		return nil
+	}
 
 	name := utils.SanitizeKubeResourceName(identity)
 	getServiceRef := func() *apiextensions.ServiceReference {
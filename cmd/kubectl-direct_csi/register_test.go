@@ -0,0 +1,65 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/minio/direct-csi/pkg/installer"
+	"github.com/minio/direct-csi/pkg/utils"
+)
+
+func TestRegisterCRDs(t *testing.T) {
+	utils.FakeInit()
+
+	ctx := context.TODO()
+	identity := "direct.csi.min.io"
+	if err := installer.CreateOrUpdateConversionCACertSecret(ctx, identity, []byte("test-ca-bundle"), false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unable to create conversion CA secret; %v", err)
+	}
+
+	testCases := []struct {
+		crdName  string
+		strategy apiextensions.ConversionStrategyType
+	}{
+		{driveCRDName, apiextensions.WebhookConverter},
+		{volumeCRDName, apiextensions.WebhookConverter},
+		{snapshotCRDName, apiextensions.NoneConverter},
+	}
+
+	// Register twice to cover both create and sync of CRDs.
+	for i := 0; i < 2; i++ {
+		if err := registerCRDs(ctx, identity, &bytes.Buffer{}); err != nil {
+			t.Fatalf("run %v: unable to register CRDs; %v", i+1, err)
+		}
+
+		for _, testCase := range testCases {
+			crd, err := utils.GetCRDClient().Get(ctx, testCase.crdName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("run %v: crd %v: %v", i+1, testCase.crdName, err)
+			}
+			if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != testCase.strategy {
+				t.Fatalf("run %v: crd %v: expected conversion strategy: %v, got: %+v", i+1, testCase.crdName, testCase.strategy, crd.Spec.Conversion)
+			}
+		}
+	}
+}
@@ -99,6 +99,37 @@ func removeVolumes(ctx context.Context, directCSIClient clientset.DirectV1beta3I
 	return err
 }
 
+func removeSnapshots(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface) error {
+	snapshotList, err := directCSIClient.DirectCSISnapshots().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if len(snapshotList.Items) > 0 && !forceRemove {
+		klog.Errorf("Cannot unregister DirectCSISnapshot CRDs. Please use `%s` to delete the resources", utils.Bold("--force"))
+		return nil
+	}
+
+	for i := range snapshotList.Items {
+		snapshot := &snapshotList.Items[i]
+		snapshot.SetFinalizers([]string{})
+		if _, err := directCSIClient.DirectCSISnapshots().Update(ctx, snapshot, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := directCSIClient.DirectCSISnapshots().Delete(ctx, snapshot.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func removeDrives(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface) error {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
@@ -160,6 +191,10 @@ func uninstall(ctx context.Context, args []string) error {
 			return err
 		}
 
+		if err := removeSnapshots(ctx, directCSIClient); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
 		if err := removeDrives(ctx, directCSIClient); err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
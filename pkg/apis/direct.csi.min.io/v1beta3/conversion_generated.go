@@ -195,9 +195,20 @@ func Convert_v1beta2_DirectCSIDriveList_To_v1beta3_DirectCSIDriveList(in *v1beta
 }
 
 func autoConvert_v1beta3_DirectCSIDriveSpec_To_v1beta2_DirectCSIDriveSpec(in *DirectCSIDriveSpec, out *v1beta2.DirectCSIDriveSpec, s conversion.Scope) error {
-	out.RequestedFormat = (*v1beta2.RequestedFormat)(unsafe.Pointer(in.RequestedFormat))
+	if in.RequestedFormat != nil {
+		in, out := &in.RequestedFormat, &out.RequestedFormat
+		*out = new(v1beta2.RequestedFormat)
+		if err := Convert_v1beta3_RequestedFormat_To_v1beta2_RequestedFormat(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestedFormat = nil
+	}
 	out.DirectCSIOwned = in.DirectCSIOwned
 	out.DriveTaint = *(*map[string]string)(unsafe.Pointer(&in.DriveTaint))
+	// INFO: in.MaxVolumes opted out of conversion generation
+	// INFO: in.Unschedulable opted out of conversion generation
+	// INFO: in.Evacuate opted out of conversion generation
 	return nil
 }
 
@@ -207,7 +218,15 @@ func Convert_v1beta3_DirectCSIDriveSpec_To_v1beta2_DirectCSIDriveSpec(in *Direct
 }
 
 func autoConvert_v1beta2_DirectCSIDriveSpec_To_v1beta3_DirectCSIDriveSpec(in *v1beta2.DirectCSIDriveSpec, out *DirectCSIDriveSpec, s conversion.Scope) error {
-	out.RequestedFormat = (*RequestedFormat)(unsafe.Pointer(in.RequestedFormat))
+	if in.RequestedFormat != nil {
+		in, out := &in.RequestedFormat, &out.RequestedFormat
+		*out = new(RequestedFormat)
+		if err := Convert_v1beta2_RequestedFormat_To_v1beta3_RequestedFormat(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestedFormat = nil
+	}
 	out.DirectCSIOwned = in.DirectCSIOwned
 	out.DriveTaint = *(*map[string]string)(unsafe.Pointer(&in.DriveTaint))
 	return nil
@@ -254,6 +273,11 @@ func autoConvert_v1beta3_DirectCSIDriveStatus_To_v1beta2_DirectCSIDriveStatus(in
 	// INFO: in.Partitioned opted out of conversion generation
 	// INFO: in.SwapOn opted out of conversion generation
 	// INFO: in.Master opted out of conversion generation
+	// INFO: in.BlockMode opted out of conversion generation
+	// INFO: in.IOErrors opted out of conversion generation
+	// INFO: in.Health opted out of conversion generation
+	// INFO: in.FormatOptions opted out of conversion generation
+	// INFO: in.BlockExtents opted out of conversion generation
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
@@ -322,7 +346,17 @@ func Convert_v1beta2_DirectCSIVolume_To_v1beta3_DirectCSIVolume(in *v1beta2.Dire
 
 func autoConvert_v1beta3_DirectCSIVolumeList_To_v1beta2_DirectCSIVolumeList(in *DirectCSIVolumeList, out *v1beta2.DirectCSIVolumeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta2.DirectCSIVolume)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta2.DirectCSIVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta3_DirectCSIVolume_To_v1beta2_DirectCSIVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -333,7 +367,17 @@ func Convert_v1beta3_DirectCSIVolumeList_To_v1beta2_DirectCSIVolumeList(in *Dire
 
 func autoConvert_v1beta2_DirectCSIVolumeList_To_v1beta3_DirectCSIVolumeList(in *v1beta2.DirectCSIVolumeList, out *DirectCSIVolumeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]DirectCSIVolume)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DirectCSIVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_DirectCSIVolume_To_v1beta3_DirectCSIVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -352,6 +396,13 @@ func autoConvert_v1beta3_DirectCSIVolumeStatus_To_v1beta2_DirectCSIVolumeStatus(
 	out.AvailableCapacity = in.AvailableCapacity
 	out.UsedCapacity = in.UsedCapacity
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
+	// INFO: in.SourceVolume opted out of conversion generation
+	// INFO: in.SourceSnapshot opted out of conversion generation
+	// INFO: in.ContentPopulated opted out of conversion generation
+	// INFO: in.BlockMode opted out of conversion generation
+	// INFO: in.PartitionNum opted out of conversion generation
+	// INFO: in.ProjectID opted out of conversion generation
+	// INFO: in.MountOptions opted out of conversion generation
 	return nil
 }
 
@@ -384,6 +435,8 @@ func autoConvert_v1beta3_RequestedFormat_To_v1beta2_RequestedFormat(in *Requeste
 	out.Filesystem = in.Filesystem
 	out.Mountpoint = in.Mountpoint
 	out.MountOptions = *(*[]string)(unsafe.Pointer(&in.MountOptions))
+	// INFO: in.BlockMode opted out of conversion generation
+	// INFO: in.FormatOptions opted out of conversion generation
 	return nil
 }
 
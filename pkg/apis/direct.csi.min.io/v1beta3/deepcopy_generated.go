@@ -26,6 +26,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockExtent) DeepCopyInto(out *BlockExtent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockExtent.
+func (in *BlockExtent) DeepCopy() *BlockExtent {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockExtent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectCSIDrive) DeepCopyInto(out *DirectCSIDrive) {
 	*out = *in
@@ -130,6 +146,21 @@ func (in *DirectCSIDriveStatus) DeepCopyInto(out *DirectCSIDriveStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(DriveHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FormatOptions != nil {
+		in, out := &in.FormatOptions, &out.FormatOptions
+		*out = new(FormatOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockExtents != nil {
+		in, out := &in.BlockExtents, &out.BlockExtents
+		*out = make([]BlockExtent, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -150,6 +181,89 @@ func (in *DirectCSIDriveStatus) DeepCopy() *DirectCSIDriveStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshot) DeepCopyInto(out *DirectCSISnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshot.
+func (in *DirectCSISnapshot) DeepCopy() *DirectCSISnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DirectCSISnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshotList) DeepCopyInto(out *DirectCSISnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DirectCSISnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshotList.
+func (in *DirectCSISnapshotList) DeepCopy() *DirectCSISnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DirectCSISnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectCSISnapshotStatus) DeepCopyInto(out *DirectCSISnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectCSISnapshotStatus.
+func (in *DirectCSISnapshotStatus) DeepCopy() *DirectCSISnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectCSISnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectCSIVolume) DeepCopyInto(out *DirectCSIVolume) {
 	*out = *in
@@ -220,6 +334,11 @@ func (in *DirectCSIVolumeStatus) DeepCopyInto(out *DirectCSIVolumeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -233,6 +352,53 @@ func (in *DirectCSIVolumeStatus) DeepCopy() *DirectCSIVolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveHealth) DeepCopyInto(out *DriveHealth) {
+	*out = *in
+	if in.CriticalWarnings != nil {
+		in, out := &in.CriticalWarnings, &out.CriticalWarnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveHealth.
+func (in *DriveHealth) DeepCopy() *DriveHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FormatOptions) DeepCopyInto(out *FormatOptions) {
+	*out = *in
+	if in.Reflink != nil {
+		in, out := &in.Reflink, &out.Reflink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CRC != nil {
+		in, out := &in.CRC, &out.CRC
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FormatOptions.
+func (in *FormatOptions) DeepCopy() *FormatOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(FormatOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestedFormat) DeepCopyInto(out *RequestedFormat) {
 	*out = *in
@@ -241,6 +407,11 @@ func (in *RequestedFormat) DeepCopyInto(out *RequestedFormat) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FormatOptions != nil {
+		in, out := &in.FormatOptions, &out.FormatOptions
+		*out = new(FormatOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,133 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ToDriveTaintEffect converts value to taint effect; drives support only NoSchedule and PreferNoSchedule effects.
+func ToDriveTaintEffect(value string) (corev1.TaintEffect, error) {
+	switch effect := corev1.TaintEffect(value); effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule:
+		return effect, nil
+	default:
+		return "", fmt.Errorf("unsupported taint effect %v; supported effects are %v and %v", value, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule)
+	}
+}
+
+func parseKeyValue(value string) (key, val string, err error) {
+	tokens := strings.SplitN(value, "=", 2)
+	key = tokens[0]
+	if len(tokens) == 2 {
+		val = tokens[1]
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return "", "", fmt.Errorf("invalid key %v; %v", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(val); len(errs) != 0 {
+		return "", "", fmt.Errorf("invalid value %v; %v", val, strings.Join(errs, "; "))
+	}
+	return key, val, nil
+}
+
+// ParseDriveTaint parses taint in "key[=value]:effect" format.
+func ParseDriveTaint(value string) (taint corev1.Taint, err error) {
+	tokens := strings.Split(value, ":")
+	if len(tokens) != 2 {
+		return taint, fmt.Errorf("invalid taint %v; taint must be in key[=value]:effect format", value)
+	}
+	if taint.Key, taint.Value, err = parseKeyValue(tokens[0]); err != nil {
+		return taint, fmt.Errorf("invalid taint %v; %v", value, err)
+	}
+	if taint.Effect, err = ToDriveTaintEffect(tokens[1]); err != nil {
+		return taint, fmt.Errorf("invalid taint %v; %v", value, err)
+	}
+	return taint, nil
+}
+
+// ParseDriveTolerations parses comma separated tolerations in "key[=value][:effect]" format.
+// A toleration without value tolerates any value of the key and a toleration without effect
+// tolerates all effects.
+func ParseDriveTolerations(value string) (tolerations []corev1.Toleration, err error) {
+	for _, token := range strings.Split(value, ",") {
+		if token = strings.TrimSpace(token); token == "" {
+			continue
+		}
+
+		var toleration corev1.Toleration
+		tokens := strings.Split(token, ":")
+		if len(tokens) > 2 {
+			return nil, fmt.Errorf("invalid toleration %v; toleration must be in key[=value][:effect] format", token)
+		}
+		if len(tokens) == 2 {
+			if toleration.Effect, err = ToDriveTaintEffect(tokens[1]); err != nil {
+				return nil, fmt.Errorf("invalid toleration %v; %v", token, err)
+			}
+		}
+		if toleration.Key, toleration.Value, err = parseKeyValue(tokens[0]); err != nil {
+			return nil, fmt.Errorf("invalid toleration %v; %v", token, err)
+		}
+		toleration.Operator = corev1.TolerationOpEqual
+		if !strings.Contains(tokens[0], "=") {
+			toleration.Operator = corev1.TolerationOpExists
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations, nil
+}
+
+// GetTaints returns the taints of the drive sorted by key. Spec.DriveTaint maps taint key to
+// "value:effect"; an entry without valid effect is treated as NoSchedule taint.
+func (drive *DirectCSIDrive) GetTaints() (taints []corev1.Taint) {
+	for key, value := range drive.Spec.DriveTaint {
+		taint := corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffectNoSchedule}
+		if i := strings.LastIndex(value, ":"); i >= 0 {
+			if effect, err := ToDriveTaintEffect(value[i+1:]); err == nil {
+				taint.Value, taint.Effect = value[:i], effect
+			}
+		}
+		taints = append(taints, taint)
+	}
+	sort.Slice(taints, func(i, j int) bool { return taints[i].Key < taints[j].Key })
+	return taints
+}
+
+// SetTaint sets the taint on the drive replacing existing taint of the same key.
+func (drive *DirectCSIDrive) SetTaint(taint corev1.Taint) {
+	if drive.Spec.DriveTaint == nil {
+		drive.Spec.DriveTaint = map[string]string{}
+	}
+	drive.Spec.DriveTaint[taint.Key] = taint.Value + ":" + string(taint.Effect)
+}
+
+// RemoveTaint removes the taint of the key from the drive; if effect is set, the taint is
+// removed only if it has the effect. It returns whether the taint is removed.
+func (drive *DirectCSIDrive) RemoveTaint(key string, effect corev1.TaintEffect) bool {
+	for _, taint := range drive.GetTaints() {
+		if taint.Key == key && (effect == "" || taint.Effect == effect) {
+			delete(drive.Spec.DriveTaint, key)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta3
+
+import (
+	"fmt"
+)
+
+const (
+	// xfsMaxLogSize is the largest log mkfs.xfs makes.
+	xfsMaxLogSize = 2136997888
+
+	// XFS log holds at least 512 filesystem blocks and 2MiB.
+	xfsMinLogBlocks = 512
+	xfsMinLogSize   = 2 * mib
+
+	// xfsMinAGSize and xfsMaxAGSize are the limits of allocation group size of XFS.
+	xfsMinAGSize = 16 * 1024 * 1024
+	xfsMaxAGSize = 1024 * 1024 * 1024 * 1024
+
+	// ext4 journal holds 1024 to 10240000 filesystem blocks.
+	ext4MinJournalBlocks = 1024
+	ext4MaxJournalBlocks = 10240000
+
+	defaultBlockSize = 4096
+	mib              = 1024 * 1024
+)
+
+func isPowerOfTwo(value int64) bool {
+	return value > 0 && value&(value-1) == 0
+}
+
+// IsReflinkEnabled returns whether the options make XFS with reflink; reflink is enabled by default.
+func (options *FormatOptions) IsReflinkEnabled() bool {
+	return options == nil || options.Reflink == nil || *options.Reflink
+}
+
+// IsCRCEnabled returns whether the options make XFS with metadata CRC; CRC is enabled by default.
+func (options *FormatOptions) IsCRCEnabled() bool {
+	return options == nil || options.CRC == nil || *options.CRC
+}
+
+// ValidateFormatOptions validates format options of the filesystem on a drive of given capacity.
+// Empty filesystem means xfs; zero capacity skips the checks depending on drive capacity.
+func ValidateFormatOptions(options *FormatOptions, filesystem string, capacity int64) error {
+	if options == nil {
+		return nil
+	}
+
+	for _, option := range []struct {
+		name  string
+		value int64
+	}{
+		{"block size", options.BlockSize},
+		{"sector size", options.SectorSize},
+		{"AG count", int64(options.AGCount)},
+		{"log size", options.LogSize},
+	} {
+		if option.value < 0 {
+			return fmt.Errorf("%v %v must not be negative", option.name, option.value)
+		}
+	}
+
+	blockSize := options.BlockSize
+	if blockSize == 0 {
+		blockSize = defaultBlockSize
+	}
+
+	switch filesystem {
+	case "", "xfs":
+		if options.BlockSize != 0 && (!isPowerOfTwo(options.BlockSize) || options.BlockSize < 512 || options.BlockSize > 65536) {
+			return fmt.Errorf("block size %v must be a power of 2 from 512 to 65536", options.BlockSize)
+		}
+		if options.IsCRCEnabled() && blockSize < 1024 {
+			return fmt.Errorf("block size %v must be at least 1024 with CRC enabled", blockSize)
+		}
+		if options.SectorSize != 0 {
+			if !isPowerOfTwo(options.SectorSize) || options.SectorSize < 512 || options.SectorSize > 32768 {
+				return fmt.Errorf("sector size %v must be a power of 2 from 512 to 32768", options.SectorSize)
+			}
+			if options.SectorSize > blockSize {
+				return fmt.Errorf("sector size %v must not be larger than block size %v", options.SectorSize, blockSize)
+			}
+		}
+		if options.IsReflinkEnabled() && !options.IsCRCEnabled() {
+			return fmt.Errorf("reflink requires CRC enabled; disable reflink to format without CRC")
+		}
+		if options.AGCount != 0 && capacity > 0 {
+			agSize := capacity / int64(options.AGCount)
+			if agSize < xfsMinAGSize {
+				return fmt.Errorf("AG count %v makes allocation groups smaller than %v bytes", options.AGCount, xfsMinAGSize)
+			}
+			if agSize > xfsMaxAGSize {
+				return fmt.Errorf("AG count %v makes allocation groups larger than %v bytes", options.AGCount, int64(xfsMaxAGSize))
+			}
+		}
+		if options.LogSize != 0 {
+			if options.LogSize%blockSize != 0 {
+				return fmt.Errorf("log size %v must be a multiple of block size %v", options.LogSize, blockSize)
+			}
+			minLogSize := xfsMinLogBlocks * blockSize
+			if minLogSize < xfsMinLogSize {
+				minLogSize = xfsMinLogSize
+			}
+			if options.LogSize < minLogSize {
+				return fmt.Errorf("log size %v must not be smaller than %v", options.LogSize, minLogSize)
+			}
+			if options.LogSize > xfsMaxLogSize {
+				return fmt.Errorf("log size %v must not be larger than %v", options.LogSize, xfsMaxLogSize)
+			}
+		}
+
+	case "ext4":
+		switch {
+		case options.SectorSize != 0:
+			return fmt.Errorf("sector size is not supported on ext4")
+		case options.Reflink != nil:
+			return fmt.Errorf("reflink is not supported on ext4")
+		case options.CRC != nil:
+			return fmt.Errorf("CRC is not supported on ext4")
+		case options.AGCount != 0:
+			return fmt.Errorf("AG count is not supported on ext4")
+		}
+		if options.BlockSize != 0 && (!isPowerOfTwo(options.BlockSize) || options.BlockSize < 1024 || options.BlockSize > 65536) {
+			return fmt.Errorf("block size %v must be a power of 2 from 1024 to 65536", options.BlockSize)
+		}
+		if options.LogSize != 0 {
+			if options.LogSize%mib != 0 {
+				return fmt.Errorf("log size %v must be a multiple of %v", options.LogSize, mib)
+			}
+			if blocks := options.LogSize / blockSize; blocks < ext4MinJournalBlocks || blocks > ext4MaxJournalBlocks {
+				return fmt.Errorf("log size %v must hold %v to %v blocks of %v bytes", options.LogSize, ext4MinJournalBlocks, ext4MaxJournalBlocks, blockSize)
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported filesystem %v", filesystem)
+	}
+
+	if capacity > 0 && options.LogSize >= capacity {
+		return fmt.Errorf("log size %v must be smaller than drive capacity %v", options.LogSize, capacity)
+	}
+
+	return nil
+}
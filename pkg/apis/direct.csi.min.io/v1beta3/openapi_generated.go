@@ -30,14 +30,56 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDrive":        schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDrive(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveList":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveList(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveSpec":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveSpec(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveStatus":  schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolume":       schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolume(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeList":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeList(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeStatus": schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref),
-		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.RequestedFormat":       schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.BlockExtent":             schema_pkg_apis_directcsiminio_v1beta3_BlockExtent(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDrive":          schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDrive(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveList":      schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveList(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveSpec":      schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveSpec(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIDriveStatus":    schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot":       schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshot(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotList":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotList(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus": schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotStatus(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolume":         schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolume(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeList":     schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeList(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSIVolumeStatus":   schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DriveHealth":             schema_pkg_apis_directcsiminio_v1beta3_DriveHealth(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.FormatOptions":           schema_pkg_apis_directcsiminio_v1beta3_FormatOptions(ref),
+		"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.RequestedFormat":         schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref),
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_BlockExtent(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlockExtent denotes the range of a drive reserved for the partition of a block volume.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"volume": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"offset": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Offset is the byte offset of the partition from the start of the drive.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+				},
+				Required: []string{"volume", "offset", "size"},
+			},
+		},
 	}
 }
 
@@ -174,6 +216,24 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveSpec(ref common.Refere
 							},
 						},
 					},
+					"evacuate": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"maxVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"unschedulable": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
 				},
 				Required: []string{"directCSIOwned"},
 			},
@@ -424,6 +484,41 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref common.Refe
 							Format: "",
 						},
 					},
+					"blockMode": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"ioErrors": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DriveHealth"),
+						},
+					},
+					"formatOptions": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.FormatOptions"),
+						},
+					},
+					"blockExtents": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.BlockExtent"),
+									},
+								},
+							},
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -451,6 +546,167 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIDriveStatus(ref common.Refe
 				Required: []string{"path"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.BlockExtent", "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DriveHealth", "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.FormatOptions", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshot denotes snapshot CRD object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus"),
+						},
+					},
+				},
+				Required: []string{"metadata"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshotList denotes list of snapshots.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "metdata is the standard list metadata.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.DirectCSISnapshot", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_DirectCSISnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DirectCSISnapshotStatus denotes snapshot information.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sourceVolume": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"drive": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"hostPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"totalCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type":       "map",
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		Dependencies: []string{
 			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
@@ -629,6 +885,61 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref common.Ref
 							},
 						},
 					},
+					"sourceVolume": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"sourceSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"contentPopulated": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"blockMode": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"partitionNum": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"projectID": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"mountOptions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -637,6 +948,123 @@ func schema_pkg_apis_directcsiminio_v1beta3_DirectCSIVolumeStatus(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_directcsiminio_v1beta3_DriveHealth(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DriveHealth denotes drive health read from S.M.A.R.T. or NVMe health log.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"temperature": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"powerOnHours": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"percentageUsed": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"mediaErrors": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"reallocatedSectors": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"pendingSectors": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"criticalWarnings": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_directcsiminio_v1beta3_FormatOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FormatOptions denotes options to make the filesystem with; unset option takes the default.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"blockSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"sectorSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"reflink": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"crc": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"agCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"logSize": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -687,8 +1115,21 @@ func schema_pkg_apis_directcsiminio_v1beta3_RequestedFormat(ref common.Reference
 							},
 						},
 					},
+					"blockMode": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"formatOptions": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.FormatOptions"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3.FormatOptions"},
 	}
 }
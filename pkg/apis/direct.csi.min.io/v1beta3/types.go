@@ -32,6 +32,12 @@ const (
 
 	// DirectCSIDriveFinalizerPrefix denotes prefix finalizer.
 	DirectCSIDriveFinalizerPrefix = Group + ".volume/"
+
+	// DirectCSIDriveFinalizerSnapshotPrefix denotes snapshot prefix finalizer.
+	DirectCSIDriveFinalizerSnapshotPrefix = Group + ".snapshot/"
+
+	// DirectCSISnapshotFinalizerPurgeProtection denotes snapshot purge protection finalizer.
+	DirectCSISnapshotFinalizerPurgeProtection = Group + "/snapshot-purge-protection"
 )
 
 // +genclient
@@ -58,6 +64,15 @@ type DirectCSIDriveSpec struct {
 	DirectCSIOwned bool `json:"directCSIOwned"`
 	// +optional
 	DriveTaint map[string]string `json:"driveTaint,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	MaxVolumes int32 `json:"maxVolumes,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	Unschedulable bool `json:"unschedulable,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	Evacuate bool `json:"evacuate,omitempty"`
 }
 
 // AccessTier denotes access tier.
@@ -163,6 +178,21 @@ type DirectCSIDriveStatus struct {
 	// +optional
 	// +k8s:conversion-gen=false
 	Master string `json:"master,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	BlockMode bool `json:"blockMode,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	IOErrors int64 `json:"ioErrors,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	Health *DriveHealth `json:"health,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	FormatOptions *FormatOptions `json:"formatOptions,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	BlockExtents []BlockExtent `json:"blockExtents,omitempty"`
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -170,6 +200,33 @@ type DirectCSIDriveStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// BlockExtent denotes the range of a drive reserved for the partition of a block volume.
+type BlockExtent struct {
+	Volume string `json:"volume"`
+	// Offset is the byte offset of the partition from the start of the drive.
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+}
+
+// DriveHealth denotes drive health read from S.M.A.R.T. or NVMe health log.
+type DriveHealth struct {
+	// +optional
+	Temperature int64 `json:"temperature,omitempty"`
+	// +optional
+	PowerOnHours int64 `json:"powerOnHours,omitempty"`
+	// +optional
+	PercentageUsed int64 `json:"percentageUsed,omitempty"`
+	// +optional
+	MediaErrors int64 `json:"mediaErrors,omitempty"`
+	// +optional
+	ReallocatedSectors int64 `json:"reallocatedSectors,omitempty"`
+	// +optional
+	PendingSectors int64 `json:"pendingSectors,omitempty"`
+	// +listType=atomic
+	// +optional
+	CriticalWarnings []string `json:"criticalWarnings,omitempty"`
+}
+
 // DirectCSIDriveCondition denotes drive condition.
 type DirectCSIDriveCondition string
 
@@ -185,6 +242,12 @@ const (
 
 	// DirectCSIDriveConditionInitialized denotes "Initialized" drive condition.
 	DirectCSIDriveConditionInitialized DirectCSIDriveCondition = "Initialized"
+
+	// DirectCSIDriveConditionDegraded denotes "Degraded" drive condition.
+	DirectCSIDriveConditionDegraded DirectCSIDriveCondition = "Degraded"
+
+	// DirectCSIDriveConditionHealthy denotes "Healthy" drive condition.
+	DirectCSIDriveConditionHealthy DirectCSIDriveCondition = "Healthy"
 )
 
 // DirectCSIDriveReason denotes drive reason.
@@ -199,6 +262,27 @@ const (
 
 	// DirectCSIDriveReasonInitialized denotes "Initialized" drive reason.
 	DirectCSIDriveReasonInitialized DirectCSIDriveReason = "Initialized"
+
+	// DirectCSIDriveReasonIOError denotes "IOError" drive reason.
+	DirectCSIDriveReasonIOError DirectCSIDriveReason = "IOError"
+
+	// DirectCSIDriveReasonFilesystemError denotes "FilesystemError" drive reason.
+	DirectCSIDriveReasonFilesystemError DirectCSIDriveReason = "FilesystemError"
+
+	// DirectCSIDriveReasonFilesystemShutdown denotes "FilesystemShutdown" drive reason.
+	DirectCSIDriveReasonFilesystemShutdown DirectCSIDriveReason = "FilesystemShutdown"
+
+	// DirectCSIDriveReasonHealthy denotes "Healthy" drive reason.
+	DirectCSIDriveReasonHealthy DirectCSIDriveReason = "Healthy"
+
+	// DirectCSIDriveReasonCriticalWarning denotes "CriticalWarning" drive reason.
+	DirectCSIDriveReasonCriticalWarning DirectCSIDriveReason = "CriticalWarning"
+
+	// DirectCSIDriveReasonMediaErrorThreshold denotes "MediaErrorThreshold" drive reason.
+	DirectCSIDriveReasonMediaErrorThreshold DirectCSIDriveReason = "MediaErrorThreshold"
+
+	// DirectCSIDriveReasonIOErrorThreshold denotes "IOErrorThreshold" drive reason.
+	DirectCSIDriveReasonIOErrorThreshold DirectCSIDriveReason = "IOErrorThreshold"
 )
 
 // DirectCSIDriveMessage denotes drive message.
@@ -231,6 +315,28 @@ type RequestedFormat struct {
 	// +listType=atomic
 	// +optional
 	MountOptions []string `json:"mountOptions,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	BlockMode bool `json:"blockMode,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	FormatOptions *FormatOptions `json:"formatOptions,omitempty"`
+}
+
+// FormatOptions denotes options to make the filesystem with; unset option takes the default.
+type FormatOptions struct {
+	// +optional
+	BlockSize int64 `json:"blockSize,omitempty"`
+	// +optional
+	SectorSize int64 `json:"sectorSize,omitempty"`
+	// +optional
+	Reflink *bool `json:"reflink,omitempty"`
+	// +optional
+	CRC *bool `json:"crc,omitempty"`
+	// +optional
+	AGCount int32 `json:"agCount,omitempty"`
+	// +optional
+	LogSize int64 `json:"logSize,omitempty"`
 }
 
 // DriveStatus denotes drive status.
@@ -305,6 +411,9 @@ const (
 
 	// DirectCSIVolumeConditionReady denotes "Ready" volume condition.
 	DirectCSIVolumeConditionReady DirectCSIVolumeCondition = "Ready"
+
+	// DirectCSIVolumeConditionEvacuated denotes "Evacuated" volume condition.
+	DirectCSIVolumeConditionEvacuated DirectCSIVolumeCondition = "Evacuated"
 )
 
 // DirectCSIVolumeReason denotes volume reason.
@@ -322,6 +431,15 @@ const (
 
 	// DirectCSIVolumeReasonNotReady denotes "NotReady" volume reason.
 	DirectCSIVolumeReasonNotReady DirectCSIVolumeReason = "NotReady"
+
+	// DirectCSIVolumeReasonEvacuating denotes "Evacuating" volume reason.
+	DirectCSIVolumeReasonEvacuating DirectCSIVolumeReason = "Evacuating"
+
+	// DirectCSIVolumeReasonEvacuated denotes "Evacuated" volume reason.
+	DirectCSIVolumeReasonEvacuated DirectCSIVolumeReason = "Evacuated"
+
+	// DirectCSIVolumeReasonEvacuationFailed denotes "EvacuationFailed" volume reason.
+	DirectCSIVolumeReasonEvacuationFailed DirectCSIVolumeReason = "EvacuationFailed"
 )
 
 // DirectCSIVolumeStatus denotes volume information.
@@ -348,4 +466,94 @@ type DirectCSIVolumeStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// +optional
+	// +k8s:conversion-gen=false
+	SourceVolume string `json:"sourceVolume,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	SourceSnapshot string `json:"sourceSnapshot,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	ContentPopulated bool `json:"contentPopulated,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	BlockMode bool `json:"blockMode,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	PartitionNum int `json:"partitionNum,omitempty"`
+	// +optional
+	// +k8s:conversion-gen=false
+	ProjectID int64 `json:"projectID,omitempty"`
+	// +listType=atomic
+	// +optional
+	// +k8s:conversion-gen=false
+	MountOptions []string `json:"mountOptions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSISnapshot denotes snapshot CRD object.
+type DirectCSISnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Status DirectCSISnapshotStatus `json:"status,omitempty"`
+}
+
+// DirectCSISnapshotCondition denotes snapshot condition.
+type DirectCSISnapshotCondition string
+
+const (
+	// DirectCSISnapshotConditionReady denotes "Ready" snapshot condition.
+	DirectCSISnapshotConditionReady DirectCSISnapshotCondition = "Ready"
+)
+
+// DirectCSISnapshotReason denotes snapshot reason.
+type DirectCSISnapshotReason string
+
+const (
+	// DirectCSISnapshotReasonReady denotes "Ready" snapshot reason.
+	DirectCSISnapshotReasonReady DirectCSISnapshotReason = "Ready"
+
+	// DirectCSISnapshotReasonNotReady denotes "NotReady" snapshot reason.
+	DirectCSISnapshotReasonNotReady DirectCSISnapshotReason = "NotReady"
+
+	// DirectCSISnapshotReasonFailed denotes "Failed" snapshot reason.
+	DirectCSISnapshotReasonFailed DirectCSISnapshotReason = "Failed"
+)
+
+// DirectCSISnapshotStatus denotes snapshot information.
+type DirectCSISnapshotStatus struct {
+	// +optional
+	SourceVolume string `json:"sourceVolume,omitempty"`
+	// +optional
+	Drive string `json:"drive,omitempty"`
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+	// +optional
+	TotalCapacity int64 `json:"totalCapacity"`
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSISnapshotList denotes list of snapshots.
+type DirectCSISnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metdata is the standard list metadata.
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	Items           []DirectCSISnapshot `json:"items"`
 }
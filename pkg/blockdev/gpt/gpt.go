@@ -86,7 +86,8 @@ type Table struct {
 	Entries []Entry
 }
 
-// Read reads GPT partition table from given reader.
+// Read reads GPT partition table from given reader; entry at index i is
+// partition number i+1 and empty entries denote unused partition numbers.
 func Read(reader io.Reader) (*Table, error) {
 	var header Header
 	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
@@ -111,9 +112,6 @@ func Read(reader io.Reader) (*Table, error) {
 		if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &entry); err != nil {
 			return nil, err
 		}
-		if isUUIDZero(entry.TypeGUID) {
-			break
-		}
 		entries = append(entries, entry)
 	}
 
@@ -152,6 +150,10 @@ func Probe(reader io.Reader) (*GPT, error) {
 	}
 	partitionMap := map[int]*parttable.Partition{}
 	for i, entry := range table.Entries {
+		// Deleted partitions leave empty entries in between used entries.
+		if entry.IsEmpty() {
+			continue
+		}
 		partitionMap[i+1] = &parttable.Partition{
 			Number: i + 1,
 			UUID:   UUID2String(entry.GUID),
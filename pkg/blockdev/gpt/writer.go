@@ -0,0 +1,399 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (c) 2021 MinIO, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package gpt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	numPartitionEntries = 128
+	partitionEntrySize  = 128
+	headerSize          = 92
+
+	// partitions are aligned to 1MiB boundary.
+	partitionAlignment = 1024 * 1024
+)
+
+var (
+	// ErrNoSpace denotes no free space found for partition error.
+	ErrNoSpace = errors.New("no free space left for partition")
+
+	// ErrNoFreeEntry denotes no free partition entry found error.
+	ErrNoFreeEntry = errors.New("no free partition entry")
+
+	// ErrPartitionNotFound denotes partition not found error.
+	ErrPartitionNotFound = errors.New("partition not found")
+)
+
+// String2UUID converts UUID string to GPT mixed endian UUID.
+func String2UUID(s string) (uuid [16]byte, err error) {
+	data := make([]byte, 0, 16)
+	for _, token := range strings.Split(s, "-") {
+		var value []byte
+		if _, err = fmt.Sscanf(token, "%x", &value); err != nil {
+			return uuid, fmt.Errorf("invalid UUID %v; %w", s, err)
+		}
+		data = append(data, value...)
+	}
+	if len(data) != 16 {
+		return uuid, fmt.Errorf("invalid UUID %v", s)
+	}
+
+	binary.LittleEndian.PutUint32(uuid[0:4], binary.BigEndian.Uint32(data[0:4]))
+	binary.LittleEndian.PutUint16(uuid[4:6], binary.BigEndian.Uint16(data[4:6]))
+	binary.LittleEndian.PutUint16(uuid[6:8], binary.BigEndian.Uint16(data[6:8]))
+	copy(uuid[8:], data[8:])
+	return uuid, nil
+}
+
+func entryArraySectors(sectorSize uint64) uint64 {
+	return (numPartitionEntries*partitionEntrySize + sectorSize - 1) / sectorSize
+}
+
+// NewTable returns empty GPT partition table for a device of totalSectors sectors.
+func NewTable(diskGUID [16]byte, totalSectors, sectorSize uint64) (*Table, error) {
+	entrySectors := entryArraySectors(sectorSize)
+	if totalSectors < 2*entrySectors+3 {
+		return nil, fmt.Errorf("device of %v sectors is too small for GPT", totalSectors)
+	}
+
+	header := Header{
+		Revision:               [4]byte{0, 0, 1, 0},
+		HeaderSize:             headerSize,
+		CurrentLBA:             1,
+		BackupLBA:              totalSectors - 1,
+		FirstUsableLBA:         2 + entrySectors,
+		LastUsableLBA:          totalSectors - 2 - entrySectors,
+		DiskGUID:               diskGUID,
+		PartitionEntryStartLBA: 2,
+		NumPartitionEntries:    numPartitionEntries,
+		PartitionEntrySize:     partitionEntrySize,
+	}
+	copy(header.Signature[:], "EFI PART")
+
+	return &Table{
+		Header:  header,
+		Entries: make([]Entry, numPartitionEntries),
+	}, nil
+}
+
+// ReadTable reads primary GPT partition table including empty partition
+// entries from given reader; entry at index i is partition number i+1.
+func ReadTable(reader io.ReaderAt, sectorSize uint64) (*Table, error) {
+	data := make([]byte, sectorSize)
+	if _, err := reader.ReadAt(data, int64(sectorSize)); err != nil {
+		return nil, err
+	}
+
+	var header Header
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if string(header.Signature[:]) != "EFI PART" {
+		return nil, errors.New("GPT signature not found")
+	}
+	if header.HeaderSize < headerSize || uint64(header.HeaderSize) > sectorSize {
+		return nil, fmt.Errorf("invalid GPT header size %v", header.HeaderSize)
+	}
+	if header.PartitionEntrySize < partitionEntrySize {
+		return nil, fmt.Errorf("invalid GPT partition entry size %v", header.PartitionEntrySize)
+	}
+
+	crc := header.CRC32
+	binary.LittleEndian.PutUint32(data[16:20], 0)
+	if crc32.ChecksumIEEE(data[:header.HeaderSize]) != crc {
+		return nil, errors.New("GPT header checksum mismatch")
+	}
+
+	entries := make([]byte, int(header.NumPartitionEntries)*int(header.PartitionEntrySize))
+	if _, err := reader.ReadAt(entries, int64(header.PartitionEntryStartLBA*sectorSize)); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(entries) != header.PartitionArrayCRC32 {
+		return nil, errors.New("GPT partition entries checksum mismatch")
+	}
+
+	table := &Table{Header: header}
+	for i := 0; i < int(header.NumPartitionEntries); i++ {
+		offset := i * int(header.PartitionEntrySize)
+		var entry Entry
+		if err := binary.Read(bytes.NewReader(entries[offset:offset+partitionEntrySize]), binary.LittleEndian, &entry); err != nil {
+			return nil, err
+		}
+		table.Entries = append(table.Entries, entry)
+	}
+
+	return table, nil
+}
+
+// PartitionName returns name of the partition entry.
+func (entry *Entry) PartitionName() string {
+	var name []uint16
+	for i := 0; i < len(entry.Name); i += 2 {
+		value := binary.LittleEndian.Uint16(entry.Name[i:])
+		if value == 0 {
+			break
+		}
+		name = append(name, value)
+	}
+	return string(utf16.Decode(name))
+}
+
+// IsEmpty returns whether the partition entry is unused.
+func (entry *Entry) IsEmpty() bool {
+	return isUUIDZero(entry.TypeGUID)
+}
+
+// FindPartition returns number of the partition by name; zero if not found.
+func (table *Table) FindPartition(name string) int {
+	for i := range table.Entries {
+		if !table.Entries[i].IsEmpty() && table.Entries[i].PartitionName() == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// AlignSize rounds size up to the partition alignment of 1MiB.
+func AlignSize(size uint64) uint64 {
+	return (size + partitionAlignment - 1) / partitionAlignment * partitionAlignment
+}
+
+// PartitionRange returns byte range [start, end) usable for partitions of a
+// device of size bytes; start is aligned to 1MiB boundary.
+func PartitionRange(size, sectorSize uint64) (start, end uint64) {
+	totalSectors := size / sectorSize
+	entrySectors := entryArraySectors(sectorSize)
+	if totalSectors < 2*entrySectors+3 {
+		return 0, 0
+	}
+	start = AlignSize((2 + entrySectors) * sectorSize)
+	end = (totalSectors - 1 - entrySectors) * sectorSize
+	if start > end {
+		return 0, 0
+	}
+	return start, end
+}
+
+func (table *Table) usedEntries() (used []Entry, index int) {
+	index = -1
+	for i := range table.Entries {
+		switch {
+		case !table.Entries[i].IsEmpty():
+			used = append(used, table.Entries[i])
+		case index < 0:
+			index = i
+		}
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].FirstLBA < used[j].FirstLBA })
+	return used, index
+}
+
+// AddPartition adds a partition of at least size bytes aligned to 1MiB in
+// the first free space of the table and returns the partition number.
+func (table *Table) AddPartition(typeGUID, guid [16]byte, name string, size, sectorSize uint64) (int, error) {
+	used, index := table.usedEntries()
+	if index < 0 {
+		return 0, ErrNoFreeEntry
+	}
+
+	alignment := uint64(partitionAlignment) / sectorSize
+	if alignment == 0 {
+		alignment = 1
+	}
+	alignUp := func(lba uint64) uint64 {
+		return (lba + alignment - 1) / alignment * alignment
+	}
+
+	sectors := (size + sectorSize - 1) / sectorSize
+	if sectors == 0 {
+		return 0, errors.New("partition size must not be zero")
+	}
+
+	firstLBA := alignUp(table.Header.FirstUsableLBA)
+	for _, entry := range used {
+		if firstLBA+sectors-1 < entry.FirstLBA {
+			break
+		}
+		if next := alignUp(entry.LastLBA + 1); next > firstLBA {
+			firstLBA = next
+		}
+	}
+	if firstLBA+sectors-1 > table.Header.LastUsableLBA {
+		return 0, ErrNoSpace
+	}
+
+	table.setEntry(index, typeGUID, guid, name, firstLBA, sectors)
+	return index + 1, nil
+}
+
+// AddPartitionAt adds a partition of at least size bytes at byte offset of
+// the device and returns the partition number; the range must be free.
+func (table *Table) AddPartitionAt(typeGUID, guid [16]byte, name string, offset, size, sectorSize uint64) (int, error) {
+	used, index := table.usedEntries()
+	if index < 0 {
+		return 0, ErrNoFreeEntry
+	}
+
+	if offset%sectorSize != 0 {
+		return 0, fmt.Errorf("partition offset %v is not aligned to sector size %v", offset, sectorSize)
+	}
+	sectors := (size + sectorSize - 1) / sectorSize
+	if sectors == 0 {
+		return 0, errors.New("partition size must not be zero")
+	}
+
+	firstLBA := offset / sectorSize
+	lastLBA := firstLBA + sectors - 1
+	if firstLBA < table.Header.FirstUsableLBA || lastLBA > table.Header.LastUsableLBA {
+		return 0, ErrNoSpace
+	}
+	for _, entry := range used {
+		if firstLBA <= entry.LastLBA && entry.FirstLBA <= lastLBA {
+			return 0, ErrNoSpace
+		}
+	}
+
+	table.setEntry(index, typeGUID, guid, name, firstLBA, sectors)
+	return index + 1, nil
+}
+
+func (table *Table) setEntry(index int, typeGUID, guid [16]byte, name string, firstLBA, sectors uint64) {
+	entry := Entry{
+		TypeGUID: typeGUID,
+		GUID:     guid,
+		FirstLBA: firstLBA,
+		LastLBA:  firstLBA + sectors - 1,
+	}
+	for i, value := range utf16.Encode([]rune(name)) {
+		if 2*i+1 >= len(entry.Name) {
+			break
+		}
+		binary.LittleEndian.PutUint16(entry.Name[2*i:], value)
+	}
+	table.Entries[index] = entry
+}
+
+// DeletePartition removes the partition by number.
+func (table *Table) DeletePartition(number int) error {
+	if number < 1 || number > len(table.Entries) || table.Entries[number-1].IsEmpty() {
+		return ErrPartitionNotFound
+	}
+	table.Entries[number-1] = Entry{}
+	return nil
+}
+
+// UsableSize returns size in bytes usable for partitions.
+func (table *Table) UsableSize(sectorSize uint64) uint64 {
+	return (table.Header.LastUsableLBA - table.Header.FirstUsableLBA + 1) * sectorSize
+}
+
+func protectiveMBR(totalSectors, sectorSize uint64) []byte {
+	data := make([]byte, sectorSize)
+	entry := data[446:462]
+	entry[2] = 0x02 // starting CHS sector.
+	entry[4] = 0xEE // GPT protective partition type.
+	entry[5], entry[6], entry[7] = 0xFF, 0xFF, 0xFF
+	binary.LittleEndian.PutUint32(entry[8:12], 1)
+	numSectors := totalSectors - 1
+	if numSectors > 0xFFFFFFFF {
+		numSectors = 0xFFFFFFFF
+	}
+	binary.LittleEndian.PutUint32(entry[12:16], uint32(numSectors))
+	data[510], data[511] = 0x55, 0xAA
+	return data
+}
+
+func marshalHeader(header Header, sectorSize uint64) ([]byte, error) {
+	header.CRC32 = 0
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, header); err != nil {
+		return nil, err
+	}
+	data := make([]byte, sectorSize)
+	copy(data, buf.Bytes())
+	binary.LittleEndian.PutUint32(data[16:20], crc32.ChecksumIEEE(data[:header.HeaderSize]))
+	return data, nil
+}
+
+// Write writes protective MBR, primary and backup GPT of given table to writer.
+func Write(writer io.WriterAt, table *Table, sectorSize uint64) error {
+	if len(table.Entries) != int(table.Header.NumPartitionEntries) {
+		return fmt.Errorf("number of entries %v does not match the header %v", len(table.Entries), table.Header.NumPartitionEntries)
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range table.Entries {
+		if err := binary.Write(&buf, binary.LittleEndian, entry); err != nil {
+			return err
+		}
+		buf.Write(make([]byte, int(table.Header.PartitionEntrySize)-partitionEntrySize))
+	}
+	entries := buf.Bytes()
+
+	primary := table.Header
+	primary.PartitionArrayCRC32 = crc32.ChecksumIEEE(entries)
+	if primary.CurrentLBA > primary.BackupLBA {
+		primary.CurrentLBA, primary.BackupLBA = primary.BackupLBA, primary.CurrentLBA
+	}
+	primary.PartitionEntryStartLBA = 2
+
+	backup := primary
+	backup.CurrentLBA, backup.BackupLBA = primary.BackupLBA, primary.CurrentLBA
+	backup.PartitionEntryStartLBA = primary.LastUsableLBA + 1
+
+	primaryData, err := marshalHeader(primary, sectorSize)
+	if err != nil {
+		return err
+	}
+	backupData, err := marshalHeader(backup, sectorSize)
+	if err != nil {
+		return err
+	}
+
+	// Write backup table first so that the primary table is valid only after
+	// everything is written.
+	writes := []struct {
+		data []byte
+		lba  uint64
+	}{
+		{entries, backup.PartitionEntryStartLBA},
+		{backupData, backup.CurrentLBA},
+		{protectiveMBR(primary.BackupLBA+1, sectorSize), 0},
+		{entries, primary.PartitionEntryStartLBA},
+		{primaryData, primary.CurrentLBA},
+	}
+	for _, w := range writes {
+		if _, err := writer.WriteAt(w.data, int64(w.lba*sectorSize)); err != nil {
+			return err
+		}
+	}
+
+	table.Header = primary
+	return nil
+}
@@ -0,0 +1,249 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (c) 2021 MinIO, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package gpt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestString2UUID(t *testing.T) {
+	testCases := []string{
+		"0fc63daf-8483-4772-8e79-3d69d8477de4",
+		"9a69a545-28c3-441c-a60b-6ed5223b03c3",
+	}
+	for i, testCase := range testCases {
+		uuid, err := String2UUID(testCase)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if result := UUID2String(uuid); result != testCase {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase, result)
+		}
+	}
+
+	if _, err := String2UUID("0fc63daf-8483"); err == nil {
+		t.Fatalf("expected error for invalid UUID")
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	const sectorSize = 512
+	const totalSectors = 16 * 1024 * 1024 / sectorSize
+
+	file, err := os.CreateTemp("", "gpt_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if err := file.Truncate(totalSectors * sectorSize); err != nil {
+		t.Fatal(err)
+	}
+
+	diskGUID, _ := String2UUID("8fe3e41c-ad8b-4fe6-9d0d-8d37d6ae3c66")
+	typeGUID, _ := String2UUID("0fc63daf-8483-4772-8e79-3d69d8477de4")
+	table, err := NewTable(diskGUID, totalSectors, sectorSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part1GUID, _ := String2UUID("9a69a545-28c3-441c-a60b-6ed5223b03c3")
+	part2GUID, _ := String2UUID("1a8a8c4b-6f0b-4a57-9a8e-0c5c1e1d3f21")
+	if partNum, err := table.AddPartition(typeGUID, part1GUID, "volume-1", 4*1024*1024, sectorSize); err != nil || partNum != 1 {
+		t.Fatalf("expected partition 1, got: %v, %v", partNum, err)
+	}
+	if partNum, err := table.AddPartition(typeGUID, part2GUID, "volume-2", 1, sectorSize); err != nil || partNum != 2 {
+		t.Fatalf("expected partition 2, got: %v, %v", partNum, err)
+	}
+	if _, err := table.AddPartition(typeGUID, part2GUID, "volume-3", 16*1024*1024, sectorSize); !errors.Is(err, ErrNoSpace) {
+		t.Fatalf("expected error: %v, got: %v", ErrNoSpace, err)
+	}
+	if err := Write(file, table, sectorSize); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ReadTable(file, sectorSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Header.DiskGUID != diskGUID || len(result.Entries) != numPartitionEntries {
+		t.Fatalf("unexpected table header %+v", result.Header)
+	}
+	if result.FindPartition("volume-1") != 1 || result.FindPartition("volume-2") != 2 || result.FindPartition("volume-3") != 0 {
+		t.Fatalf("partitions not found by name")
+	}
+	entry1, entry2 := result.Entries[0], result.Entries[1]
+	if entry1.FirstLBA != 2048 || entry1.LastLBA != 2048+8192-1 {
+		t.Fatalf("unexpected partition 1 range %v-%v", entry1.FirstLBA, entry1.LastLBA)
+	}
+	if entry2.FirstLBA != 2048+8192 || entry2.LastLBA != entry2.FirstLBA {
+		t.Fatalf("unexpected partition 2 range %v-%v", entry2.FirstLBA, entry2.LastLBA)
+	}
+
+	// Deleted partition space must be reused.
+	if err := result.DeletePartition(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := result.DeletePartition(1); !errors.Is(err, ErrPartitionNotFound) {
+		t.Fatalf("expected error: %v, got: %v", ErrPartitionNotFound, err)
+	}
+	if partNum, err := result.AddPartition(typeGUID, part1GUID, "volume-3", 1024*1024, sectorSize); err != nil || partNum != 1 {
+		t.Fatalf("expected partition 1, got: %v, %v", partNum, err)
+	}
+	if result.Entries[0].FirstLBA != 2048 {
+		t.Fatalf("expected freed space to be reused, got first LBA %v", result.Entries[0].FirstLBA)
+	}
+
+	// Written table must be readable by the probe reader.
+	if _, err := file.Seek(sectorSize, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	gpt, err := Probe(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gpt.UUID() != "8fe3e41c-ad8b-4fe6-9d0d-8d37d6ae3c66" || len(gpt.Partitions()) != 2 {
+		t.Fatalf("unexpected probe result %v %v", gpt.UUID(), gpt.Partitions())
+	}
+	if gpt.Partitions()[1].UUID != "9a69a545-28c3-441c-a60b-6ed5223b03c3" {
+		t.Fatalf("unexpected partition UUID %v", gpt.Partitions()[1].UUID)
+	}
+
+	// Backup header must be written at the last sector.
+	data := make([]byte, 8)
+	if _, err := file.ReadAt(data, (totalSectors-1)*sectorSize); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "EFI PART" {
+		t.Fatalf("backup GPT header not found")
+	}
+}
+
+func TestDeletePartitionProbe(t *testing.T) {
+	const sectorSize = 512
+	const totalSectors = 16 * 1024 * 1024 / sectorSize
+
+	file, err := os.CreateTemp("", "gpt_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if err := file.Truncate(totalSectors * sectorSize); err != nil {
+		t.Fatal(err)
+	}
+
+	diskGUID, _ := String2UUID("8fe3e41c-ad8b-4fe6-9d0d-8d37d6ae3c66")
+	typeGUID, _ := String2UUID("0fc63daf-8483-4772-8e79-3d69d8477de4")
+	table, err := NewTable(diskGUID, totalSectors, sectorSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guids := []string{
+		"9a69a545-28c3-441c-a60b-6ed5223b03c3",
+		"1a8a8c4b-6f0b-4a57-9a8e-0c5c1e1d3f21",
+		"4c2f1b7e-3d5a-4e8b-9f1c-2a6b7d8e9f01",
+	}
+	for i, value := range guids {
+		guid, _ := String2UUID(value)
+		if partNum, err := table.AddPartition(typeGUID, guid, fmt.Sprintf("volume-%v", i+1), 1024*1024, sectorSize); err != nil || partNum != i+1 {
+			t.Fatalf("expected partition %v, got: %v, %v", i+1, partNum, err)
+		}
+	}
+	if err := table.DeletePartition(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(file, table, sectorSize); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Seek(sectorSize, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	gpt, err := Probe(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partitions := gpt.Partitions()
+	if len(partitions) != 2 || partitions[1] != nil {
+		t.Fatalf("unexpected partitions %v", partitions)
+	}
+	for _, partNum := range []int{2, 3} {
+		if partitions[partNum] == nil || partitions[partNum].Number != partNum || partitions[partNum].UUID != guids[partNum-1] {
+			t.Fatalf("unexpected partition %v; %+v", partNum, partitions[partNum])
+		}
+	}
+}
+
+func TestAddPartitionAt(t *testing.T) {
+	const sectorSize = 512
+	const size = 16 * 1024 * 1024
+
+	diskGUID, _ := String2UUID("8fe3e41c-ad8b-4fe6-9d0d-8d37d6ae3c66")
+	typeGUID, _ := String2UUID("0fc63daf-8483-4772-8e79-3d69d8477de4")
+	guid, _ := String2UUID("9a69a545-28c3-441c-a60b-6ed5223b03c3")
+	table, err := NewTable(diskGUID, size/sectorSize, sectorSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := PartitionRange(size, sectorSize)
+	if start != 1024*1024 || end != (table.Header.LastUsableLBA+1)*sectorSize {
+		t.Fatalf("unexpected partition range %v-%v", start, end)
+	}
+	if AlignSize(1) != 1024*1024 || AlignSize(1024*1024) != 1024*1024 {
+		t.Fatalf("unexpected aligned size")
+	}
+
+	testCases := []struct {
+		offset      uint64
+		size        uint64
+		expectedNum int
+		expectErr   bool
+	}{
+		{4 * 1024 * 1024, 2 * 1024 * 1024, 1, false},
+		{start, 1024 * 1024, 2, false},
+		{5 * 1024 * 1024, 1024 * 1024, 0, true},
+		{3 * 1024 * 1024, 2 * 1024 * 1024, 0, true},
+		{end - 1024*1024, 2 * 1024 * 1024, 0, true},
+		{0, 1024 * 1024, 0, true},
+		{start + 1, 1024 * 1024, 0, true},
+		{6 * 1024 * 1024, 1024 * 1024, 3, false},
+	}
+	for i, testCase := range testCases {
+		partNum, err := table.AddPartitionAt(typeGUID, guid, fmt.Sprintf("volume-%v", i+1), testCase.offset, testCase.size, sectorSize)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil || partNum != testCase.expectedNum {
+			t.Fatalf("case %v: expected partition %v, got: %v, %v", i+1, testCase.expectedNum, partNum, err)
+		}
+		if entry := table.Entries[partNum-1]; entry.FirstLBA != testCase.offset/sectorSize {
+			t.Fatalf("case %v: unexpected first LBA %v", i+1, entry.FirstLBA)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/minio/direct-csi/pkg/blockdev/gpt"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// defaultSizeKey is the StorageClass parameter holding the size of a volume requested without capacity range.
+	defaultSizeKey = "direct-csi-min-io/default-size"
+
+	// minSizeKey is the StorageClass parameter holding the minimum size of a volume.
+	minSizeKey = "direct-csi-min-io/min-size"
+
+	// maxSizeKey is the StorageClass parameter holding the maximum size of a volume.
+	maxSizeKey = "direct-csi-min-io/max-size"
+)
+
+type sizePolicy struct {
+	defaultSize int64
+	minSize     int64
+	maxSize     int64
+}
+
+func parseSizeParameter(parameters map[string]string, key string) (int64, error) {
+	value, found := parameters[key]
+	if !found {
+		return 0, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v parameter %v; %w", key, value, err)
+	}
+
+	size, ok := quantity.AsInt64()
+	if !ok || size <= 0 {
+		return 0, fmt.Errorf("invalid %v parameter %v; size must be a positive number of bytes", key, value)
+	}
+
+	return size, nil
+}
+
+// getSizePolicy returns the size policy set by StorageClass parameters.
+func getSizePolicy(parameters map[string]string) (policy sizePolicy, err error) {
+	if policy.defaultSize, err = parseSizeParameter(parameters, defaultSizeKey); err != nil {
+		return policy, err
+	}
+	if policy.minSize, err = parseSizeParameter(parameters, minSizeKey); err != nil {
+		return policy, err
+	}
+	if policy.maxSize, err = parseSizeParameter(parameters, maxSizeKey); err != nil {
+		return policy, err
+	}
+
+	if policy.maxSize != 0 && policy.minSize > policy.maxSize {
+		return policy, fmt.Errorf("%v parameter must not be greater than %v parameter", minSizeKey, maxSizeKey)
+	}
+	if policy.defaultSize != 0 && policy.defaultSize < policy.minSize {
+		return policy, fmt.Errorf("%v parameter must not be less than %v parameter", defaultSizeKey, minSizeKey)
+	}
+	if policy.defaultSize != 0 && policy.maxSize != 0 && policy.defaultSize > policy.maxSize {
+		return policy, fmt.Errorf("%v parameter must not be greater than %v parameter", defaultSizeKey, maxSizeKey)
+	}
+
+	return policy, nil
+}
+
+// isSizeRequested returns whether the capacity range or the size parameters of the request constrain the volume size.
+func isSizeRequested(req *csi.CreateVolumeRequest) bool {
+	if req.GetCapacityRange().GetRequiredBytes() != 0 || req.GetCapacityRange().GetLimitBytes() != 0 {
+		return true
+	}
+
+	for _, key := range []string{defaultSizeKey, minSizeKey, maxSizeKey} {
+		if _, found := req.GetParameters()[key]; found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getVolumeSize returns the size of the volume to be created for the request. The required bytes
+// of the capacity range is used if set, else the size of the content source or the default size
+// parameter bounded by the limit bytes. Zero is returned if the request does not constrain the size.
+func getVolumeSize(req *csi.CreateVolumeRequest, sourceSize int64) (int64, error) {
+	requiredBytes := req.GetCapacityRange().GetRequiredBytes()
+	limitBytes := req.GetCapacityRange().GetLimitBytes()
+	if requiredBytes < 0 || limitBytes < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "required bytes %v and limit bytes %v must not be negative", requiredBytes, limitBytes)
+	}
+	if limitBytes != 0 && requiredBytes > limitBytes {
+		return 0, status.Errorf(codes.InvalidArgument, "required bytes %v is greater than limit bytes %v", requiredBytes, limitBytes)
+	}
+
+	policy, err := getSizePolicy(req.GetParameters())
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	size := requiredBytes
+	if size == 0 {
+		size = sourceSize
+	}
+	if size == 0 && req.GetVolumeContentSource() == nil {
+		size = policy.defaultSize
+		if limitBytes != 0 && (size == 0 || size > limitBytes) {
+			size = limitBytes
+		}
+	}
+
+	// Required bytes is the lower bound; round up to the minimum size.
+	if size < policy.minSize {
+		size = policy.minSize
+	}
+
+	// Partitions of block volumes are aligned to 1MiB; reserve the aligned size.
+	if size > 0 && isBlockAccessType(req.GetVolumeCapabilities()) {
+		size = int64(gpt.AlignSize(uint64(size)))
+	}
+
+	if policy.maxSize != 0 && size > policy.maxSize {
+		return 0, status.Errorf(codes.OutOfRange, "volume size %v is greater than maximum size %v", size, policy.maxSize)
+	}
+	if limitBytes != 0 && size > limitBytes {
+		return 0, status.Errorf(codes.OutOfRange, "volume size %v is greater than limit bytes %v", size, limitBytes)
+	}
+
+	return size, nil
+}
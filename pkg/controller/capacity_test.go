@@ -0,0 +1,108 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetVolumeSize(t *testing.T) {
+	newRequest := func(requiredBytes, limitBytes int64, parameters map[string]string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          "volume-1",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: requiredBytes, LimitBytes: limitBytes},
+			Parameters:    parameters,
+		}
+	}
+
+	testCases := []struct {
+		req          *csi.CreateVolumeRequest
+		sourceSize   int64
+		expectedSize int64
+		expectedCode codes.Code
+	}{
+		{&csi.CreateVolumeRequest{Name: "volume-1"}, 0, 0, codes.OK},
+		{newRequest(GiB, 0, nil), 0, GiB, codes.OK},
+		{newRequest(GiB, 2*GiB, nil), 0, GiB, codes.OK},
+		{newRequest(0, 2*GiB, nil), 0, 2 * GiB, codes.OK},
+		{newRequest(0, 0, nil), GiB, GiB, codes.OK},
+		{newRequest(0, 0, map[string]string{defaultSizeKey: "1Gi"}), 0, GiB, codes.OK},
+		{newRequest(0, 0, map[string]string{defaultSizeKey: "1Gi"}), 2 * GiB, 2 * GiB, codes.OK},
+		{newRequest(0, GiB, map[string]string{defaultSizeKey: "2Gi"}), 0, GiB, codes.OK},
+		{newRequest(GiB, 0, map[string]string{minSizeKey: "2Gi"}), 0, 2 * GiB, codes.OK},
+		{newRequest(0, 0, map[string]string{minSizeKey: "2Gi"}), 0, 2 * GiB, codes.OK},
+		{newRequest(GiB, GiB, map[string]string{minSizeKey: "2Gi"}), 0, 0, codes.OutOfRange},
+		{newRequest(3*GiB, 0, map[string]string{maxSizeKey: "2Gi"}), 0, 0, codes.OutOfRange},
+		{newRequest(0, 0, map[string]string{maxSizeKey: "2Gi"}), 3 * GiB, 0, codes.OutOfRange},
+		{newRequest(0, GiB, nil), 2 * GiB, 0, codes.OutOfRange},
+		{newRequest(-1, 0, nil), 0, 0, codes.InvalidArgument},
+		{newRequest(2*GiB, GiB, nil), 0, 0, codes.InvalidArgument},
+		{newRequest(GiB, 0, map[string]string{defaultSizeKey: "invalid"}), 0, 0, codes.InvalidArgument},
+		{newRequest(GiB, 0, map[string]string{maxSizeKey: "0"}), 0, 0, codes.InvalidArgument},
+		{newRequest(GiB, 0, map[string]string{minSizeKey: "2Gi", maxSizeKey: "1Gi"}), 0, 0, codes.InvalidArgument},
+		{newRequest(GiB, 0, map[string]string{defaultSizeKey: "3Gi", maxSizeKey: "2Gi"}), 0, 0, codes.InvalidArgument},
+	}
+
+	for i, testCase := range testCases {
+		size, err := getVolumeSize(testCase.req, testCase.sourceSize)
+		if status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected code: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+		if size != testCase.expectedSize {
+			t.Fatalf("case %v: expected size: %v, got: %v", i+1, testCase.expectedSize, size)
+		}
+	}
+}
+
+func TestCreateVolumeSizePolicy(t *testing.T) {
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(newStrategyTestDrive("drive-1", "node-1", 8*GiB))
+
+	// Volume without size is rejected instead of taking the whole drive.
+	if _, err := cl.CreateVolume(ctx, &csi.CreateVolumeRequest{Name: "volume-1"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected error code %v, got %v", codes.InvalidArgument, err)
+	}
+
+	res, err := cl.CreateVolume(ctx, newStrategyTestRequest("volume-1", map[string]string{defaultSizeKey: "2Gi"}))
+	if err != nil {
+		t.Fatalf("unable to create volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != GiB {
+		t.Fatalf("expected capacity %v, got %v", GiB, res.Volume.CapacityBytes)
+	}
+
+	res, err = cl.CreateVolume(ctx, &csi.CreateVolumeRequest{Name: "volume-2", Parameters: map[string]string{defaultSizeKey: "2Gi"}})
+	if err != nil {
+		t.Fatalf("unable to create volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != 2*GiB {
+		t.Fatalf("expected capacity %v, got %v", 2*GiB, res.Volume.CapacityBytes)
+	}
+
+	// Volume not fitting in the free capacity is rejected.
+	req := &csi.CreateVolumeRequest{Name: "volume-3", CapacityRange: &csi.CapacityRange{RequiredBytes: 6 * GiB}}
+	if _, err = cl.CreateVolume(ctx, req); status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected error code %v, got %v", codes.OutOfRange, err)
+	}
+}
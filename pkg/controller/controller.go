@@ -22,12 +22,16 @@ import (
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
 	"github.com/minio/direct-csi/pkg/matcher"
+	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -53,7 +57,10 @@ import (
  *       2. pv-protection: to prevent deletion of volume while
  *          associated PV is present (direct.csi.min.io/pv-protection)
  *
- *   - a finalizer by the volume name is added to the associated drive
+ *   - a finalizer by the volume name is added to the associated drive;
+ *     the drive is re-read and re-matched on conflicts so that parallel
+ *     requests cannot over-commit it, and the volume is rolled back if
+ *     the drive cannot be reserved
  *
  *   Deletion
  *   --------------
@@ -72,6 +79,16 @@ import (
  *       - the purge protection finalizer is first removed by
  *         the volume controller
  *
+ *   Expansion
+ *   --------------
+ *   - ControllerExpandVolume is called when the associated PVC
+ *     is resized
+ *
+ *   - the additional capacity is reserved in the associated drive
+ *     and the total capacity of the volume is updated
+ *
+ *   - NodeExpandVolume then raises the quota of the volume
+ *
  */
 
 type ControllerServer struct {
@@ -81,6 +98,7 @@ type ControllerServer struct {
 	Zone            string
 	Region          string
 	directcsiClient clientset.Interface
+	kubeClient      kubernetes.Interface
 }
 
 func NewControllerServer(ctx context.Context, identity, nodeID, rack, zone, region string) (*ControllerServer, error) {
@@ -91,6 +109,7 @@ func NewControllerServer(ctx context.Context, identity, nodeID, rack, zone, regi
 		Zone:            zone,
 		Region:          region,
 		directcsiClient: utils.GetDirectClientset(),
+		kubeClient:      utils.GetKubeClient(),
 	}
 	go serveAdmissionController(ctx) // Start admission webhook server
 	return controller, nil
@@ -104,6 +123,31 @@ func (c *ControllerServer) ControllerGetCapabilities(ctx context.Context, req *c
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CLONE_VOLUME},
+				},
+			},
 		},
 	}, nil
 }
@@ -115,6 +159,10 @@ func (c *ControllerServer) ValidateVolumeCapabilities(ctx context.Context, req *
 			message = fmt.Sprintf("unsupported access mode %s", vcap.GetAccessMode().GetMode())
 			break
 		}
+		if _, err := sys.ParseVolumeMountFlags(vcap.GetMount().GetMountFlags()); err != nil {
+			message = err.Error()
+			break
+		}
 	}
 
 	response := &csi.ValidateVolumeCapabilitiesResponse{
@@ -143,19 +191,69 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 		}
 	}
 
-	if len(req.GetVolumeCapabilities()) > 0 && req.GetVolumeCapabilities()[0].GetMount().GetFsType() != "xfs" {
-		return nil, status.Errorf(codes.InvalidArgument, "unsupported filesystem type %v", req.GetVolumeCapabilities()[0].GetMount().GetFsType())
+	blockMode := isBlockAccessType(req.GetVolumeCapabilities())
+	for _, vcap := range req.GetVolumeCapabilities() {
+		if vcap.GetBlock() != nil {
+			continue
+		}
+		if blockMode {
+			return nil, status.Error(codes.InvalidArgument, "block and mount access types cannot be requested together")
+		}
+		switch sys.FSType(vcap.GetMount().GetFsType()) {
+		case sys.FSTypeXFS, sys.FSTypeExt4:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported filesystem type %v", vcap.GetMount().GetFsType())
+		}
+		if _, err := sys.ParseVolumeMountFlags(vcap.GetMount().GetMountFlags()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
 
 	for key, value := range req.GetParameters() {
-		if key == "direct-csi-min-io/access-tier" {
+		switch key {
+		case "direct-csi-min-io/access-tier":
 			if _, err := directcsi.ToAccessTier(value); err != nil {
 				return nil, status.Errorf(codes.InvalidArgument, "unknown access-tier %v; %v", value, err)
 			}
+		case driveSelectionStrategyKey:
+			if _, err := getDriveSelectionStrategy(req.GetParameters()); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			if value == LabelSpreadStrategy && req.GetParameters()[spreadGroupKey] == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "%v parameter must be set for %v strategy", spreadGroupKey, LabelSpreadStrategy)
+			}
+		case driveTolerationsKey:
+			if _, err := directcsi.ParseDriveTolerations(value); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid %v parameter; %v", driveTolerationsKey, err)
+			}
+		case xfs.InodeLimitKey, xfs.SoftLimitPercentageKey, xfs.BlockGracePeriodKey, xfs.InodeGracePeriodKey:
+			if _, err := xfs.NewQuota(0, req.GetParameters()); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
 		}
 	}
 
-	drive, err := selectDrive(ctx, c.directcsiClient.DirectV1beta3().DirectCSIDrives(), req)
+	size, err := getVolumeSize(req, 0)
+	if err != nil {
+		return nil, err
+	}
+	// Only a block volume may take a whole drive without size.
+	if size == 0 && !blockMode && req.GetVolumeContentSource() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "volume size must be requested by capacity range or %v parameter", defaultSizeKey)
+	}
+
+	group, err := c.getVolumeGroup(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to get volume group of volume %v; %v", name, err)
+	}
+
+	var drive *directcsi.DirectCSIDrive
+	var source *contentSource
+	if req.GetVolumeContentSource() != nil {
+		drive, source, err = selectContentSourceDrive(ctx, c.directcsiClient.DirectV1beta3(), req)
+	} else {
+		drive, err = selectDrive(ctx, c.directcsiClient.DirectV1beta3(), req, group)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -165,9 +263,17 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 		"node", drive.Status.NodeName,
 		"volume", name)
 
-	size := drive.Status.FreeCapacity
-	if req.GetCapacityRange() != nil {
-		size = req.GetCapacityRange().GetRequiredBytes()
+	if source != nil {
+		if size, err = getVolumeSize(req, source.size); err != nil {
+			return nil, err
+		}
+	}
+
+	// A whole drive handed over to a block volume is accounted with all of
+	// its free capacity, but reports total capacity of the drive.
+	wholeDrive := isWholeDriveRequest(drive, req)
+	if wholeDrive {
+		size = drive.Status.TotalCapacity
 	}
 
 	labels := map[string]string{
@@ -177,6 +283,12 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 		utils.VersionLabel:           directcsi.Version,
 		utils.CreatedByLabel:         "directcsi-controller",
 	}
+	if group := req.GetParameters()[spreadGroupKey]; group != "" {
+		labels[utils.SpreadGroupLabel] = utils.SanitizeLabelV(group)
+	}
+	if group != "" {
+		labels[utils.VolumeGroupLabel] = utils.SanitizeLabelV(group)
+	}
 
 	newVolume := &directcsi.DirectCSIVolume{
 		ObjectMeta: metav1.ObjectMeta{
@@ -193,6 +305,7 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 			TotalCapacity:     size,
 			AvailableCapacity: size,
 			UsedCapacity:      0,
+			BlockMode:         blockMode,
 			Conditions: []metav1.Condition{
 				{
 					Type:               string(directcsi.DirectCSIVolumeConditionStaged),
@@ -218,55 +331,82 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 			},
 		},
 	}
+	if source != nil {
+		newVolume.Status.SourceVolume = source.volume
+		newVolume.Status.SourceSnapshot = source.snapshot
+	}
 
 	volumeInterface := c.directcsiClient.DirectV1beta3().DirectCSIVolumes()
-	if _, err := volumeInterface.Create(ctx, newVolume, metav1.CreateOptions{}); err != nil {
+	volume, err := volumeInterface.Create(ctx, newVolume, metav1.CreateOptions{})
+	created := err == nil
+	if err != nil {
 		if !errors.IsAlreadyExists(err) {
 			return nil, status.Errorf(codes.Internal, "could not create volume %s; %v", name, err)
 		}
 
-		volume, err := volumeInterface.Get(
+		volume, err = volumeInterface.Get(
 			ctx, newVolume.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
 		)
 		if err != nil {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
 
+		// Keep the drive an earlier request has reserved for the volume.
+		if volume.Status.Drive != "" && volume.Status.Drive != drive.Name {
+			reservedDrive, err := c.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(
+				ctx, volume.Status.Drive, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+			)
+			switch {
+			case err == nil && matcher.StringIn(reservedDrive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+name):
+				drive = reservedDrive
+				labels[utils.NodeLabel] = utils.SanitizeLabelV(drive.Status.NodeName)
+				labels[utils.ReservedDrivePathLabel] = utils.SanitizeDrivePath(drive.Status.Path)
+				labels[utils.DriveLabel] = utils.SanitizeLabelV(drive.Name)
+				newVolume.Status.Drive = drive.Name
+				newVolume.Status.NodeName = drive.Status.NodeName
+				newVolume.Status.TotalCapacity = volume.Status.TotalCapacity
+				newVolume.Status.AvailableCapacity = volume.Status.AvailableCapacity
+				size = volume.Status.TotalCapacity
+				wholeDrive = isWholeDriveRequest(drive, req)
+			case err != nil && !errors.IsNotFound(err):
+				return nil, status.Errorf(codes.Internal, "could not get drive %v of volume %v; %v", volume.Status.Drive, name, err)
+			}
+		}
+
 		volume.SetLabels(labels)
 		volume.Finalizers = newVolume.Finalizers
 		volume.Status = newVolume.Status
-		_, err = volumeInterface.Update(
+		volume, err = volumeInterface.Update(
 			ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()},
 		)
 		if err != nil {
 			return nil, err
 		}
-
-		utils.Eventf(volume, corev1.EventTypeNormal, "VolumeProvisioningSucceeded", "volume %v provisioned", volume.Name)
-	} else {
-		utils.Eventf(newVolume, corev1.EventTypeNormal, "VolumeProvisioningSucceeded", "volume %v is created", newVolume.Name)
 	}
 
-	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + req.GetName()
-	if !matcher.StringIn(drive.Finalizers, finalizer) {
-		drive.Status.FreeCapacity = drive.Status.FreeCapacity - size
-		drive.Status.AllocatedCapacity = drive.Status.AllocatedCapacity + size
-		drive.Status.DriveStatus = directcsi.DriveStatusInUse
-		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
-
-		klog.V(4).InfoS("Reserving DirectCSI drive",
-			"drive-name", drive.Name,
-			"node", drive.Status.NodeName,
-			"volume", name)
-
-		_, err = c.directcsiClient.DirectV1beta3().DirectCSIDrives().Update(
-			ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
-		)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "could not reserve drive[%s] %v", drive.Name, err)
-		} else {
-			utils.Eventf(drive, corev1.EventTypeNormal, "DriveReservationSucceded", "reserved drive %v on node %v and volume %v", drive.Name, drive.Status.NodeName, name)
+	reserved, err := c.reserveDrive(ctx, drive.Name, req, size, wholeDrive, group)
+	if err != nil {
+		// Roll back the volume created by this request to avoid leaving an
+		// orphan volume behind; a volume of an earlier request is retained.
+		if created {
+			if rerr := c.deleteVolumeObject(ctx, name); rerr != nil {
+				klog.ErrorS(rerr, "unable to roll back volume", "volume", name)
+			}
 		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "could not reserve drive[%s] %v", drive.Name, err)
+	}
+
+	if created {
+		utils.Eventf(volume, corev1.EventTypeNormal, "VolumeProvisioningSucceeded", "volume %v is created", name)
+	} else {
+		utils.Eventf(volume, corev1.EventTypeNormal, "VolumeProvisioningSucceeded", "volume %v provisioned", name)
+	}
+	if reserved != nil {
+		drive = reserved
+		utils.Eventf(drive, corev1.EventTypeNormal, "DriveReservationSucceded", "reserved drive %v on node %v and volume %v", drive.Name, drive.Status.NodeName, name)
 	}
 
 	return &csi.CreateVolumeResponse{
@@ -284,6 +424,97 @@ func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolu
 	}, nil
 }
 
+// reserveDrive reserves capacity of the drive for the volume and returns the
+// updated drive; nil drive is returned if the drive is already reserved for the
+// volume. The drive is re-read and re-matched on every attempt so that parallel
+// reservations cannot over-commit it.
+func (c *ControllerServer) reserveDrive(ctx context.Context, driveName string, req *csi.CreateVolumeRequest, size int64, wholeDrive bool, group string) (*directcsi.DirectCSIDrive, error) {
+	dclient := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + req.GetName()
+
+	var reserved *directcsi.DirectCSIDrive
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		reserved = nil
+		drive, err := dclient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if matcher.StringIn(drive.Finalizers, finalizer) {
+			return nil
+		}
+
+		if !matchDrive(*drive, req) || wholeDrive != isWholeDriveRequest(drive, req) {
+			return status.Errorf(codes.ResourceExhausted, "drive %v cannot satisfy volume %v anymore", drive.Name, req.GetName())
+		}
+
+		// Volumes of the same group must be kept on distinct drives.
+		if group != "" {
+			if err := checkVolumeGroup(ctx, c.directcsiClient.DirectV1beta3(), drive, req.GetName(), group); err != nil {
+				return err
+			}
+		}
+
+		// A whole drive handed over to a block volume is accounted with all of its free capacity.
+		delta := size
+		if wholeDrive {
+			delta = drive.Status.FreeCapacity
+		}
+		if drive.Status.FreeCapacity < delta {
+			return status.Errorf(codes.ResourceExhausted, "drive %v has only %v bytes free; %v bytes required", drive.Name, drive.Status.FreeCapacity, delta)
+		}
+
+		// A partition of a block volume takes a contiguous range of the drive.
+		if drive.Status.BlockMode && !wholeDrive {
+			offset, found := findFreeBlockExtent(*drive, size)
+			if !found {
+				return status.Errorf(codes.ResourceExhausted, "drive %v has no contiguous free range of %v bytes", drive.Name, size)
+			}
+			drive.Status.BlockExtents = append(drive.Status.BlockExtents, directcsi.BlockExtent{
+				Volume: req.GetName(),
+				Offset: offset,
+				Size:   size,
+			})
+		}
+
+		drive.Status.FreeCapacity -= delta
+		drive.Status.AllocatedCapacity += delta
+		drive.Status.DriveStatus = directcsi.DriveStatusInUse
+		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+
+		klog.V(4).InfoS("Reserving DirectCSI drive",
+			"drive-name", drive.Name,
+			"node", drive.Status.NodeName,
+			"volume", req.GetName())
+
+		reserved, err = dclient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+
+	return reserved, err
+}
+
+// deleteVolumeObject removes the volume object along with its finalizers.
+func (c *ControllerServer) deleteVolumeObject(ctx context.Context, volumeID string) error {
+	vclient := c.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := vclient.Get(ctx, volumeID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		volume.SetFinalizers(nil)
+		_, err = vclient.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+	if err == nil {
+		err = vclient.Delete(ctx, volumeID, metav1.DeleteOptions{})
+	}
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (c *ControllerServer) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
 	klog.V(3).InfoS("DeleteVolumeRequest", "name", req.GetVolumeId())
 	vID := req.GetVolumeId()
@@ -351,26 +582,378 @@ func (c *ControllerServer) ControllerUnpublishVolume(ctx context.Context, req *c
 	return nil, status.Error(codes.Unimplemented, "unimplemented")
 }
 
+// ControllerExpandVolume - Expands a DirectCSI Volume by reserving additional capacity in its drive
 func (c *ControllerServer) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("ControllerExpandVolumeRequest", "name", req.GetVolumeId(), "requiredBytes", req.GetCapacityRange().GetRequiredBytes())
+	vID := req.GetVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+
+	if req.GetCapacityRange() == nil {
+		return nil, status.Error(codes.InvalidArgument, "capacity range missing in request")
+	}
+
+	size := req.GetCapacityRange().GetRequiredBytes()
+	if limit := req.GetCapacityRange().GetLimitBytes(); limit > 0 && size > limit {
+		return nil, status.Errorf(codes.OutOfRange, "required bytes %v is greater than limit bytes %v", size, limit)
+	}
+
+	vclient := c.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+
+	var vol *directcsi.DirectCSIVolume
+	var expanded bool
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		expanded = false
+		if vol, err = vclient.Get(ctx, vID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+			return err
+		}
+		if vol.Status.BlockMode {
+			return status.Errorf(codes.InvalidArgument, "expansion of block volume [%s] is not supported", vID)
+		}
+
+		// Compute the delta from the volume being updated so that
+		// concurrent expansions do not reserve the same bytes twice.
+		extraBytes := size - vol.Status.TotalCapacity
+		if extraBytes <= 0 {
+			return nil
+		}
+
+		if err = c.updateDriveCapacity(ctx, vol.Status.Drive, vID, extraBytes); err != nil {
+			return err
+		}
+
+		vol.Status.AvailableCapacity += extraBytes
+		vol.Status.TotalCapacity = size
+		if _, err = vclient.Update(ctx, vol, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+			// Return the reserved bytes back to the drive.
+			if rerr := c.updateDriveCapacity(ctx, vol.Status.Drive, vID, -extraBytes); rerr != nil {
+				klog.ErrorS(rerr, "unable to release reserved capacity", "drive", vol.Status.Drive, "volume", vID, "bytes", extraBytes)
+			}
+			return err
+		}
+
+		expanded = true
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		if errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "volume [%s] not found; %v", vID, err)
+		}
+		return nil, status.Errorf(codes.Internal, "could not expand volume [%s]: %v", vID, err)
+	}
+
+	if expanded {
+		utils.Eventf(vol, corev1.EventTypeNormal, "VolumeExpansionSucceeded", "volume %v expanded to %v bytes on drive %v", vID, size, vol.Status.Drive)
+	}
+
+	return &csi.ControllerExpandVolumeResponse{
+		CapacityBytes:         vol.Status.TotalCapacity,
+		NodeExpansionRequired: true,
+	}, nil
+}
+
+// updateDriveCapacity reserves (positive delta) or releases (negative delta) capacity of a volume in its drive.
+func (c *ControllerServer) updateDriveCapacity(ctx context.Context, driveName, volumeID string, delta int64) error {
+	dclient := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := dclient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if !matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volumeID) {
+			return status.Errorf(codes.FailedPrecondition, "drive %v does not hold volume %v", drive.Name, volumeID)
+		}
+
+		if drive.Status.FreeCapacity < delta {
+			return status.Errorf(codes.ResourceExhausted, "drive %v has only %v bytes free; %v bytes required", drive.Name, drive.Status.FreeCapacity, delta)
+		}
+
+		drive.Status.FreeCapacity -= delta
+		drive.Status.AllocatedCapacity += delta
+		_, err = dclient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
 }
 
 func (c *ControllerServer) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "unimplemented")
 }
 
+// ListSnapshots - Lists DirectCSI Snapshots
 func (c *ControllerServer) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("ListSnapshotsRequest", "snapshotID", req.GetSnapshotId(), "sourceVolumeID", req.GetSourceVolumeId())
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max entries %v", req.GetMaxEntries())
+	}
+
+	sclient := c.directcsiClient.DirectV1beta3().DirectCSISnapshots()
+	vID := req.GetSourceVolumeId()
+
+	if sID := req.GetSnapshotId(); sID != "" {
+		snapshot, err := sclient.Get(ctx, sID, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return &csi.ListSnapshotsResponse{}, nil
+			}
+			return nil, status.Errorf(codes.Internal, "could not retreive snapshot [%s]: %v", sID, err)
+		}
+
+		if vID != "" && snapshot.Status.SourceVolume != vID {
+			return &csi.ListSnapshotsResponse{}, nil
+		}
+
+		return &csi.ListSnapshotsResponse{
+			Entries: []*csi.ListSnapshotsResponse_Entry{{Snapshot: newCSISnapshot(snapshot)}},
+		}, nil
+	}
+
+	options := metav1.ListOptions{
+		TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+		Limit:    int64(req.GetMaxEntries()),
+		Continue: req.GetStartingToken(),
+	}
+	if vID != "" {
+		options.LabelSelector = fmt.Sprintf("%s=%s", utils.VolumeLabel, utils.SanitizeLabelV(vID))
+	}
+
+	result, err := sclient.List(ctx, options)
+	if err != nil {
+		if errors.IsResourceExpired(err) || errors.IsBadRequest(err) {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %v; %v", req.GetStartingToken(), err)
+		}
+		return nil, status.Errorf(codes.Internal, "could not list snapshots: %v", err)
+	}
+
+	entries := []*csi.ListSnapshotsResponse_Entry{}
+	for i := range result.Items {
+		if vID != "" && result.Items[i].Status.SourceVolume != vID {
+			continue
+		}
+		entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: newCSISnapshot(&result.Items[i])})
+	}
+
+	return &csi.ListSnapshotsResponse{
+		Entries:   entries,
+		NextToken: result.Continue,
+	}, nil
 }
 
+// CreateSnapshot - Creates a DirectCSI Snapshot by reserving the capacity of source volume in its drive
 func (c *ControllerServer) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("CreateSnapshotRequest", "name", req.GetName(), "sourceVolumeID", req.GetSourceVolumeId())
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot name cannot be empty")
+	}
+
+	vID := req.GetSourceVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "source volume ID missing in request")
+	}
+
+	directCSIClient := c.directcsiClient.DirectV1beta3()
+	sclient := directCSIClient.DirectCSISnapshots()
+
+	snapshot, err := sclient.Get(ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+	switch {
+	case err == nil:
+		if snapshot.Status.SourceVolume != vID {
+			return nil, status.Errorf(codes.AlreadyExists, "snapshot [%s] already exists for volume [%s]", name, snapshot.Status.SourceVolume)
+		}
+		return &csi.CreateSnapshotResponse{Snapshot: newCSISnapshot(snapshot)}, nil
+	case !errors.IsNotFound(err):
+		return nil, status.Errorf(codes.Internal, "could not retreive snapshot [%s]: %v", name, err)
+	}
+
+	vol, err := directCSIClient.DirectCSIVolumes().Get(ctx, vID, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "volume [%s] not found", vID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not retreive volume [%s]: %v", vID, err)
+	}
+	if vol.Status.BlockMode {
+		return nil, status.Errorf(codes.InvalidArgument, "snapshot of block volume [%s] is not supported", vID)
+	}
+
+	if err := c.reserveSnapshotCapacity(ctx, vol.Status.Drive, name, vol.Status.TotalCapacity); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "could not reserve %v bytes in drive [%s]: %v", vol.Status.TotalCapacity, vol.Status.Drive, err)
+	}
+
+	newSnapshot := &directcsi.DirectCSISnapshot{
+		TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Finalizers: []string{
+				directcsi.DirectCSISnapshotFinalizerPurgeProtection,
+			},
+			Labels: map[string]string{
+				utils.NodeLabel:      utils.SanitizeLabelV(vol.Status.NodeName),
+				utils.DriveLabel:     utils.SanitizeLabelV(vol.Status.Drive),
+				utils.VolumeLabel:    utils.SanitizeLabelV(vID),
+				utils.VersionLabel:   directcsi.Version,
+				utils.CreatedByLabel: "directcsi-controller",
+			},
+		},
+		Status: directcsi.DirectCSISnapshotStatus{
+			SourceVolume:  vID,
+			Drive:         vol.Status.Drive,
+			NodeName:      vol.Status.NodeName,
+			TotalCapacity: vol.Status.TotalCapacity,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(directcsi.DirectCSISnapshotConditionReady),
+					Status:             metav1.ConditionFalse,
+					Message:            "",
+					Reason:             string(directcsi.DirectCSISnapshotReasonNotReady),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+
+	if snapshot, err = sclient.Create(ctx, newSnapshot, metav1.CreateOptions{}); err != nil {
+		if errors.IsAlreadyExists(err) {
+			// A parallel request created the snapshot and owns the reservation.
+			if snapshot, err = sclient.Get(ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()}); err == nil {
+				if snapshot.Status.SourceVolume != vID {
+					return nil, status.Errorf(codes.AlreadyExists, "snapshot [%s] already exists for volume [%s]", name, snapshot.Status.SourceVolume)
+				}
+				return &csi.CreateSnapshotResponse{Snapshot: newCSISnapshot(snapshot)}, nil
+			}
+		}
+		// Release the reservation to avoid leaving an orphan reservation behind.
+		if rerr := c.releaseSnapshotCapacity(ctx, vol.Status.Drive, name, vol.Status.TotalCapacity); rerr != nil {
+			klog.ErrorS(rerr, "unable to release snapshot reservation", "snapshot", name, "drive", vol.Status.Drive)
+		}
+		return nil, status.Errorf(codes.Internal, "could not create snapshot [%s]: %v", name, err)
+	}
+
+	utils.Eventf(snapshot, corev1.EventTypeNormal, "SnapshotCreationSucceeded", "snapshot %v of volume %v is created", name, vID)
+
+	return &csi.CreateSnapshotResponse{Snapshot: newCSISnapshot(snapshot)}, nil
 }
 
+// reserveSnapshotCapacity reserves capacity of a snapshot in the drive of its source volume.
+func (c *ControllerServer) reserveSnapshotCapacity(ctx context.Context, driveName, snapshotName string, size int64) error {
+	dclient := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	finalizer := directcsi.DirectCSIDriveFinalizerSnapshotPrefix + snapshotName
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := dclient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		if matcher.StringIn(drive.Finalizers, finalizer) {
+			return nil
+		}
+
+		// Snapshots are reflink copies, which only XFS supports.
+		if drive.Status.Filesystem != string(sys.FSTypeXFS) {
+			return status.Errorf(codes.InvalidArgument, "snapshot is not supported on %v filesystem of drive %v", drive.Status.Filesystem, drive.Name)
+		}
+		if !drive.Status.FormatOptions.IsReflinkEnabled() {
+			return status.Errorf(codes.InvalidArgument, "snapshot is not supported on drive %v formatted without reflink", drive.Name)
+		}
+
+		if drive.Status.FreeCapacity < size {
+			return status.Errorf(codes.ResourceExhausted, "drive %v has only %v bytes free; %v bytes required", drive.Name, drive.Status.FreeCapacity, size)
+		}
+
+		drive.Status.FreeCapacity -= size
+		drive.Status.AllocatedCapacity += size
+		drive.Status.DriveStatus = directcsi.DriveStatusInUse
+		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+		_, err = dclient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+}
+
+// releaseSnapshotCapacity releases capacity reserved for a snapshot by reserveSnapshotCapacity.
+func (c *ControllerServer) releaseSnapshotCapacity(ctx context.Context, driveName, snapshotName string, size int64) error {
+	dclient := c.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := dclient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		finalizers, found := utils.ExcludeFinalizer(drive.GetFinalizers(), directcsi.DirectCSIDriveFinalizerSnapshotPrefix+snapshotName)
+		if !found {
+			return nil
+		}
+
+		if len(finalizers) == 1 && finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+			drive.Status.DriveStatus = directcsi.DriveStatusReady
+		}
+
+		drive.SetFinalizers(finalizers)
+		drive.Status.FreeCapacity += size
+		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+		_, err = dclient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+}
+
+// DeleteSnapshot - Deletes a DirectCSI Snapshot; the snapshot is purged by its node
 func (c *ControllerServer) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(3).InfoS("DeleteSnapshotRequest", "name", req.GetSnapshotId())
+	sID := req.GetSnapshotId()
+	if sID == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot ID missing in request")
+	}
+
+	err := c.directcsiClient.DirectV1beta3().DirectCSISnapshots().Delete(ctx, sID, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "could not delete snapshot [%s]: %v", sID, err)
+	}
+
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
+// GetCapacity - Returns the free capacity of matching DirectCSI drives
 func (c *ControllerServer) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+	klog.V(5).InfoS("GetCapacityRequest", "topology", req.GetAccessibleTopology().GetSegments(), "parameters", req.GetParameters())
+	for _, vcap := range req.GetVolumeCapabilities() {
+		if vcap.GetAccessMode() != nil && vcap.GetAccessMode().GetMode() != csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported access mode: %s", vcap.GetAccessMode().GetMode())
+		}
+	}
+
+	for key, value := range req.GetParameters() {
+		if key == "direct-csi-min-io/access-tier" {
+			if _, err := directcsi.ToAccessTier(value); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown access-tier %v; %v", value, err)
+			}
+		}
+	}
+
+	resultCh, err := utils.ListDrives(ctx, c.directcsiClient.DirectV1beta3().DirectCSIDrives(), nil, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var availableCapacity int64
+	for result := range resultCh {
+		if result.Err != nil {
+			return nil, status.Error(codes.Internal, result.Err.Error())
+		}
+
+		// A volume cannot span drives, hence report the largest free capacity of a single drive.
+		if matchCapacityDrive(result.Drive, req) && getDriveFreeCapacity(result.Drive) > availableCapacity {
+			availableCapacity = getDriveFreeCapacity(result.Drive)
+		}
+	}
+
+	return &csi.GetCapacityResponse{
+		AvailableCapacity: availableCapacity,
+	}, nil
 }
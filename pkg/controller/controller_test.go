@@ -18,17 +18,24 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/direct-csi/pkg/matcher"
 	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 func init() {
@@ -37,6 +44,7 @@ func init() {
 
 const (
 	mb100 = 100 * 1024 * 1024
+	mb50  = 50 * 1024 * 1024
 	mb20  = 20 * 1024 * 1024
 )
 
@@ -48,6 +56,7 @@ func createFakeController() *ControllerServer {
 		Zone:            "test-zone-1",
 		Region:          "test-region-1",
 		directcsiClient: clientsetfake.NewSimpleClientset(),
+		kubeClient:      kubernetesfake.NewSimpleClientset(),
 	}
 }
 
@@ -348,6 +357,31 @@ func TestControllerGetCapabilities(t *testing.T) {
 					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_EXPAND_VOLUME},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{Type: csi.ControllerServiceCapability_RPC_CLONE_VOLUME},
+				},
+			},
 		},
 	}
 	if !reflect.DeepEqual(result, expectedResult) {
@@ -388,6 +422,19 @@ func TestValidateVolumeCapabilities(t *testing.T) {
 				Message: "unsupported access mode MULTI_NODE_MULTI_WRITER",
 			},
 		},
+		{
+			&csi.ValidateVolumeCapabilitiesRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{
+						AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{MountFlags: []string{"noexec", "sync"}}},
+						AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+					},
+				},
+			},
+			&csi.ValidateVolumeCapabilitiesResponse{
+				Message: "unsupported mount flag sync",
+			},
+		},
 	}
 
 	controller := createFakeController()
@@ -421,10 +468,104 @@ func TestControllerUnpublishVolume(t *testing.T) {
 	}
 }
 
+func newExpandVolumeTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-drive",
+				Finalizers: []string{
+					string(directcsi.DirectCSIDriveFinalizerDataProtection),
+					directcsi.DirectCSIDriveFinalizerPrefix + "test-volume",
+				},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          "N1",
+				Filesystem:        string(sys.FSTypeXFS),
+				DriveStatus:       directcsi.DriveStatusInUse,
+				TotalCapacity:     mb100,
+				FreeCapacity:      mb100 - mb20,
+				AllocatedCapacity: mb20,
+			},
+		},
+		&directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-volume",
+				Finalizers: []string{
+					string(directcsi.DirectCSIVolumeFinalizerPurgeProtection),
+				},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:          "N1",
+				Drive:             "test-drive",
+				TotalCapacity:     mb20,
+				AvailableCapacity: mb20,
+			},
+		},
+	}
+}
+
 func TestControllerExpandVolume(t *testing.T) {
 	if _, err := createFakeController().ControllerExpandVolume(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	testObjects := newExpandVolumeTestObjects()
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(testObjects...)
+
+	testCases := []struct {
+		requiredBytes int64
+		limitBytes    int64
+		expectErr     bool
+		totalCapacity int64
+	}{
+		{mb20, 0, false, mb20},
+		{2 * mb20, 0, false, 2 * mb20},
+		{3 * mb20, 2 * mb20, true, 2 * mb20},
+		{2 * mb100, 0, true, 2 * mb20},
+	}
+
+	for i, testCase := range testCases {
+		result, err := cl.ControllerExpandVolume(ctx, &csi.ControllerExpandVolumeRequest{
+			VolumeId: "test-volume",
+			CapacityRange: &csi.CapacityRange{
+				RequiredBytes: testCase.requiredBytes,
+				LimitBytes:    testCase.limitBytes,
+			},
+		})
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+			if result.CapacityBytes != testCase.totalCapacity || !result.NodeExpansionRequired {
+				t.Fatalf("case %v: unexpected result %#+v", i+1, result)
+			}
+		}
+
+		volume, err := cl.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "test-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: volume fetch error %v", i+1, err)
+		}
+		if volume.Status.TotalCapacity != testCase.totalCapacity {
+			t.Fatalf("case %v: expected total capacity %v, got %v", i+1, testCase.totalCapacity, volume.Status.TotalCapacity)
+		}
+
+		drive, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: drive fetch error %v", i+1, err)
+		}
+		if drive.Status.AllocatedCapacity != testCase.totalCapacity || drive.Status.FreeCapacity != mb100-testCase.totalCapacity {
+			t.Fatalf("case %v: unexpected drive capacity; allocated: %v, free: %v", i+1, drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+		}
+	}
 }
 
 func TestControllerGetVolume(t *testing.T) {
@@ -433,15 +574,335 @@ func TestControllerGetVolume(t *testing.T) {
 	}
 }
 
-func TestListSnapshots(t *testing.T) {
-	if _, err := createFakeController().ListSnapshots(context.TODO(), nil); err == nil {
+func TestCreateSnapshot(t *testing.T) {
+	if _, err := createFakeController().CreateSnapshot(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(newExpandVolumeTestObjects()...)
+
+	req := &csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "test-volume"}
+	// Create twice to ensure the capacity is reserved only once.
+	for i := 0; i < 2; i++ {
+		result, err := cl.CreateSnapshot(ctx, req)
+		if err != nil {
+			t.Fatalf("run %v: unexpected error %v", i+1, err)
+		}
+		if result.Snapshot.SnapshotId != "test-snapshot" || result.Snapshot.SourceVolumeId != "test-volume" || result.Snapshot.SizeBytes != mb20 {
+			t.Fatalf("run %v: unexpected snapshot %+v", i+1, result.Snapshot)
+		}
+		if result.Snapshot.ReadyToUse {
+			t.Fatalf("run %v: snapshot must not be ready before it is taken by the node", i+1)
+		}
+	}
+
+	drive, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.AllocatedCapacity != 2*mb20 || drive.Status.FreeCapacity != mb100-2*mb20 {
+		t.Fatalf("unexpected drive capacity; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+	if !matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerSnapshotPrefix+"test-snapshot") {
+		t.Fatalf("snapshot finalizer not found in %v", drive.Finalizers)
+	}
+
+	snapshot, err := cl.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(ctx, "test-snapshot", metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+	if err != nil {
+		t.Fatalf("snapshot fetch error %v", err)
+	}
+	if !matcher.StringIn(snapshot.Finalizers, directcsi.DirectCSISnapshotFinalizerPurgeProtection) {
+		t.Fatalf("purge protection finalizer not found in %v", snapshot.Finalizers)
+	}
+	if snapshot.Status.Drive != "test-drive" || snapshot.Status.NodeName != "N1" {
+		t.Fatalf("unexpected snapshot status %+v", snapshot.Status)
+	}
+
+	testCases := []struct {
+		req          *csi.CreateSnapshotRequest
+		expectedCode codes.Code
+	}{
+		{&csi.CreateSnapshotRequest{SourceVolumeId: "test-volume"}, codes.InvalidArgument},
+		{&csi.CreateSnapshotRequest{Name: "test-snapshot-2"}, codes.InvalidArgument},
+		{&csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "other-volume"}, codes.AlreadyExists},
+		{&csi.CreateSnapshotRequest{Name: "test-snapshot-2", SourceVolumeId: "unknown-volume"}, codes.NotFound},
+	}
+	for i, testCase := range testCases {
+		if _, err := cl.CreateSnapshot(ctx, testCase.req); status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected code: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+	}
 }
 
-func TestCreateSnapshot(t *testing.T) {
-	if _, err := createFakeController().CreateSnapshot(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+func TestCreateSnapshotInsufficientCapacity(t *testing.T) {
+	objects := newExpandVolumeTestObjects()
+	drive := objects[0].(*directcsi.DirectCSIDrive)
+	drive.Status.FreeCapacity = mb20 - 1
+
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(objects...)
+	_, err := cl.CreateSnapshot(context.TODO(), &csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "test-volume"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected code: %v, got: %v", codes.ResourceExhausted, err)
+	}
+
+	if _, err := cl.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(context.TODO(), "test-snapshot", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected snapshot not to be created; %v", err)
+	}
+}
+
+func TestCreateSnapshotReflinkDisabled(t *testing.T) {
+	reflink := false
+	objects := newExpandVolumeTestObjects()
+	drive := objects[0].(*directcsi.DirectCSIDrive)
+	drive.Status.FormatOptions = &directcsi.FormatOptions{Reflink: &reflink}
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(objects...)
+	if _, err := cl.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "test-volume"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("snapshot: expected code: %v, got: %v", codes.InvalidArgument, err)
+	}
+
+	req := &csi.CreateVolumeRequest{
+		Name:          "clone-volume",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+				AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+			},
+		},
+		VolumeContentSource: &csi.VolumeContentSource{
+			Type: &csi.VolumeContentSource_Volume{Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: "test-volume"}},
+		},
+	}
+	if _, err := cl.CreateVolume(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("clone: expected code: %v, got: %v", codes.InvalidArgument, err)
+	}
+
+	drive, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.AllocatedCapacity != mb20 || drive.Status.FreeCapacity != mb100-mb20 {
+		t.Fatalf("unexpected drive capacity; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+}
+
+func TestCreateSnapshotRollback(t *testing.T) {
+	ctx := context.TODO()
+	clientset := clientsetfake.NewSimpleClientset(newExpandVolumeTestObjects()...)
+	clientset.PrependReactor("create", "directcsisnapshots", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("injected create failure")
+	})
+
+	cl := createFakeController()
+	cl.directcsiClient = clientset
+	_, err := cl.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "test-volume"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected code: %v, got: %v", codes.Internal, err)
+	}
+
+	drive, err := clientset.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.AllocatedCapacity != mb20 || drive.Status.FreeCapacity != mb100-mb20 {
+		t.Fatalf("reservation not released; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+	if matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerSnapshotPrefix+"test-snapshot") {
+		t.Fatalf("snapshot finalizer not removed from %v", drive.Finalizers)
+	}
+}
+
+func TestCreateVolumeFromContentSource(t *testing.T) {
+	newRequest := func(name string, requiredBytes int64, source *csi.VolumeContentSource) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: requiredBytes},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			VolumeContentSource: source,
+		}
+	}
+	volumeSource := func(volumeID string) *csi.VolumeContentSource {
+		return &csi.VolumeContentSource{
+			Type: &csi.VolumeContentSource_Volume{Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: volumeID}},
+		}
+	}
+	snapshotSource := func(snapshotID string) *csi.VolumeContentSource {
+		return &csi.VolumeContentSource{
+			Type: &csi.VolumeContentSource_Snapshot{Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: snapshotID}},
+		}
+	}
+	newSnapshot := func(name string, ready bool) *directcsi.DirectCSISnapshot {
+		return &directcsi.DirectCSISnapshot{
+			TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume:  "test-volume",
+				Drive:         "test-drive",
+				NodeName:      "N1",
+				TotalCapacity: mb20,
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(directcsi.DirectCSISnapshotConditionReady),
+						Status: utils.BoolToCondition(ready),
+					},
+				},
+			},
+		}
+	}
+
+	objects := append(newExpandVolumeTestObjects(), newSnapshot("ready-snapshot", true), newSnapshot("pending-snapshot", false))
+	objects = append(objects, &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "other-drive",
+			Finalizers: []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      "N2",
+			Filesystem:    string(sys.FSTypeXFS),
+			DriveStatus:   directcsi.DriveStatusReady,
+			TotalCapacity: mb100,
+			FreeCapacity:  mb100,
+		},
+	})
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(objects...)
+
+	successCases := []struct {
+		req                    *csi.CreateVolumeRequest
+		expectedSize           int64
+		expectedSourceVolume   string
+		expectedSourceSnapshot string
+	}{
+		{newRequest("clone-volume", 0, volumeSource("test-volume")), mb20, "test-volume", ""},
+		{newRequest("restored-volume", mb50, snapshotSource("ready-snapshot")), mb50, "", "ready-snapshot"},
+	}
+	for i, testCase := range successCases {
+		// Create twice to ensure the capacity is reserved only once.
+		for j := 0; j < 2; j++ {
+			result, err := cl.CreateVolume(ctx, testCase.req)
+			if err != nil {
+				t.Fatalf("case %v: run %v: unexpected error %v", i+1, j+1, err)
+			}
+			if result.Volume.CapacityBytes != testCase.expectedSize || result.Volume.ContentSource != testCase.req.VolumeContentSource {
+				t.Fatalf("case %v: run %v: unexpected volume %+v", i+1, j+1, result.Volume)
+			}
+		}
+
+		volume, err := cl.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, testCase.req.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: volume fetch error %v", i+1, err)
+		}
+		if volume.Status.Drive != "test-drive" {
+			t.Fatalf("case %v: expected drive: test-drive, got: %v", i+1, volume.Status.Drive)
+		}
+		if volume.Status.SourceVolume != testCase.expectedSourceVolume || volume.Status.SourceSnapshot != testCase.expectedSourceSnapshot {
+			t.Fatalf("case %v: unexpected volume source %+v", i+1, volume.Status)
+		}
+	}
+
+	drive, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.AllocatedCapacity != mb20+mb20+mb50 || drive.Status.FreeCapacity != mb100-mb20-mb20-mb50 {
+		t.Fatalf("unexpected drive capacity; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+
+	errorCases := []struct {
+		req          *csi.CreateVolumeRequest
+		expectedCode codes.Code
+	}{
+		{newRequest("volume-1", 0, volumeSource("unknown-volume")), codes.NotFound},
+		{newRequest("volume-2", 0, snapshotSource("unknown-snapshot")), codes.NotFound},
+		{newRequest("volume-3", 0, snapshotSource("pending-snapshot")), codes.FailedPrecondition},
+		{newRequest("volume-4", mb20-1, volumeSource("test-volume")), codes.OutOfRange},
+		{newRequest("volume-5", mb50, volumeSource("test-volume")), codes.ResourceExhausted},
+		{newRequest("volume-6", 0, &csi.VolumeContentSource{}), codes.InvalidArgument},
+	}
+	for i, testCase := range errorCases {
+		if _, err := cl.CreateVolume(ctx, testCase.req); status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected code: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+	}
+}
+
+func TestListSnapshots(t *testing.T) {
+	newSnapshot := func(name, volume string) *directcsi.DirectCSISnapshot {
+		return &directcsi.DirectCSISnapshot{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.VolumeLabel: utils.SanitizeLabelV(volume)},
+			},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume:  volume,
+				TotalCapacity: mb20,
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(directcsi.DirectCSISnapshotConditionReady),
+						Status: metav1.ConditionTrue,
+						Reason: string(directcsi.DirectCSISnapshotReasonReady),
+					},
+				},
+			},
+		}
+	}
+
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(
+		newSnapshot("snapshot-1", "volume-1"),
+		newSnapshot("snapshot-2", "volume-1"),
+		newSnapshot("snapshot-3", "volume-2"),
+	)
+
+	testCases := []struct {
+		req             *csi.ListSnapshotsRequest
+		expectedEntries int
+		expectErr       bool
+	}{
+		{&csi.ListSnapshotsRequest{}, 3, false},
+		{&csi.ListSnapshotsRequest{SourceVolumeId: "volume-1"}, 2, false},
+		{&csi.ListSnapshotsRequest{SourceVolumeId: "volume-3"}, 0, false},
+		{&csi.ListSnapshotsRequest{SnapshotId: "snapshot-3"}, 1, false},
+		{&csi.ListSnapshotsRequest{SnapshotId: "snapshot-3", SourceVolumeId: "volume-1"}, 0, false},
+		{&csi.ListSnapshotsRequest{SnapshotId: "unknown-snapshot"}, 0, false},
+		{&csi.ListSnapshotsRequest{MaxEntries: -1}, 0, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := cl.ListSnapshots(context.TODO(), testCase.req)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if len(result.Entries) != testCase.expectedEntries {
+			t.Fatalf("case %v: expected entries: %v, got: %v", i+1, testCase.expectedEntries, len(result.Entries))
+		}
+		for _, entry := range result.Entries {
+			if !entry.Snapshot.ReadyToUse || entry.Snapshot.SizeBytes != mb20 {
+				t.Fatalf("case %v: unexpected snapshot %+v", i+1, entry.Snapshot)
+			}
+		}
 	}
 }
 
@@ -449,10 +910,600 @@ func TestDeleteSnapshot(t *testing.T) {
 	if _, err := createFakeController().DeleteSnapshot(context.TODO(), nil); err == nil {
 		t.Fatal("error expected")
 	}
+
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(&directcsi.DirectCSISnapshot{
+		TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "test-snapshot"},
+	})
+
+	// Deleting a missing snapshot must succeed.
+	for i := 0; i < 2; i++ {
+		if _, err := cl.DeleteSnapshot(context.TODO(), &csi.DeleteSnapshotRequest{SnapshotId: "test-snapshot"}); err != nil {
+			t.Fatalf("run %v: unexpected error %v", i+1, err)
+		}
+	}
+
+	if _, err := cl.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(context.TODO(), "test-snapshot", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected snapshot to be deleted; %v", err)
+	}
 }
 
 func TestGetCapacity(t *testing.T) {
-	if _, err := createFakeController().GetCapacity(context.TODO(), nil); err == nil {
-		t.Fatal("error expected")
+	createTestDrive := func(name, node string, driveStatus directcsi.DriveStatus, accessTier directcsi.AccessTier, freeCapacity int64) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:     node,
+				Filesystem:   string(sys.FSTypeXFS),
+				DriveStatus:  driveStatus,
+				AccessTier:   accessTier,
+				FreeCapacity: freeCapacity,
+				Topology:     map[string]string{"node": node},
+			},
+		}
+	}
+
+	testObjects := []runtime.Object{
+		createTestDrive("drive-1", "N1", directcsi.DriveStatusReady, directcsi.AccessTierUnknown, mb100),
+		createTestDrive("drive-2", "N1", directcsi.DriveStatusInUse, directcsi.AccessTierHot, mb20),
+		createTestDrive("drive-3", "N1", directcsi.DriveStatusAvailable, directcsi.AccessTierUnknown, mb100),
+		createTestDrive("drive-4", "N2", directcsi.DriveStatusReady, directcsi.AccessTierHot, mb100),
+		createTestDrive("drive-5", "N2", directcsi.DriveStatusReady, directcsi.AccessTierUnknown, 2*mb100),
+	}
+	testObjects[4].(*directcsi.DirectCSIDrive).Status.Filesystem = ""
+	testObjects[4].(*directcsi.DirectCSIDrive).Status.BlockMode = true
+	testObjects[4].(*directcsi.DirectCSIDrive).Status.TotalCapacity = 4 * mb100
+
+	testCases := []struct {
+		request          *csi.GetCapacityRequest
+		expectedCapacity int64
+		expectErr        bool
+	}{
+		{&csi.GetCapacityRequest{}, mb100, false},
+		{&csi.GetCapacityRequest{AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N1"}}}, mb100, false},
+		{&csi.GetCapacityRequest{AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N3"}}}, 0, false},
+		{&csi.GetCapacityRequest{Parameters: map[string]string{"direct-csi-min-io/access-tier": "Hot"}}, mb100, false},
+		{
+			&csi.GetCapacityRequest{
+				AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N1"}},
+				Parameters:         map[string]string{"direct-csi-min-io/access-tier": "Hot"},
+			},
+			mb20,
+			false,
+		},
+		{
+			&csi.GetCapacityRequest{
+				AccessibleTopology: &csi.Topology{Segments: map[string]string{"node": "N2"}},
+				Parameters:         map[string]string{"direct-csi-min-io/access-tier": "Hot"},
+			},
+			mb100,
+			false,
+		},
+		{
+			&csi.GetCapacityRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "ext4"}}},
+				},
+			},
+			0,
+			false,
+		},
+		{
+			&csi.GetCapacityRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}}},
+				},
+			},
+			mb100,
+			false,
+		},
+		{
+			&csi.GetCapacityRequest{
+				VolumeCapabilities: []*csi.VolumeCapability{
+					{AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}}},
+				},
+			},
+			2 * mb100,
+			false,
+		},
+		{&csi.GetCapacityRequest{Parameters: map[string]string{"direct-csi-min-io/access-tier": "invalid"}}, 0, true},
+	}
+
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(testObjects...)
+	for i, testCase := range testCases {
+		result, err := cl.GetCapacity(context.TODO(), testCase.request)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		if result.AvailableCapacity != testCase.expectedCapacity {
+			t.Fatalf("case %v: expected capacity %v, got %v", i+1, testCase.expectedCapacity, result.AvailableCapacity)
+		}
+	}
+}
+
+func TestControllerExpandVolumeRollback(t *testing.T) {
+	ctx := context.TODO()
+	clientset := clientsetfake.NewSimpleClientset(newExpandVolumeTestObjects()...)
+	clientset.PrependReactor("update", "directcsivolumes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("injected update failure")
+	})
+
+	cl := createFakeController()
+	cl.directcsiClient = clientset
+	_, err := cl.ControllerExpandVolume(ctx, &csi.ControllerExpandVolumeRequest{
+		VolumeId:      "test-volume",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * mb20},
+	})
+	if err == nil {
+		t.Fatal("expected error, but succeeded")
+	}
+
+	drive, err := clientset.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.AllocatedCapacity != mb20 || drive.Status.FreeCapacity != mb100-mb20 {
+		t.Fatalf("reserved capacity not released; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+
+	volume, err := clientset.DirectV1beta3().DirectCSIVolumes().Get(ctx, "test-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	if volume.Status.TotalCapacity != mb20 {
+		t.Fatalf("expected total capacity %v, got %v", mb20, volume.Status.TotalCapacity)
+	}
+}
+
+func TestCreateBlockVolume(t *testing.T) {
+	newRequest := func(name string, requiredBytes int64) *csi.CreateVolumeRequest {
+		req := &csi.CreateVolumeRequest{
+			Name: name,
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+		}
+		if requiredBytes != 0 {
+			req.CapacityRange = &csi.CapacityRange{RequiredBytes: requiredBytes}
+		}
+		return req
+	}
+
+	const usable = mb100 - 2*1024*1024
+	newDrive := func(name string, blockMode bool) *directcsi.DirectCSIDrive {
+		drive := &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          "N1",
+				DriveStatus:       directcsi.DriveStatusReady,
+				BlockMode:         blockMode,
+				TotalCapacity:     mb100,
+				FreeCapacity:      usable,
+				AllocatedCapacity: mb100 - usable,
+			},
+		}
+		if !blockMode {
+			drive.Status.Filesystem = string(sys.FSTypeXFS)
+		}
+		return drive
+	}
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(newDrive("xfs-drive", false), newDrive("block-drive", true))
+	volumeClient := cl.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+
+	// Partitioned block volume is carved only from block mode drive.
+	res, err := cl.CreateVolume(ctx, newRequest("partition-volume", mb20))
+	if err != nil {
+		t.Fatalf("unable to create block volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != mb20 {
+		t.Fatalf("expected capacity %v, got %v", mb20, res.Volume.CapacityBytes)
+	}
+	volume, err := volumeClient.Get(ctx, "partition-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	if !volume.Status.BlockMode || volume.Status.Drive != "block-drive" {
+		t.Fatalf("expected block volume on block-drive, got block mode: %v, drive: %v", volume.Status.BlockMode, volume.Status.Drive)
+	}
+
+	// Block volume size is aligned to 1MiB and reserved as a contiguous range.
+	if res, err = cl.CreateVolume(ctx, newRequest("aligned-volume", mb20+1)); err != nil {
+		t.Fatalf("unable to create block volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != mb20+1024*1024 {
+		t.Fatalf("expected capacity %v, got %v", mb20+1024*1024, res.Volume.CapacityBytes)
+	}
+	blockDrive, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "block-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	expectedExtents := []directcsi.BlockExtent{
+		{Volume: "partition-volume", Offset: 1024 * 1024, Size: mb20},
+		{Volume: "aligned-volume", Offset: 1024*1024 + mb20, Size: mb20 + 1024*1024},
+	}
+	if !reflect.DeepEqual(blockDrive.Status.BlockExtents, expectedExtents) {
+		t.Fatalf("expected extents %v, got %v", expectedExtents, blockDrive.Status.BlockExtents)
+	}
+	if blockDrive.Status.FreeCapacity != usable-2*mb20-1024*1024 {
+		t.Fatalf("unexpected free capacity %v", blockDrive.Status.FreeCapacity)
+	}
+
+	// Volume without size is rejected as the whole drive is in use.
+	if _, err = cl.CreateVolume(ctx, newRequest("whole-volume", 0)); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected error code %v, got %v", codes.FailedPrecondition, err)
+	}
+
+	// Whole drive is handed over to a volume without size on an unused drive.
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(newDrive("block-drive", true))
+	driveClient := cl.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	volumeClient = cl.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	res, err = cl.CreateVolume(ctx, newRequest("whole-volume", 0))
+	if err != nil {
+		t.Fatalf("unable to create block volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != mb100 {
+		t.Fatalf("expected capacity %v, got %v", mb100, res.Volume.CapacityBytes)
+	}
+	drive, err := driveClient.Get(ctx, "block-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.FreeCapacity != 0 || drive.Status.AllocatedCapacity != mb100 {
+		t.Fatalf("expected whole drive to be reserved; allocated: %v, free: %v", drive.Status.AllocatedCapacity, drive.Status.FreeCapacity)
+	}
+	volume, err = volumeClient.Get(ctx, "whole-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	if !utils.IsWholeDriveVolume(volume, drive) {
+		t.Fatalf("expected whole drive volume")
+	}
+
+	// Snapshot and expansion of block volume are rejected.
+	if _, err = cl.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{Name: "snapshot", SourceVolumeId: "whole-volume"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected error code %v, got %v", codes.InvalidArgument, err)
+	}
+	_, err = cl.ControllerExpandVolume(ctx, &csi.ControllerExpandVolumeRequest{
+		VolumeId:      "whole-volume",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * mb100},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected error code %v, got %v", codes.InvalidArgument, err)
+	}
+
+	// Mount volume is not carved from block mode drive.
+	_, err = cl.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name:          "mount-volume",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+				AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+			},
+		},
+	})
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected error code %v, got %v", codes.OutOfRange, err)
+	}
+}
+
+func TestCreateBlockVolumeFragmented(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "block-drive",
+			Finalizers: []string{
+				string(directcsi.DirectCSIDriveFinalizerDataProtection),
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-2",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:          "N1",
+			DriveStatus:       directcsi.DriveStatusInUse,
+			BlockMode:         true,
+			TotalCapacity:     mb100,
+			FreeCapacity:      mb100 - 2*1024*1024 - 2*mb20,
+			AllocatedCapacity: 2*1024*1024 + 2*mb20,
+			// Free ranges are 1MiB-31MiB, 51MiB-78MiB and 98MiB to the end.
+			BlockExtents: []directcsi.BlockExtent{
+				{Volume: "volume-1", Offset: 31 * 1024 * 1024, Size: mb20},
+				{Volume: "volume-2", Offset: 98*1024*1024 - mb20, Size: mb20},
+			},
+		},
+	}
+	newRequest := func(name string, requiredBytes int64) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: requiredBytes},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+		}
+	}
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(drive)
+
+	result, err := cl.GetCapacity(ctx, &csi.GetCapacityRequest{
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result.AvailableCapacity != 30*1024*1024 {
+		t.Fatalf("expected capacity %v, got %v", 30*1024*1024, result.AvailableCapacity)
+	}
+
+	// Free capacity is enough, but no contiguous range fits the volume.
+	if _, err := cl.CreateVolume(ctx, newRequest("volume-3", 40*1024*1024)); status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected error code %v, got %v", codes.OutOfRange, err)
+	}
+
+	if _, err := cl.CreateVolume(ctx, newRequest("volume-3", 30*1024*1024)); err != nil {
+		t.Fatalf("unable to create block volume; %v", err)
+	}
+	result2, err := cl.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "block-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if extent := result2.Status.BlockExtents[2]; extent.Volume != "volume-3" || extent.Offset != 1024*1024 || extent.Size != 30*1024*1024 {
+		t.Fatalf("unexpected extent %+v", extent)
+	}
+}
+
+func TestCreateVolumeReservationConflict(t *testing.T) {
+	newRequest := func(name string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+		}
+	}
+	driveResource := directcsi.SchemeGroupVersion.WithResource("directcsidrives")
+	conflictErr := errors.NewConflict(driveResource.GroupResource(), "test-drive", fmt.Errorf("object has been modified"))
+
+	testCases := []struct {
+		// concurrentFreeCapacity is the free capacity left by a concurrent reservation.
+		concurrentFreeCapacity int64
+		expectedFreeCapacity   int64
+		expectErr              bool
+	}{
+		{mb100 - mb20, mb100 - 2*mb20, false},
+		{mb20 / 2, mb20 / 2, true},
+	}
+
+	for i, testCase := range testCases {
+		ctx := context.TODO()
+		clientset := clientsetfake.NewSimpleClientset(newExpandVolumeTestObjects()...)
+		conflicted := false
+		clientset.PrependReactor("update", "directcsidrives", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if conflicted {
+				return false, nil, nil
+			}
+			conflicted = true
+
+			// Simulate a concurrent reservation updating the drive first.
+			drive := action.(clienttesting.UpdateAction).GetObject().(*directcsi.DirectCSIDrive).DeepCopy()
+			drive.Finalizers = []string{string(directcsi.DirectCSIDriveFinalizerDataProtection), directcsi.DirectCSIDriveFinalizerPrefix + "test-volume"}
+			drive.Status.FreeCapacity = testCase.concurrentFreeCapacity
+			drive.Status.AllocatedCapacity = mb100 - testCase.concurrentFreeCapacity
+			if err := clientset.Tracker().Update(driveResource, drive, ""); err != nil {
+				t.Fatalf("case %v: unable to update drive; %v", i+1, err)
+			}
+			return true, nil, conflictErr
+		})
+
+		cl := createFakeController()
+		cl.directcsiClient = clientset
+		_, err := cl.CreateVolume(ctx, newRequest("new-volume"))
+		if testCase.expectErr {
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Fatalf("case %v: expected error code %v, got %v", i+1, codes.ResourceExhausted, err)
+			}
+		} else if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+
+		drive, err := clientset.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: drive fetch error %v", i+1, err)
+		}
+		if drive.Status.FreeCapacity != testCase.expectedFreeCapacity {
+			t.Fatalf("case %v: expected free capacity %v, got %v", i+1, testCase.expectedFreeCapacity, drive.Status.FreeCapacity)
+		}
+
+		_, err = clientset.DirectV1beta3().DirectCSIVolumes().Get(ctx, "new-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if testCase.expectErr {
+			if !errors.IsNotFound(err) {
+				t.Fatalf("case %v: expected volume to be rolled back; %v", i+1, err)
+			}
+		} else if err != nil {
+			t.Fatalf("case %v: volume fetch error %v", i+1, err)
+		}
+	}
+}
+
+func TestCreateVolumeExistingVolume(t *testing.T) {
+	ctx := context.TODO()
+	req := &csi.CreateVolumeRequest{
+		Name:          "new-volume",
+		CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			{
+				AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+				AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+			},
+		},
+	}
+	newVolume := func(drive string) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "new-volume",
+				Finalizers: []string{
+					string(directcsi.DirectCSIVolumeFinalizerPVProtection),
+					string(directcsi.DirectCSIVolumeFinalizerPurgeProtection),
+				},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:          "N1",
+				Drive:             drive,
+				TotalCapacity:     mb20,
+				AvailableCapacity: mb20,
+			},
+		}
+	}
+	otherDrive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "other-drive",
+			Finalizers: []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      "N1",
+			Filesystem:    string(sys.FSTypeXFS),
+			DriveStatus:   directcsi.DriveStatusReady,
+			TotalCapacity: mb100,
+			FreeCapacity:  mb100,
+		},
+	}
+
+	// A failed reservation must not remove a volume created by an earlier request.
+	clientset := clientsetfake.NewSimpleClientset(append(newExpandVolumeTestObjects(), newVolume(""))...)
+	clientset.PrependReactor("update", "directcsidrives", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("unable to update drive")
+	})
+	cl := createFakeController()
+	cl.directcsiClient = clientset
+	if _, err := cl.CreateVolume(ctx, req); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := clientset.DirectV1beta3().DirectCSIVolumes().Get(ctx, "new-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+		t.Fatalf("expected volume to be retained; %v", err)
+	}
+
+	// The drive reserved by an earlier request is kept even if another drive is selected.
+	testObjects := newExpandVolumeTestObjects()
+	reservedDrive := testObjects[0].(*directcsi.DirectCSIDrive)
+	reservedDrive.Finalizers = append(reservedDrive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+"new-volume")
+	clientset = clientsetfake.NewSimpleClientset(append(testObjects, newVolume("test-drive"), otherDrive)...)
+	clientset.PrependReactor("list", "directcsidrives", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		// Simulate a drive list taken before the earlier reservation.
+		return true, &directcsi.DirectCSIDriveList{Items: []directcsi.DirectCSIDrive{*otherDrive}}, nil
+	})
+	cl.directcsiClient = clientset
+	if _, err := cl.CreateVolume(ctx, req); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	volume, err := clientset.DirectV1beta3().DirectCSIVolumes().Get(ctx, "new-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	if volume.Status.Drive != "test-drive" {
+		t.Fatalf("expected drive test-drive, got %v", volume.Status.Drive)
+	}
+	drive, err := clientset.DirectV1beta3().DirectCSIDrives().Get(ctx, "other-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if drive.Status.FreeCapacity != mb100 {
+		t.Fatalf("expected free capacity %v, got %v", mb100, drive.Status.FreeCapacity)
+	}
+}
+
+func TestCreateExt4Volume(t *testing.T) {
+	newRequest := func(name, fsType string, source *csi.VolumeContentSource) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: fsType}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			VolumeContentSource: source,
+		}
+	}
+
+	objects := newExpandVolumeTestObjects()
+	objects[0].(*directcsi.DirectCSIDrive).Status.Filesystem = string(sys.FSTypeExt4)
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(objects...)
+
+	res, err := cl.CreateVolume(ctx, newRequest("ext4-volume", "ext4", nil))
+	if err != nil {
+		t.Fatalf("unable to create ext4 volume; %v", err)
+	}
+	if res.Volume.CapacityBytes != mb20 {
+		t.Fatalf("expected capacity %v, got %v", mb20, res.Volume.CapacityBytes)
+	}
+	volume, err := cl.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, "ext4-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	if volume.Status.Drive != "test-drive" {
+		t.Fatalf("expected volume on test-drive, got %v", volume.Status.Drive)
+	}
+
+	testCases := []struct {
+		req          *csi.CreateVolumeRequest
+		expectedCode codes.Code
+	}{
+		{newRequest("xfs-volume", "xfs", nil), codes.OutOfRange},
+		{newRequest("btrfs-volume", "btrfs", nil), codes.InvalidArgument},
+		{
+			newRequest("cloned-volume", "ext4", &csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Volume{Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: "test-volume"}},
+			}),
+			codes.InvalidArgument,
+		},
+	}
+	for i, testCase := range testCases {
+		if _, err := cl.CreateVolume(ctx, testCase.req); status.Code(err) != testCase.expectedCode {
+			t.Fatalf("case %v: expected code: %v, got: %v", i+1, testCase.expectedCode, err)
+		}
+	}
+
+	_, err = cl.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{Name: "test-snapshot", SourceVolumeId: "test-volume"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected code: %v, got: %v", codes.InvalidArgument, err)
 	}
 }
@@ -0,0 +1,223 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientset "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// driveSelectionStrategyKey is the StorageClass parameter to choose drive selection strategy.
+	driveSelectionStrategyKey = "direct-csi-min-io/drive-selection-strategy"
+
+	// spreadGroupKey is the StorageClass parameter to set the group of volumes spread by label-spread strategy.
+	spreadGroupKey = "direct-csi-min-io/spread-group"
+
+	// MaxFreeCapacityStrategy selects the drive with most free capacity.
+	MaxFreeCapacityStrategy = "max-free-capacity"
+
+	// BinPackingStrategy selects the drive with least free capacity satisfying the request.
+	BinPackingStrategy = "bin-packing"
+
+	// LeastVolumesStrategy selects the drive with least number of volumes.
+	LeastVolumesStrategy = "least-volumes"
+
+	// RoundRobinStrategy selects drives of nodes in round-robin order.
+	RoundRobinStrategy = "round-robin"
+
+	// LabelSpreadStrategy selects the drive with least number of volumes of the same spread group.
+	LabelSpreadStrategy = "label-spread"
+)
+
+// DriveSelectionStrategy selects a drive for a volume from the drives matching the request.
+type DriveSelectionStrategy interface {
+	Select(ctx context.Context, client clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error)
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]DriveSelectionStrategy{
+		MaxFreeCapacityStrategy: &maxFreeCapacityStrategy{},
+		BinPackingStrategy:      &binPackingStrategy{},
+		LeastVolumesStrategy:    &leastVolumesStrategy{},
+		RoundRobinStrategy:      &roundRobinStrategy{},
+		LabelSpreadStrategy:     &labelSpreadStrategy{},
+	}
+)
+
+// RegisterDriveSelectionStrategy registers drive selection strategy by name.
+func RegisterDriveSelectionStrategy(name string, strategy DriveSelectionStrategy) error {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	if _, found := strategies[name]; found {
+		return fmt.Errorf("drive selection strategy %v already registered", name)
+	}
+	strategies[name] = strategy
+	return nil
+}
+
+// getDriveSelectionStrategy returns the drive selection strategy requested in parameters.
+func getDriveSelectionStrategy(parameters map[string]string) (DriveSelectionStrategy, error) {
+	name, found := parameters[driveSelectionStrategyKey]
+	if !found || name == "" {
+		name = MaxFreeCapacityStrategy
+	}
+
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	strategy, found := strategies[name]
+	if !found {
+		return nil, fmt.Errorf("unknown drive selection strategy %v", name)
+	}
+	return strategy, nil
+}
+
+// filterDrives returns the drives having lowest score.
+func filterDrives(drives []directcsi.DirectCSIDrive, score func(drive directcsi.DirectCSIDrive) int64) (result []directcsi.DirectCSIDrive) {
+	var minScore int64
+	for _, drive := range drives {
+		value := score(drive)
+		switch {
+		case len(result) == 0 || value < minScore:
+			minScore = value
+			result = []directcsi.DirectCSIDrive{drive}
+		case value == minScore:
+			result = append(result, drive)
+		}
+	}
+	return result
+}
+
+// randomDrive returns a random drive to break ties.
+func randomDrive(drives []directcsi.DirectCSIDrive) (*directcsi.DirectCSIDrive, error) {
+	if len(drives) == 1 {
+		return &drives[0], nil
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(drives))))
+	if err != nil {
+		return nil, fmt.Errorf("random number generation failed; %v", err)
+	}
+
+	return &drives[n.Int64()], nil
+}
+
+func negativeFreeCapacity(drive directcsi.DirectCSIDrive) int64 {
+	return -drive.Status.FreeCapacity
+}
+
+func freeCapacity(drive directcsi.DirectCSIDrive) int64 {
+	return drive.Status.FreeCapacity
+}
+
+func volumeCount(drive directcsi.DirectCSIDrive) int64 {
+	return int64(len(utils.GetDriveVolumes(&drive)))
+}
+
+type maxFreeCapacityStrategy struct{}
+
+func (s *maxFreeCapacityStrategy) Select(_ context.Context, _ clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, _ *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
+	return randomDrive(filterDrives(drives, negativeFreeCapacity))
+}
+
+type binPackingStrategy struct{}
+
+func (s *binPackingStrategy) Select(_ context.Context, _ clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, _ *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
+	return randomDrive(filterDrives(drives, freeCapacity))
+}
+
+type leastVolumesStrategy struct{}
+
+func (s *leastVolumesStrategy) Select(_ context.Context, _ clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, _ *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
+	return randomDrive(filterDrives(filterDrives(drives, volumeCount), negativeFreeCapacity))
+}
+
+type roundRobinStrategy struct {
+	mutex    sync.Mutex
+	lastNode string
+}
+
+func (s *roundRobinStrategy) Select(_ context.Context, _ clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, _ *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
+	nodeDrives := map[string][]directcsi.DirectCSIDrive{}
+	var nodes []string
+	for _, drive := range drives {
+		if _, found := nodeDrives[drive.Status.NodeName]; !found {
+			nodes = append(nodes, drive.Status.NodeName)
+		}
+		nodeDrives[drive.Status.NodeName] = append(nodeDrives[drive.Status.NodeName], drive)
+	}
+	sort.Strings(nodes)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Pick the node next to the last selected node.
+	node := nodes[0]
+	for _, name := range nodes {
+		if name > s.lastNode {
+			node = name
+			break
+		}
+	}
+	s.lastNode = node
+
+	return randomDrive(filterDrives(nodeDrives[node], negativeFreeCapacity))
+}
+
+type labelSpreadStrategy struct{}
+
+func (s *labelSpreadStrategy) Select(ctx context.Context, client clientset.DirectV1beta3Interface, drives []directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) (*directcsi.DirectCSIDrive, error) {
+	group := req.GetParameters()[spreadGroupKey]
+	if group == "" {
+		return nil, fmt.Errorf("%v parameter must be set for %v strategy", spreadGroupKey, LabelSpreadStrategy)
+	}
+
+	volumeList, err := client.DirectCSIVolumes().List(ctx, metav1.ListOptions{
+		TypeMeta:      utils.DirectCSIVolumeTypeMeta(),
+		LabelSelector: fmt.Sprintf("%s=%s", utils.SpreadGroupLabel, utils.SanitizeLabelV(group)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groupVolumes := map[string]int64{}
+	for _, volume := range volumeList.Items {
+		if volume.Name != req.GetName() {
+			groupVolumes[volume.Status.Drive]++
+		}
+	}
+
+	groupVolumeCount := func(drive directcsi.DirectCSIDrive) int64 {
+		return groupVolumes[drive.Name]
+	}
+
+	return randomDrive(filterDrives(filterDrives(drives, groupVolumeCount), negativeFreeCapacity))
+}
@@ -0,0 +1,182 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func newStrategyTestDrive(name, node string, freeCapacity int64, volumes ...string) *directcsi.DirectCSIDrive {
+	finalizers := []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)}
+	for _, volume := range volumes {
+		finalizers = append(finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume)
+	}
+	return &directcsi.DirectCSIDrive{
+		TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: name, Finalizers: finalizers},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      node,
+			DriveStatus:   directcsi.DriveStatusInUse,
+			TotalCapacity: 10 * GiB,
+			FreeCapacity:  freeCapacity,
+		},
+	}
+}
+
+func newStrategyTestRequest(name string, parameters map[string]string) *csi.CreateVolumeRequest {
+	return &csi.CreateVolumeRequest{
+		Name:          name,
+		CapacityRange: &csi.CapacityRange{RequiredBytes: GiB},
+		Parameters:    parameters,
+	}
+}
+
+func TestDriveSelectionStrategies(t *testing.T) {
+	objects := []runtime.Object{
+		newStrategyTestDrive("drive-1", "node-1", 8*GiB, "volume-1", "volume-2", "volume-3"),
+		newStrategyTestDrive("drive-2", "node-1", 2*GiB),
+		newStrategyTestDrive("drive-3", "node-2", 5*GiB, "volume-4"),
+	}
+
+	testCases := []struct {
+		strategy      string
+		expectedDrive string
+	}{
+		{"", "drive-1"},
+		{MaxFreeCapacityStrategy, "drive-1"},
+		{BinPackingStrategy, "drive-2"},
+		{LeastVolumesStrategy, "drive-2"},
+	}
+
+	for i, testCase := range testCases {
+		client := clientsetfake.NewSimpleClientset(objects...).DirectV1beta3()
+		req := newStrategyTestRequest("volume", map[string]string{driveSelectionStrategyKey: testCase.strategy})
+		drive, err := selectDrive(context.TODO(), client, req, "")
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if drive.Name != testCase.expectedDrive {
+			t.Fatalf("case %v: expected drive %v, got %v", i+1, testCase.expectedDrive, drive.Name)
+		}
+	}
+
+	client := clientsetfake.NewSimpleClientset(objects...).DirectV1beta3()
+	req := newStrategyTestRequest("volume", map[string]string{driveSelectionStrategyKey: "unknown"})
+	if _, err := selectDrive(context.TODO(), client, req, ""); err == nil {
+		t.Fatalf("expected error for unknown strategy, but succeeded")
+	}
+}
+
+func TestRoundRobinStrategy(t *testing.T) {
+	drives := []directcsi.DirectCSIDrive{
+		*newStrategyTestDrive("drive-1", "node-2", 4*GiB),
+		*newStrategyTestDrive("drive-2", "node-1", 4*GiB),
+		*newStrategyTestDrive("drive-3", "node-1", 8*GiB),
+		*newStrategyTestDrive("drive-4", "node-3", 4*GiB),
+	}
+
+	strategy := &roundRobinStrategy{}
+	client := clientsetfake.NewSimpleClientset().DirectV1beta3()
+	for i, expectedDrive := range []string{"drive-3", "drive-1", "drive-4", "drive-3"} {
+		drive, err := strategy.Select(context.TODO(), client, drives, newStrategyTestRequest("volume", nil))
+		if err != nil {
+			t.Fatalf("run %v: unexpected error %v", i+1, err)
+		}
+		if drive.Name != expectedDrive {
+			t.Fatalf("run %v: expected drive %v, got %v", i+1, expectedDrive, drive.Name)
+		}
+	}
+}
+
+func TestLabelSpreadStrategy(t *testing.T) {
+	newVolume := func(name, drive, group string) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.SpreadGroupLabel: group},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{Drive: drive},
+		}
+	}
+
+	drives := []directcsi.DirectCSIDrive{
+		*newStrategyTestDrive("drive-1", "node-1", 8*GiB, "volume-1", "volume-2"),
+		*newStrategyTestDrive("drive-2", "node-1", 4*GiB, "volume-3"),
+		*newStrategyTestDrive("drive-3", "node-1", 2*GiB),
+	}
+	client := clientsetfake.NewSimpleClientset(
+		newVolume("volume-1", "drive-1", "tenant-2"),
+		newVolume("volume-2", "drive-1", "tenant-2"),
+		newVolume("volume-3", "drive-2", "tenant-1"),
+	).DirectV1beta3()
+
+	testCases := []struct {
+		group         string
+		expectedDrive string
+		expectErr     bool
+	}{
+		{"tenant-1", "drive-1", false},
+		{"tenant-2", "drive-2", false},
+		{"tenant-3", "drive-1", false},
+		{"", "", true},
+	}
+
+	strategy := &labelSpreadStrategy{}
+	for i, testCase := range testCases {
+		req := newStrategyTestRequest("volume", map[string]string{spreadGroupKey: testCase.group})
+		drive, err := strategy.Select(context.TODO(), client, drives, req)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if drive.Name != testCase.expectedDrive {
+			t.Fatalf("case %v: expected drive %v, got %v", i+1, testCase.expectedDrive, drive.Name)
+		}
+	}
+}
+
+func TestRegisterDriveSelectionStrategy(t *testing.T) {
+	if err := RegisterDriveSelectionStrategy(MaxFreeCapacityStrategy, &binPackingStrategy{}); err == nil {
+		t.Fatalf("expected error for duplicate strategy, but succeeded")
+	}
+
+	if err := RegisterDriveSelectionStrategy("test-strategy", &binPackingStrategy{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	strategy, err := getDriveSelectionStrategy(map[string]string{driveSelectionStrategyKey: "test-strategy"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := strategy.(*binPackingStrategy); !ok {
+		t.Fatalf("expected registered strategy, got %T", strategy)
+	}
+}
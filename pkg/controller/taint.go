@@ -0,0 +1,77 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// driveTolerationsKey is the StorageClass parameter holding comma separated drive tolerations
+// in "key[=value][:effect]" format.
+const driveTolerationsKey = "direct-csi-min-io/drive-tolerations"
+
+// isTaintsTolerated returns whether all taints of given effect on the drive are tolerated.
+func isTaintsTolerated(drive directcsi.DirectCSIDrive, tolerations []corev1.Toleration, effect corev1.TaintEffect) bool {
+	for _, taint := range drive.GetTaints() {
+		if taint.Effect != effect {
+			continue
+		}
+
+		tolerated := false
+		for i := range tolerations {
+			if tolerated = tolerations[i].ToleratesTaint(&taint); tolerated {
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// isNoScheduleTolerated returns whether NoSchedule taints of the drive are tolerated by the
+// tolerations in parameters.
+func isNoScheduleTolerated(drive directcsi.DirectCSIDrive, parameters map[string]string) bool {
+	tolerations, err := directcsi.ParseDriveTolerations(parameters[driveTolerationsKey])
+	if err != nil {
+		return false
+	}
+	return isTaintsTolerated(drive, tolerations, corev1.TaintEffectNoSchedule)
+}
+
+// filterPreferNoScheduleDrives drops drives having PreferNoSchedule taints not tolerated by
+// the tolerations in parameters, unless no other drive is left.
+func filterPreferNoScheduleDrives(drives []directcsi.DirectCSIDrive, parameters map[string]string) []directcsi.DirectCSIDrive {
+	tolerations, err := directcsi.ParseDriveTolerations(parameters[driveTolerationsKey])
+	if err != nil {
+		return drives
+	}
+
+	var preferred []directcsi.DirectCSIDrive
+	for _, drive := range drives {
+		if isTaintsTolerated(drive, tolerations, corev1.TaintEffectPreferNoSchedule) {
+			preferred = append(preferred, drive)
+		}
+	}
+	if len(preferred) == 0 {
+		return drives
+	}
+	return preferred
+}
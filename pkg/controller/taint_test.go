@@ -0,0 +1,159 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseDriveTolerations(t *testing.T) {
+	testCases := []struct {
+		value               string
+		expectedTolerations []corev1.Toleration
+		expectErr           bool
+	}{
+		{"", nil, false},
+		{"maintenance", []corev1.Toleration{{Key: "maintenance", Operator: corev1.TolerationOpExists}}, false},
+		{
+			"maintenance=true:NoSchedule, degraded:PreferNoSchedule",
+			[]corev1.Toleration{
+				{Key: "maintenance", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "degraded", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+			false,
+		},
+		{"maintenance:NoExecute", nil, true},
+		{"maintenance:NoSchedule:extra", nil, true},
+		{"=true", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		tolerations, err := directcsi.ParseDriveTolerations(testCase.value)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if len(tolerations) != len(testCase.expectedTolerations) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedTolerations, tolerations)
+		}
+		for j := range tolerations {
+			if tolerations[j] != testCase.expectedTolerations[j] {
+				t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedTolerations, tolerations)
+			}
+		}
+	}
+}
+
+func TestDriveTaints(t *testing.T) {
+	drive := newStrategyTestDrive("drive", "node", GiB)
+	taint, err := directcsi.ParseDriveTaint("maintenance=true:NoSchedule")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	drive.SetTaint(taint)
+	drive.Spec.DriveTaint["legacy"] = "yes"
+
+	taints := drive.GetTaints()
+	expectedTaints := []corev1.Taint{
+		{Key: "legacy", Value: "yes", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "maintenance", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+	}
+	if len(taints) != len(expectedTaints) || taints[0] != expectedTaints[0] || taints[1] != expectedTaints[1] {
+		t.Fatalf("expected: %+v, got: %+v", expectedTaints, taints)
+	}
+
+	if drive.RemoveTaint("maintenance", corev1.TaintEffectPreferNoSchedule) {
+		t.Fatalf("taint must not be removed for different effect")
+	}
+	if !drive.RemoveTaint("maintenance", "") || len(drive.GetTaints()) != 1 {
+		t.Fatalf("taint must be removed; %+v", drive.GetTaints())
+	}
+
+	for _, value := range []string{"maintenance", "maintenance=true", "maintenance:NoExecute", ":NoSchedule"} {
+		if _, err := directcsi.ParseDriveTaint(value); err == nil {
+			t.Fatalf("expected error for taint %v, but succeeded", value)
+		}
+	}
+}
+
+func TestSelectDriveTaints(t *testing.T) {
+	newTaintedDrive := func(name string, freeCapacity int64, taint string) *directcsi.DirectCSIDrive {
+		drive := newStrategyTestDrive(name, "node-1", freeCapacity)
+		if taint != "" {
+			parsed, err := directcsi.ParseDriveTaint(taint)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			drive.SetTaint(parsed)
+		}
+		return drive
+	}
+
+	drives := []*directcsi.DirectCSIDrive{
+		newTaintedDrive("drive-1", 8*GiB, "maintenance=true:NoSchedule"),
+		newTaintedDrive("drive-2", 6*GiB, "degraded:PreferNoSchedule"),
+		newTaintedDrive("drive-3", 2*GiB, ""),
+	}
+
+	testCases := []struct {
+		tolerations   string
+		drives        []*directcsi.DirectCSIDrive
+		expectedDrive string
+	}{
+		{"", drives, "drive-3"},
+		{"degraded", drives, "drive-2"},
+		{"maintenance=true:NoSchedule,degraded", drives, "drive-1"},
+		{"maintenance=false", drives, "drive-3"},
+		{"", drives[:2], "drive-2"},
+	}
+
+	for i, testCase := range testCases {
+		var objects []runtime.Object
+		for _, drive := range testCase.drives {
+			objects = append(objects, drive)
+		}
+		client := clientsetfake.NewSimpleClientset(objects...)
+		req := newStrategyTestRequest("volume", map[string]string{driveTolerationsKey: testCase.tolerations})
+		drive, err := selectDrive(context.TODO(), client.DirectV1beta3(), req, "")
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if drive.Name != testCase.expectedDrive {
+			t.Fatalf("case %v: expected drive %v, got %v", i+1, testCase.expectedDrive, drive.Name)
+		}
+	}
+
+	client := clientsetfake.NewSimpleClientset(drives[0])
+	req := newStrategyTestRequest("volume", nil)
+	if _, err := selectDrive(context.TODO(), client.DirectV1beta3(), req, ""); status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected code: %v, got: %v", codes.OutOfRange, err)
+	}
+}
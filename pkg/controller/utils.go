@@ -18,50 +18,211 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
+	"sort"
+	"strings"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/blockdev/gpt"
 	clientset "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/matcher"
+	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bool {
+func isDriveStatusMatched(drive directcsi.DirectCSIDrive) bool {
 	// Match drive only in Ready or InUse state.
 	switch drive.Status.DriveStatus {
 	case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+		return true
 	default:
 		return false
 	}
+}
 
-	// Match drive if it has requested capacity.
-	if req.GetCapacityRange() != nil && drive.Status.FreeCapacity < req.GetCapacityRange().GetRequiredBytes() {
+func isDriveHealthy(drive directcsi.DirectCSIDrive) bool {
+	// Match drive unless it is cordoned by health policy or its filesystem is shut down.
+	if utils.IsConditionStatus(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy), metav1.ConditionFalse) {
 		return false
 	}
+	for _, condition := range drive.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSIDriveConditionDegraded) &&
+			condition.Status == metav1.ConditionTrue &&
+			condition.Reason == string(directcsi.DirectCSIDriveReasonFilesystemShutdown) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDriveSchedulable(drive directcsi.DirectCSIDrive) bool {
+	// Match drive unless it is cordoned by the user.
+	return !drive.Spec.Unschedulable
+}
+
+func isFilesystemMatched(drive directcsi.DirectCSIDrive, volumeCapabilities []*csi.VolumeCapability) bool {
+	// Match drive if requested filesystem matches; empty filesystem type means any.
+	if len(volumeCapabilities) == 0 {
+		return true
+	}
+	fsType := volumeCapabilities[0].GetMount().GetFsType()
+	return fsType == "" || drive.Status.Filesystem == fsType
+}
+
+func isBlockAccessType(volumeCapabilities []*csi.VolumeCapability) bool {
+	for _, vcap := range volumeCapabilities {
+		if vcap.GetBlock() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlockModeMatched(drive directcsi.DirectCSIDrive, volumeCapabilities []*csi.VolumeCapability) bool {
+	// Block volumes are carved only from drives formatted in block mode and vice versa.
+	return drive.Status.BlockMode == isBlockAccessType(volumeCapabilities)
+}
+
+// getFreeBlockExtents returns free ranges of the block mode drive left between reserved extents.
+func getFreeBlockExtents(drive directcsi.DirectCSIDrive) (free []directcsi.BlockExtent) {
+	start, end := gpt.PartitionRange(uint64(drive.Status.TotalCapacity), utils.GetSectorSize(&drive))
+	extents := append([]directcsi.BlockExtent{}, drive.Status.BlockExtents...)
+	sort.Slice(extents, func(i, j int) bool { return extents[i].Offset < extents[j].Offset })
+
+	offset := int64(start)
+	for _, extent := range extents {
+		if extent.Offset > offset {
+			free = append(free, directcsi.BlockExtent{Offset: offset, Size: extent.Offset - offset})
+		}
+		if extent.Offset+extent.Size > offset {
+			offset = extent.Offset + extent.Size
+		}
+	}
+	if int64(end) > offset {
+		free = append(free, directcsi.BlockExtent{Offset: offset, Size: int64(end) - offset})
+	}
+	return free
+}
+
+// findFreeBlockExtent returns offset of the first free range of the block mode drive fitting size bytes.
+func findFreeBlockExtent(drive directcsi.DirectCSIDrive, size int64) (int64, bool) {
+	for _, extent := range getFreeBlockExtents(drive) {
+		if extent.Size >= size {
+			return extent.Offset, true
+		}
+	}
+	return 0, false
+}
 
-	// Match drive if requested filesystem matches.
-	if len(req.GetVolumeCapabilities()) > 0 && drive.Status.Filesystem != req.GetVolumeCapabilities()[0].GetMount().GetFsType() {
+// getDriveFreeCapacity returns the largest size a volume can take from the drive. A volume
+// of a block mode drive is a partition, hence it is limited to the largest contiguous free range.
+func getDriveFreeCapacity(drive directcsi.DirectCSIDrive) int64 {
+	if !drive.Status.BlockMode {
+		return drive.Status.FreeCapacity
+	}
+	var largest int64
+	for _, extent := range getFreeBlockExtents(drive) {
+		if extent.Size > largest {
+			largest = extent.Size
+		}
+	}
+	if drive.Status.FreeCapacity < largest {
+		return drive.Status.FreeCapacity
+	}
+	return largest
+}
+
+func isWholeDriveRequest(drive *directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bool {
+	// Hand over the whole drive to a block volume if no size is requested and no other volume uses the drive.
+	if !isBlockAccessType(req.GetVolumeCapabilities()) || isSizeRequested(req) {
 		return false
 	}
+	for _, finalizer := range drive.GetFinalizers() {
+		if strings.HasPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix) && finalizer != directcsi.DirectCSIDriveFinalizerPrefix+req.GetName() {
+			return false
+		}
+	}
+	return true
+}
+
+func isVolumeLimitMatched(drive directcsi.DirectCSIDrive) bool {
+	// Match drive if it is below its optional volume limit.
+	return drive.Spec.MaxVolumes <= 0 || volumeCount(drive) < int64(drive.Spec.MaxVolumes)
+}
 
+func isAccessTierMatched(drive directcsi.DirectCSIDrive, parameters map[string]string) bool {
 	// Match drive by access-tier if requested.
-	for key, value := range req.GetParameters() {
+	for key, value := range parameters {
 		if key == "direct-csi-min-io/access-tier" && string(drive.Status.AccessTier) != value {
 			return false
 		}
 	}
+	return true
+}
+
+func isTopologyMatched(drive directcsi.DirectCSIDrive, topology *csi.Topology) bool {
+	for key, value := range topology.GetSegments() {
+		if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
+			return false
+		}
+	}
+	return true
+}
+
+func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bool {
+	if !isDriveStatusMatched(drive) {
+		return false
+	}
+
+	if !isDriveHealthy(drive) {
+		return false
+	}
+
+	if !isDriveSchedulable(drive) {
+		return false
+	}
+
+	// Match drive if it has requested capacity.
+	size, err := getVolumeSize(req, 0)
+	if err != nil || getDriveFreeCapacity(drive) < size {
+		return false
+	}
+
+	// Match only an unused drive for a block volume without size, as the whole drive is handed over.
+	if size == 0 && isBlockAccessType(req.GetVolumeCapabilities()) && !isWholeDriveRequest(&drive, req) {
+		return false
+	}
+
+	if !isFilesystemMatched(drive, req.GetVolumeCapabilities()) {
+		return false
+	}
+
+	if !isBlockModeMatched(drive, req.GetVolumeCapabilities()) {
+		return false
+	}
+
+	if !isAccessTierMatched(drive, req.GetParameters()) {
+		return false
+	}
+
+	if !isNoScheduleTolerated(drive, req.GetParameters()) {
+		return false
+	}
+
+	if !isVolumeLimitMatched(drive) {
+		return false
+	}
 
 	matchTopologies := func(topologies []*csi.Topology) bool {
 		for _, topology := range topologies {
-			for key, value := range topology.GetSegments() {
-				if driveValue, found := drive.Status.Topology[key]; !found || value != driveValue {
-					return false
-				}
+			if !isTopologyMatched(drive, topology) {
+				return false
 			}
 		}
 		return true
@@ -81,6 +242,18 @@ func matchDrive(drive directcsi.DirectCSIDrive, req *csi.CreateVolumeRequest) bo
 	return len(req.GetAccessibilityRequirements().GetPreferred()) == 0 && len(req.GetAccessibilityRequirements().GetRequisite()) == 0
 }
 
+func matchCapacityDrive(drive directcsi.DirectCSIDrive, req *csi.GetCapacityRequest) bool {
+	return isDriveStatusMatched(drive) &&
+		isDriveHealthy(drive) &&
+		isDriveSchedulable(drive) &&
+		isFilesystemMatched(drive, req.GetVolumeCapabilities()) &&
+		isBlockModeMatched(drive, req.GetVolumeCapabilities()) &&
+		isAccessTierMatched(drive, req.GetParameters()) &&
+		isNoScheduleTolerated(drive, req.GetParameters()) &&
+		isVolumeLimitMatched(drive) &&
+		isTopologyMatched(drive, req.GetAccessibleTopology())
+}
+
 func getFilteredDrives(
 	ctx context.Context,
 	driveInterface clientset.DirectCSIDriveInterface,
@@ -110,10 +283,16 @@ func getFilteredDrives(
 
 func selectDrive(
 	ctx context.Context,
-	driveInterface clientset.DirectCSIDriveInterface,
+	directCSIClient clientset.DirectV1beta3Interface,
 	req *csi.CreateVolumeRequest,
+	group string,
 ) (*directcsi.DirectCSIDrive, error) {
-	drives, err := getFilteredDrives(ctx, driveInterface, req)
+	strategy, err := getDriveSelectionStrategy(req.GetParameters())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	drives, err := getFilteredDrives(ctx, directCSIClient.DirectCSIDrives(), req)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -123,33 +302,133 @@ func selectDrive(
 			return nil, status.Error(codes.ResourceExhausted, "no drive found for requested topology")
 		}
 
-		if req.GetCapacityRange() != nil {
-			return nil, status.Errorf(codes.OutOfRange, "no drive found for requested size %v", req.GetCapacityRange().GetRequiredBytes())
+		if size, err := getVolumeSize(req, 0); err == nil && size != 0 {
+			return nil, status.Errorf(codes.OutOfRange, "no drive found for requested size %v", size)
 		}
 
 		return nil, status.Error(codes.FailedPrecondition, "no drive found")
 	}
 
-	maxFreeCapacity := int64(-1)
-	var maxFreeCapacityDrives []directcsi.DirectCSIDrive
-	for _, drive := range drives {
-		switch {
-		case drive.Status.FreeCapacity == maxFreeCapacity:
-			maxFreeCapacityDrives = append(maxFreeCapacityDrives, drive)
-		case drive.Status.FreeCapacity > maxFreeCapacity:
-			maxFreeCapacity = drive.Status.FreeCapacity
-			maxFreeCapacityDrives = []directcsi.DirectCSIDrive{drive}
+	// Drive is already reserved for this volume.
+	if len(drives) == 1 && matcher.StringIn(drives[0].Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+req.GetName()) {
+		return &drives[0], nil
+	}
+
+	if group != "" {
+		if drives, err = filterVolumeGroupDrives(ctx, directCSIClient, drives, req.GetName(), group); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(drives) == 0 {
+			return nil, status.Errorf(codes.ResourceExhausted, "not enough distinct drives for volume group %v on requested nodes", group)
+		}
+	}
+
+	drives = filterPreferNoScheduleDrives(drives, req.GetParameters())
+
+	if len(drives) == 1 {
+		return &drives[0], nil
+	}
+
+	drive, err := strategy.Select(ctx, directCSIClient, drives, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to select drive; %v", err)
+	}
+
+	return drive, nil
+}
+
+// contentSource denotes the volume or snapshot a new volume is populated from.
+type contentSource struct {
+	volume   string
+	snapshot string
+	size     int64
+}
+
+// selectContentSourceDrive returns the drive of requested volume content source;
+// a cloned volume is placed on the same drive as its source to make reflink copy possible.
+func selectContentSourceDrive(
+	ctx context.Context,
+	directCSIClient clientset.DirectV1beta3Interface,
+	req *csi.CreateVolumeRequest,
+) (*directcsi.DirectCSIDrive, *contentSource, error) {
+	var driveName string
+	source := &contentSource{}
+
+	switch {
+	case req.GetVolumeContentSource().GetVolume() != nil:
+		source.volume = req.GetVolumeContentSource().GetVolume().GetVolumeId()
+		volume, err := directCSIClient.DirectCSIVolumes().Get(ctx, source.volume, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil, status.Errorf(codes.NotFound, "source volume [%s] not found", source.volume)
+			}
+			return nil, nil, status.Errorf(codes.Internal, "could not retreive source volume [%s]: %v", source.volume, err)
+		}
+		if volume.Status.BlockMode {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "cloning block volume [%s] is not supported", source.volume)
 		}
+		driveName, source.size = volume.Status.Drive, volume.Status.TotalCapacity
+
+	case req.GetVolumeContentSource().GetSnapshot() != nil:
+		source.snapshot = req.GetVolumeContentSource().GetSnapshot().GetSnapshotId()
+		snapshot, err := directCSIClient.DirectCSISnapshots().Get(ctx, source.snapshot, metav1.GetOptions{TypeMeta: utils.DirectCSISnapshotTypeMeta()})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil, status.Errorf(codes.NotFound, "source snapshot [%s] not found", source.snapshot)
+			}
+			return nil, nil, status.Errorf(codes.Internal, "could not retreive source snapshot [%s]: %v", source.snapshot, err)
+		}
+		if !utils.IsConditionStatus(snapshot.Status.Conditions, string(directcsi.DirectCSISnapshotConditionReady), metav1.ConditionTrue) {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "source snapshot [%s] is not ready", source.snapshot)
+		}
+		driveName, source.size = snapshot.Status.Drive, snapshot.Status.TotalCapacity
+
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported volume content source %v", req.GetVolumeContentSource())
 	}
 
-	if len(maxFreeCapacityDrives) == 1 {
-		return &maxFreeCapacityDrives[0], nil
+	if req.GetCapacityRange().GetRequiredBytes() != 0 && req.GetCapacityRange().GetRequiredBytes() < source.size {
+		return nil, nil, status.Errorf(codes.OutOfRange, "required bytes %v is less than source size %v", req.GetCapacityRange().GetRequiredBytes(), source.size)
 	}
 
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(maxFreeCapacityDrives))))
+	drive, err := directCSIClient.DirectCSIDrives().Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "random number generation failed; %v", err)
+		if errors.IsNotFound(err) {
+			return nil, nil, status.Errorf(codes.NotFound, "drive [%s] of volume content source not found", driveName)
+		}
+		return nil, nil, status.Errorf(codes.Internal, "could not retreive drive [%s]: %v", driveName, err)
+	}
+
+	// Cloning is done by reflink copy, which only XFS supports.
+	if drive.Status.Filesystem != string(sys.FSTypeXFS) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "cloning from %v filesystem of drive [%s] is not supported", drive.Status.Filesystem, driveName)
+	}
+	if !drive.Status.FormatOptions.IsReflinkEnabled() {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "cloning from drive [%s] formatted without reflink is not supported", driveName)
 	}
 
-	return &maxFreeCapacityDrives[n.Int64()], nil
+	// Drive is already reserved for this volume.
+	if matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+req.GetName()) {
+		return drive, source, nil
+	}
+
+	if !matchDrive(*drive, req) || drive.Status.FreeCapacity < source.size {
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "drive [%s] of volume content source cannot satisfy the request", driveName)
+	}
+
+	return drive, source, nil
+}
+
+func newCSISnapshot(snapshot *directcsi.DirectCSISnapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SnapshotId:     snapshot.Name,
+		SourceVolumeId: snapshot.Status.SourceVolume,
+		SizeBytes:      snapshot.Status.TotalCapacity,
+		CreationTime:   timestamppb.New(snapshot.CreationTimestamp.Time),
+		ReadyToUse: utils.IsConditionStatus(
+			snapshot.Status.Conditions,
+			string(directcsi.DirectCSISnapshotConditionReady),
+			metav1.ConditionTrue,
+		),
+	}
 }
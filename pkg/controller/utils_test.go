@@ -205,6 +205,39 @@ func TestGetFilteredDrives(t *testing.T) {
 		},
 	}
 
+	case10Result := []directcsi.DirectCSIDrive{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-2"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Topology:    map[string]string{"node": "N2", "topology.kubernetes.io/zone": "zone-a"},
+			},
+		},
+	}
+	case10Objects := []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-1"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Topology:    map[string]string{"node": "N1", "topology.kubernetes.io/zone": "zone-b"},
+			},
+		},
+		&directcsi.DirectCSIDrive{
+			ObjectMeta: metav1.ObjectMeta{Name: "drive-3"},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus: directcsi.DriveStatusReady,
+				Topology:    map[string]string{"node": "N3"},
+			},
+		},
+		&case10Result[0],
+	}
+	case10Request := &csi.CreateVolumeRequest{
+		Name: "volume-1",
+		AccessibilityRequirements: &csi.TopologyRequirement{
+			Requisite: []*csi.Topology{{Segments: map[string]string{"topology.kubernetes.io/zone": "zone-a"}}},
+		},
+	}
+
 	testCases := []struct {
 		objects        []runtime.Object
 		request        *csi.CreateVolumeRequest
@@ -219,6 +252,7 @@ func TestGetFilteredDrives(t *testing.T) {
 		{case7Objects, case7Request, case7Result},
 		{case8Objects, case8Request, case8Result},
 		{case9Objects, case9Request, nil},
+		{case10Objects, case10Request, case10Result},
 	}
 
 	for i, testCase := range testCases {
@@ -260,8 +294,9 @@ func TestGetDrive(t *testing.T) {
 	for i, testCase := range testCases {
 		result, err := selectDrive(
 			context.TODO(),
-			clientsetfake.NewSimpleClientset(testCase.objects...).DirectV1beta3().DirectCSIDrives(),
+			clientsetfake.NewSimpleClientset(testCase.objects...).DirectV1beta3(),
 			testCase.request,
+			"",
 		)
 
 		if testCase.expectErr {
@@ -294,8 +329,9 @@ func TestGetDrive(t *testing.T) {
 
 	result, err := selectDrive(
 		context.TODO(),
-		clientsetfake.NewSimpleClientset(objects...).DirectV1beta3().DirectCSIDrives(),
+		clientsetfake.NewSimpleClientset(objects...).DirectV1beta3(),
 		request,
+		"",
 	)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -304,3 +340,76 @@ func TestGetDrive(t *testing.T) {
 		t.Fatalf("result: expected: %v, got: %v", []string{"drive-2", "drive-3"}, result.Name)
 	}
 }
+
+func TestMatchDriveVolumeLimit(t *testing.T) {
+	request := &csi.CreateVolumeRequest{Name: "volume", CapacityRange: &csi.CapacityRange{RequiredBytes: GiB}}
+	testCases := []struct {
+		maxVolumes int32
+		volumes    []string
+		expected   bool
+	}{
+		{0, []string{"volume-1", "volume-2"}, true},
+		{3, []string{"volume-1", "volume-2"}, true},
+		{2, []string{"volume-1", "volume-2"}, false},
+	}
+
+	for i, testCase := range testCases {
+		drive := newStrategyTestDrive("drive", "node", 4*GiB, testCase.volumes...)
+		drive.Spec.MaxVolumes = testCase.maxVolumes
+		if result := matchDrive(*drive, request); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+		capacityRequest := &csi.GetCapacityRequest{}
+		if result := matchCapacityDrive(*drive, capacityRequest); result != testCase.expected {
+			t.Fatalf("case %v: capacity match: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestMatchDriveHealthy(t *testing.T) {
+	request := &csi.CreateVolumeRequest{Name: "volume", CapacityRange: &csi.CapacityRange{RequiredBytes: GiB}}
+	testCases := []struct {
+		conditions []metav1.Condition
+		expected   bool
+	}{
+		{nil, true},
+		{[]metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionTrue}}, true},
+		{[]metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionFalse}}, false},
+		{[]metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionDegraded), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIDriveReasonIOError)}}, true},
+		{[]metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionDegraded), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIDriveReasonFilesystemShutdown)}}, false},
+		{[]metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionDegraded), Status: metav1.ConditionFalse, Reason: string(directcsi.DirectCSIDriveReasonFilesystemShutdown)}}, true},
+	}
+
+	for i, testCase := range testCases {
+		drive := newStrategyTestDrive("drive", "node", 4*GiB)
+		drive.Status.Conditions = testCase.conditions
+		if result := matchDrive(*drive, request); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+		if result := matchCapacityDrive(*drive, &csi.GetCapacityRequest{}); result != testCase.expected {
+			t.Fatalf("case %v: capacity match: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestMatchDriveSchedulable(t *testing.T) {
+	request := &csi.CreateVolumeRequest{Name: "volume", CapacityRange: &csi.CapacityRange{RequiredBytes: GiB}}
+	testCases := []struct {
+		unschedulable bool
+		expected      bool
+	}{
+		{false, true},
+		{true, false},
+	}
+
+	for i, testCase := range testCases {
+		drive := newStrategyTestDrive("drive", "node", 4*GiB)
+		drive.Spec.Unschedulable = testCase.unschedulable
+		if result := matchDrive(*drive, request); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+		if result := matchCapacityDrive(*drive, &csi.GetCapacityRequest{}); result != testCase.expected {
+			t.Fatalf("case %v: capacity match: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func TestValidateRequestedFormatOptions(t *testing.T) {
+	boolPtr := func(value bool) *bool { return &value }
+	testCases := []struct {
+		requestedFormat *directcsi.RequestedFormat
+		capacity        int64
+		allowed         bool
+	}{
+		{&directcsi.RequestedFormat{Force: true}, 0, true},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 4096, SectorSize: 512, AGCount: 4}}, 1024 * 1024 * 1024, true},
+		{&directcsi.RequestedFormat{Filesystem: "ext4", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 4096, LogSize: 64 * 1024 * 1024}}, 1024 * 1024 * 1024, true},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 3000}}, 0, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 512}}, 0, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{CRC: boolPtr(false)}}, 0, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{AGCount: 128}}, 1024 * 1024 * 1024, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{LogSize: 1024 * 1024}}, 0, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 8192, LogSize: 2 * 1024 * 1024}}, 0, false},
+		{&directcsi.RequestedFormat{Filesystem: "xfs", Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 8192, LogSize: 4 * 1024 * 1024}}, 1024 * 1024 * 1024, true},
+		{&directcsi.RequestedFormat{Filesystem: "ext4", Force: true, FormatOptions: &directcsi.FormatOptions{Reflink: boolPtr(true)}}, 0, false},
+		{&directcsi.RequestedFormat{BlockMode: true, Force: true, FormatOptions: &directcsi.FormatOptions{BlockSize: 4096}}, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		drive := directcsi.DirectCSIDrive{
+			Spec: directcsi.DirectCSIDriveSpec{
+				RequestedFormat: testCase.requestedFormat,
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				DriveStatus:   directcsi.DriveStatusAvailable,
+				TotalCapacity: testCase.capacity,
+			},
+		}
+		admissionReview := &admissionv1.AdmissionReview{
+			Response: &admissionv1.AdmissionResponse{Allowed: true},
+		}
+
+		allowed := validateRequestedFormat(drive, admissionReview)
+		if allowed != testCase.allowed || admissionReview.Response.Allowed != testCase.allowed {
+			t.Fatalf("case %v: allowed: expected: %v, got: %v, response: %+v", i+1, testCase.allowed, allowed, admissionReview.Response)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientset "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/matcher"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// volumeGroupKey is the StorageClass parameter to set the volume group.
+	volumeGroupKey = "direct-csi-min-io/volume-group"
+
+	// PVC metadata passed by external-provisioner with --extra-create-metadata.
+	pvcNameKey      = "csi.storage.k8s.io/pvc/name"
+	pvcNamespaceKey = "csi.storage.k8s.io/pvc/namespace"
+)
+
+// getVolumeGroup returns the volume group of the request. The volume group is
+// read from direct.csi.min.io/volume-group label of the PVC, else from the
+// StorageClass parameter. The group is scoped to the namespace of the PVC so
+// that PVCs of different namespaces never share a group by name.
+func (c *ControllerServer) getVolumeGroup(ctx context.Context, req *csi.CreateVolumeRequest) (string, error) {
+	group := req.GetParameters()[volumeGroupKey]
+	name, namespace := req.GetParameters()[pvcNameKey], req.GetParameters()[pvcNamespaceKey]
+	if name != "" && namespace != "" {
+		pvc, err := c.kubeClient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			if label := pvc.GetLabels()[utils.VolumeGroupLabel]; label != "" {
+				group = label
+			}
+		case !errors.IsNotFound(err):
+			return "", err
+		}
+	}
+
+	// Namespaces have no dots, hence the prefix cannot collide with another namespace.
+	if group != "" && namespace != "" {
+		group = namespace + "." + group
+	}
+	return group, nil
+}
+
+// listVolumeGroup returns the volumes of the group other than the volume.
+func listVolumeGroup(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface, volumeID, group string) ([]directcsi.DirectCSIVolume, error) {
+	volumeList, err := directCSIClient.DirectCSIVolumes().List(ctx, metav1.ListOptions{
+		TypeMeta:      utils.DirectCSIVolumeTypeMeta(),
+		LabelSelector: fmt.Sprintf("%s=%s", utils.VolumeGroupLabel, utils.SanitizeLabelV(group)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := []directcsi.DirectCSIVolume{}
+	for _, volume := range volumeList.Items {
+		if volume.Name != volumeID {
+			volumes = append(volumes, volume)
+		}
+	}
+	return volumes, nil
+}
+
+// filterVolumeGroupDrives excludes the drives holding a volume of the group.
+func filterVolumeGroupDrives(
+	ctx context.Context,
+	directCSIClient clientset.DirectV1beta3Interface,
+	drives []directcsi.DirectCSIDrive,
+	volumeID, group string,
+) ([]directcsi.DirectCSIDrive, error) {
+	volumes, err := listVolumeGroup(ctx, directCSIClient, volumeID, group)
+	if err != nil {
+		return nil, err
+	}
+
+	groupDrives := map[string]struct{}{}
+	for _, volume := range volumes {
+		groupDrives[volume.Status.Drive] = struct{}{}
+	}
+
+	var result []directcsi.DirectCSIDrive
+	for _, drive := range drives {
+		if _, found := groupDrives[drive.Name]; !found {
+			result = append(result, drive)
+		}
+	}
+	return result, nil
+}
+
+// checkVolumeGroup fails if the drive is reserved for another volume of the group.
+func checkVolumeGroup(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface, drive *directcsi.DirectCSIDrive, volumeID, group string) error {
+	volumes, err := listVolumeGroup(ctx, directCSIClient, volumeID, group)
+	if err != nil {
+		return err
+	}
+
+	for _, volume := range volumes {
+		if matcher.StringIn(drive.Finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume.Name) {
+			return status.Errorf(codes.ResourceExhausted, "drive %v on node %v already holds volume %v of volume group %v", drive.Name, drive.Status.NodeName, volume.Name, group)
+		}
+	}
+	return nil
+}
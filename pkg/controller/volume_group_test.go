@@ -0,0 +1,170 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetVolumeGroup(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-0",
+			Namespace: "tenant-1",
+			Labels:    map[string]string{utils.VolumeGroupLabel: "pvc-group"},
+		},
+	}
+
+	testCases := []struct {
+		parameters    map[string]string
+		expectedGroup string
+	}{
+		{nil, ""},
+		{map[string]string{volumeGroupKey: "sc-group"}, "sc-group"},
+		{map[string]string{volumeGroupKey: "sc-group", pvcNameKey: "data-0", pvcNamespaceKey: "tenant-1"}, "tenant-1.pvc-group"},
+		{map[string]string{volumeGroupKey: "sc-group", pvcNameKey: "data-1", pvcNamespaceKey: "tenant-1"}, "tenant-1.sc-group"},
+		{map[string]string{volumeGroupKey: "sc-group", pvcNameKey: "data-0", pvcNamespaceKey: "tenant-2"}, "tenant-2.sc-group"},
+		{map[string]string{pvcNameKey: "data-1", pvcNamespaceKey: "tenant-1"}, ""},
+	}
+
+	cl := createFakeController()
+	cl.kubeClient = kubernetesfake.NewSimpleClientset(pvc)
+	for i, testCase := range testCases {
+		group, err := cl.getVolumeGroup(context.TODO(), &csi.CreateVolumeRequest{Name: "volume", Parameters: testCase.parameters})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if group != testCase.expectedGroup {
+			t.Fatalf("case %v: expected group %v, got %v", i+1, testCase.expectedGroup, group)
+		}
+	}
+}
+
+func TestCreateVolumeGroup(t *testing.T) {
+	newDrive := func(name string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:      "N1",
+				Filesystem:    string(sys.FSTypeXFS),
+				DriveStatus:   directcsi.DriveStatusReady,
+				TotalCapacity: mb100,
+				FreeCapacity:  mb100,
+			},
+		}
+	}
+	newRequest := func(name, group string) *csi.CreateVolumeRequest {
+		return &csi.CreateVolumeRequest{
+			Name:          name,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: mb20},
+			VolumeCapabilities: []*csi.VolumeCapability{
+				{
+					AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs"}},
+					AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+				},
+			},
+			Parameters: map[string]string{volumeGroupKey: group},
+		}
+	}
+
+	ctx := context.TODO()
+	cl := createFakeController()
+	cl.directcsiClient = clientsetfake.NewSimpleClientset(newDrive("drive-1"), newDrive("drive-2"))
+	vclient := cl.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+
+	drives := map[string]struct{}{}
+	for _, name := range []string{"volume-1", "volume-2"} {
+		// Create twice to ensure the retried request keeps its drive.
+		for i := 0; i < 2; i++ {
+			if _, err := cl.CreateVolume(ctx, newRequest(name, "group-1")); err != nil {
+				t.Fatalf("volume %v: unexpected error %v", name, err)
+			}
+		}
+		volume, err := vclient.Get(ctx, name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("volume %v: fetch error %v", name, err)
+		}
+		if volume.Labels[utils.VolumeGroupLabel] != "group-1" {
+			t.Fatalf("volume %v: expected volume group label, got %v", name, volume.Labels)
+		}
+		drives[volume.Status.Drive] = struct{}{}
+	}
+	if len(drives) != 2 {
+		t.Fatalf("expected volumes of the group on distinct drives, got %v", drives)
+	}
+
+	_, err := cl.CreateVolume(ctx, newRequest("volume-3", "group-1"))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected error code %v, got %v", codes.ResourceExhausted, err)
+	}
+
+	if _, err = cl.CreateVolume(ctx, newRequest("volume-4", "group-2")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestCheckVolumeGroup(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "drive-1",
+			Finalizers: []string{directcsi.DirectCSIDriveFinalizerPrefix + "volume-1"},
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "volume-1",
+			Labels: map[string]string{utils.VolumeGroupLabel: "group-1"},
+		},
+		Status: directcsi.DirectCSIVolumeStatus{Drive: "drive-1"},
+	}
+	client := clientsetfake.NewSimpleClientset(volume).DirectV1beta3()
+
+	testCases := []struct {
+		volumeID  string
+		group     string
+		errorCode codes.Code
+	}{
+		{"volume-1", "group-1", codes.OK},
+		{"volume-2", "group-2", codes.OK},
+		{"volume-2", "group-1", codes.ResourceExhausted},
+	}
+
+	for i, testCase := range testCases {
+		err := checkVolumeGroup(context.TODO(), client, drive, testCase.volumeID, testCase.group)
+		if status.Code(err) != testCase.errorCode {
+			t.Fatalf("case %v: expected error code %v, got %v", i+1, testCase.errorCode, err)
+		}
+	}
+}
@@ -42,9 +42,10 @@ type driveEventHandler struct {
 	mounter         sys.DriveMounter
 	formatter       sys.DriveFormatter
 	statter         sys.DriveStatter
+	healthPolicy    HealthPolicy
 }
 
-func newDriveEventHandler(nodeID string) *driveEventHandler {
+func newDriveEventHandler(nodeID string, healthPolicy HealthPolicy) *driveEventHandler {
 	return &driveEventHandler{
 		directCSIClient: utils.GetDirectClientset(),
 		kubeClient:      utils.GetKubeClient(),
@@ -52,6 +53,7 @@ func newDriveEventHandler(nodeID string) *driveEventHandler {
 		mounter:         &sys.DefaultDriveMounter{},
 		formatter:       &sys.DefaultDriveFormatter{},
 		statter:         &sys.DefaultDriveStatter{},
+		healthPolicy:    healthPolicy,
 	}
 }
 
@@ -136,6 +138,20 @@ func (handler *driveEventHandler) getFSUUID(ctx context.Context, drive *directcs
 	return drive.Status.FilesystemUUID, nil
 }
 
+func toFormatOptions(options *directcsi.FormatOptions) sys.FormatOptions {
+	if options == nil {
+		return sys.FormatOptions{}
+	}
+	return sys.FormatOptions{
+		BlockSize:  options.BlockSize,
+		SectorSize: options.SectorSize,
+		Reflink:    options.Reflink,
+		CRC:        options.CRC,
+		AGCount:    options.AGCount,
+		LogSize:    options.LogSize,
+	}
+}
+
 func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.DirectCSIDrive) (err error) {
 	fsUUID, err := handler.getFSUUID(ctx, drive)
 	if err != nil {
@@ -152,9 +168,21 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 	target := filepath.Join(sys.MountRoot, drive.Status.FilesystemUUID)
 	mountOpts := drive.Spec.RequestedFormat.MountOptions
 	force := drive.Spec.RequestedFormat.Force
+	fsType := sys.FSType(drive.Spec.RequestedFormat.Filesystem)
+	if fsType == "" {
+		fsType = sys.FSTypeXFS
+	}
+	formatOptions := drive.Spec.RequestedFormat.FormatOptions
 	mounted := drive.Status.Mountpoint != ""
 	formatted := drive.Status.Filesystem != ""
 
+	if err == nil && (!formatted || force) {
+		if err = directcsi.ValidateFormatOptions(formatOptions, string(fsType), drive.Status.TotalCapacity); err != nil {
+			err = fmt.Errorf("invalid format options of drive %s; %w", drive.Name, err)
+			klog.Error(err)
+		}
+	}
+
 	if err == nil && (!formatted || force) {
 		if mounted {
 			if err = handler.mounter.UnmountDrive(source); err != nil {
@@ -167,19 +195,29 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 		}
 
 		if err == nil {
-			if err = handler.formatter.FormatDrive(ctx, drive.Status.FilesystemUUID, source, force); err != nil {
+			if err = handler.formatter.FormatDrive(ctx, drive.Status.FilesystemUUID, source, fsType, force, toFormatOptions(formatOptions)); err != nil {
 				err = fmt.Errorf("failed to format drive %s; %w", drive.Name, err)
 				klog.Error(err)
 			} else {
-				drive.Status.Filesystem = string(sys.FSTypeXFS)
+				drive.Status.Filesystem = string(fsType)
+				drive.Status.FormatOptions = formatOptions
 				drive.Status.AllocatedCapacity = 0
 				formatted = true
+				// Errors reported before are of the earlier filesystem.
+				drive.Status.IOErrors = 0
+				utils.UpdateCondition(
+					drive.Status.Conditions,
+					string(directcsi.DirectCSIDriveConditionDegraded),
+					metav1.ConditionFalse,
+					string(directcsi.DirectCSIDriveReasonAdded),
+					"",
+				)
 			}
 		}
 	}
 
 	if err == nil && formatted && !mounted {
-		if err = handler.mounter.MountDrive(source, target, mountOpts); err != nil {
+		if err = handler.mounter.MountDrive(source, target, sys.FSType(drive.Status.Filesystem), mountOpts); err != nil {
 			err = fmt.Errorf("failed to mount drive %s; %w", drive.Name, err)
 			klog.Error(err)
 		} else {
@@ -251,6 +289,79 @@ func (handler *driveEventHandler) format(ctx context.Context, drive *directcsi.D
 	return err
 }
 
+// formatBlockMode takes over the drive for raw block volumes by writing an
+// empty GPT partition table; volumes are carved as partitions of the drive.
+func (handler *driveEventHandler) formatBlockMode(ctx context.Context, drive *directcsi.DirectCSIDrive) (err error) {
+	source := sys.GetDirectCSIPath(drive.Name)
+	if err = handler.formatter.MakeBlockFile(source, drive.Status.MajorNumber, drive.Status.MinorNumber); err != nil {
+		klog.Error(err)
+	}
+
+	if err == nil && drive.Status.Mountpoint != "" {
+		if err = handler.mounter.UnmountDrive(sys.GetDirectCSIPath(drive.Status.FilesystemUUID)); err != nil {
+			err = fmt.Errorf("failed to unmount drive %s; %w", drive.Name, err)
+			klog.Error(err)
+		} else {
+			drive.Status.Mountpoint = ""
+			drive.Status.MountOptions = nil
+		}
+	}
+
+	formatted := false
+	if err == nil {
+		var freeCapacity int64
+		if freeCapacity, err = handler.formatter.MakeGPT(source, utils.GetSectorSize(drive)); err != nil {
+			err = fmt.Errorf("failed to make GPT on drive %s; %w", drive.Name, err)
+			klog.Error(err)
+		} else {
+			formatted = true
+			drive.Status.BlockMode = true
+			drive.Status.Filesystem = ""
+			drive.Status.FilesystemUUID = ""
+			drive.Status.FormatOptions = nil
+			drive.Status.FreeCapacity = freeCapacity
+			drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+		}
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	utils.UpdateCondition(
+		drive.Status.Conditions,
+		string(directcsi.DirectCSIDriveConditionOwned),
+		utils.BoolToCondition(formatted),
+		string(directcsi.DirectCSIDriveReasonAdded),
+		message,
+	)
+	utils.UpdateCondition(
+		drive.Status.Conditions,
+		string(directcsi.DirectCSIDriveConditionMounted),
+		metav1.ConditionFalse,
+		string(directcsi.DirectCSIDriveReasonAdded),
+		string(directcsi.DirectCSIDriveMessageNotMounted),
+	)
+
+	if err == nil {
+		drive.Finalizers = []string{directcsi.DirectCSIDriveFinalizerDataProtection}
+		drive.Status.DriveStatus = directcsi.DriveStatusReady
+		drive.Spec.RequestedFormat = nil
+	}
+
+	if _, uErr := handler.directCSIClient.DirectV1beta3().DirectCSIDrives().Update(
+		ctx, drive, metav1.UpdateOptions{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		},
+	); uErr != nil {
+		if err == nil {
+			err = uErr
+		}
+	}
+
+	return err
+}
+
 func (handler *driveEventHandler) release(ctx context.Context, drive *directcsi.DirectCSIDrive) error {
 	err := handler.mounter.UnmountDrive(sys.GetDirectCSIPath(drive.Status.FilesystemUUID))
 	if err != nil {
@@ -309,6 +420,9 @@ func (handler *driveEventHandler) update(ctx context.Context, drive *directcsi.D
 
 		switch drive.Status.DriveStatus {
 		case directcsi.DriveStatusAvailable:
+			if drive.Spec.RequestedFormat.BlockMode {
+				return handler.formatBlockMode(ctx, drive)
+			}
 			return handler.format(ctx, drive)
 		case directcsi.DriveStatusReleased,
 			directcsi.DriveStatusUnavailable,
@@ -319,7 +433,7 @@ func (handler *driveEventHandler) update(ctx context.Context, drive *directcsi.D
 		}
 	}
 
-	return nil
+	return handler.checkHealth(ctx, drive)
 }
 
 func (handler *driveEventHandler) delete(ctx context.Context, drive *directcsi.DirectCSIDrive) error {
@@ -327,12 +441,12 @@ func (handler *driveEventHandler) delete(ctx context.Context, drive *directcsi.D
 }
 
 // StartController starts drive event controller.
-func StartController(ctx context.Context, nodeID string) error {
+func StartController(ctx context.Context, nodeID string, healthPolicy HealthPolicy) error {
 	hostname, err := os.Hostname()
 	if err != nil {
 		return err
 	}
 
-	listener := listener.NewListener(newDriveEventHandler(nodeID), "drive-controller", hostname, 40)
+	listener := listener.NewListener(newDriveEventHandler(nodeID, healthPolicy), "drive-controller", hostname, 40)
 	return listener.Run(ctx)
 }
@@ -34,6 +34,8 @@ import (
 
 const (
 	testNodeID = "test-node"
+
+	mb100 = 100 * 1024 * 1024
 )
 
 type fakeDriveStatter struct {
@@ -47,20 +49,32 @@ func (c *fakeDriveStatter) GetFreeCapacityFromStatfs(path string) (int64, error)
 	return 0, nil
 }
 
+func (c *fakeDriveStatter) IsReadOnly(path string) (bool, error) {
+	return false, nil
+}
+
 type fakeDriveFormatter struct {
 	formatArgs struct {
-		uuid  string
-		path  string
-		force bool
+		uuid    string
+		path    string
+		fsType  sys.FSType
+		force   bool
+		options sys.FormatOptions
 	}
 	makeBlockFileArgs struct {
 		path  string
 		major uint32
 		minor uint32
 	}
+	makeGPTArgs struct {
+		path       string
+		sectorSize uint64
+	}
 }
 
-func (c *fakeDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, force bool) error {
+func (c *fakeDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, fsType sys.FSType, force bool, options sys.FormatOptions) error {
+	c.formatArgs.fsType = fsType
+	c.formatArgs.options = options
 	c.formatArgs.path = path
 	c.formatArgs.force = force
 	c.formatArgs.uuid = uuid
@@ -74,10 +88,17 @@ func (c *fakeDriveFormatter) MakeBlockFile(path string, major, minor uint32) err
 	return nil
 }
 
+func (c *fakeDriveFormatter) MakeGPT(path string, sectorSize uint64) (int64, error) {
+	c.makeGPTArgs.path = path
+	c.makeGPTArgs.sectorSize = sectorSize
+	return mb100, nil
+}
+
 type fakeDriveMounter struct {
 	mountArgs struct {
 		source    string
 		target    string
+		fsType    sys.FSType
 		mountOpts []string
 	}
 	unmountArgs struct {
@@ -85,7 +106,8 @@ type fakeDriveMounter struct {
 	}
 }
 
-func (c *fakeDriveMounter) MountDrive(source, target string, mountOpts []string) error {
+func (c *fakeDriveMounter) MountDrive(source, target string, fsType sys.FSType, mountOpts []string) error {
+	c.mountArgs.fsType = fsType
 	c.mountArgs.source = source
 	c.mountArgs.target = target
 	c.mountArgs.mountOpts = mountOpts
@@ -230,6 +252,7 @@ func TestDriveFormat(t *testing.T) {
 	dl := createFakeDriveEventListener()
 	dl.directCSIClient = clientsetfake.NewSimpleClientset(testDriveObjs...)
 	directCSIClient := dl.directCSIClient.DirectV1beta3()
+	fsTypes := []sys.FSType{sys.FSTypeXFS, sys.FSTypeExt4}
 
 	for i, tObj := range testDriveObjs {
 		dObj := tObj.(*directcsi.DirectCSIDrive)
@@ -245,8 +268,9 @@ func TestDriveFormat(t *testing.T) {
 		newObj.Spec.DirectCSIOwned = true
 		force := true
 		newObj.Spec.RequestedFormat = &directcsi.RequestedFormat{
-			Force:      force,
-			Filesystem: string(sys.FSTypeXFS),
+			Force:         force,
+			Filesystem:    string(fsTypes[i]),
+			FormatOptions: &directcsi.FormatOptions{BlockSize: 2048},
 		}
 
 		// Step 4: Execute the Update hook
@@ -275,6 +299,12 @@ func TestDriveFormat(t *testing.T) {
 		if dl.formatter.(*fakeDriveFormatter).formatArgs.force != force {
 			t.Errorf("Test case [%d]: Wrong force option provided for formatting. Expected: %v, Found: %v", i, force, dl.formatter.(*fakeDriveFormatter).formatArgs.force)
 		}
+		if dl.formatter.(*fakeDriveFormatter).formatArgs.fsType != fsTypes[i] {
+			t.Errorf("Test case [%d]: Wrong filesystem provided for formatting. Expected: %v, Found: %v", i, fsTypes[i], dl.formatter.(*fakeDriveFormatter).formatArgs.fsType)
+		}
+		if dl.formatter.(*fakeDriveFormatter).formatArgs.options.BlockSize != 2048 {
+			t.Errorf("Test case [%d]: Wrong block size provided for formatting. Expected: 2048, Found: %v", i, dl.formatter.(*fakeDriveFormatter).formatArgs.options.BlockSize)
+		}
 
 		// Step 4.2: Check if mount arguments passed are correct
 		if dl.mounter.(*fakeDriveMounter).mountArgs.source != sys.GetDirectCSIPath(dObj.Status.FilesystemUUID) {
@@ -283,6 +313,9 @@ func TestDriveFormat(t *testing.T) {
 		if dl.mounter.(*fakeDriveMounter).mountArgs.target != filepath.Join(sys.MountRoot, dObj.Status.FilesystemUUID) {
 			t.Errorf("Test case [%d]: Wrong target provided for mounting. Expected: %s, Found: %s", i, filepath.Join(sys.MountRoot, dObj.Status.FilesystemUUID), dl.mounter.(*fakeDriveMounter).mountArgs.target)
 		}
+		if dl.mounter.(*fakeDriveMounter).mountArgs.fsType != fsTypes[i] {
+			t.Errorf("Test case [%d]: Wrong filesystem provided for mounting. Expected: %v, Found: %v", i, fsTypes[i], dl.mounter.(*fakeDriveMounter).mountArgs.fsType)
+		}
 		umountSource := func() string {
 			if dObj.Status.Mountpoint != "" {
 				return sys.GetDirectCSIPath(dObj.Status.FilesystemUUID)
@@ -310,10 +343,13 @@ func TestDriveFormat(t *testing.T) {
 		if csiDrive.Status.DriveStatus != directcsi.DriveStatusReady {
 			t.Errorf("Test case [%d]: Drive is not in 'ready' state after formatting. Current status: %s", i, csiDrive.Status.DriveStatus)
 		}
+		if csiDrive.Status.FormatOptions == nil || csiDrive.Status.FormatOptions.BlockSize != 2048 {
+			t.Errorf("Test case [%d]: Format options are not recorded after formatting. Found: %+v", i, csiDrive.Status.FormatOptions)
+		}
 		if csiDrive.Status.Mountpoint != filepath.Join(sys.MountRoot, newObj.Status.FilesystemUUID) {
 			t.Errorf("Test case [%d]: Drive mountpoint invalid: %s", i, csiDrive.Status.Mountpoint)
 		}
-		if csiDrive.Status.Filesystem != string(sys.FSTypeXFS) {
+		if csiDrive.Status.Filesystem != string(fsTypes[i]) {
 			t.Errorf("Test case [%d]: Invalid filesystem after formatting: %s", i, string(csiDrive.Status.Filesystem))
 		}
 
@@ -343,6 +379,67 @@ func TestDriveFormat(t *testing.T) {
 	}
 }
 
+func TestDriveFormatBlockMode(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-drive",
+		},
+		Spec: directcsi.DirectCSIDriveSpec{
+			DirectCSIOwned:  true,
+			RequestedFormat: &directcsi.RequestedFormat{BlockMode: true, Force: true},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:         testNodeID,
+			DriveStatus:      directcsi.DriveStatusAvailable,
+			Path:             "/dev/sdb",
+			Filesystem:       string(sys.FSTypeXFS),
+			Mountpoint:       "/mnt/mp",
+			FilesystemUUID:   "d8e7d5de-88c6-4675-9e38-f712669e87b3",
+			LogicalBlockSize: 4096,
+			MajorNumber:      8,
+			MinorNumber:      16,
+			Conditions: []metav1.Condition{
+				{Type: string(directcsi.DirectCSIDriveConditionOwned), Status: metav1.ConditionFalse},
+				{Type: string(directcsi.DirectCSIDriveConditionMounted), Status: metav1.ConditionTrue},
+			},
+		},
+	}
+
+	ctx := context.TODO()
+	dl := createFakeDriveEventListener()
+	dl.directCSIClient = clientsetfake.NewSimpleClientset(drive)
+
+	if err := dl.update(ctx, drive.DeepCopy()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	formatter := dl.formatter.(*fakeDriveFormatter)
+	if formatter.makeGPTArgs.path != sys.GetDirectCSIPath(drive.Name) || formatter.makeGPTArgs.sectorSize != 4096 {
+		t.Fatalf("unexpected MakeGPT arguments %+v", formatter.makeGPTArgs)
+	}
+	if formatter.formatArgs.path != "" {
+		t.Fatalf("drive must not be formatted with filesystem; %+v", formatter.formatArgs)
+	}
+	if dl.mounter.(*fakeDriveMounter).unmountArgs.source != sys.GetDirectCSIPath(drive.Status.FilesystemUUID) {
+		t.Fatalf("expected drive to be unmounted, got: %v", dl.mounter.(*fakeDriveMounter).unmountArgs.source)
+	}
+
+	result, err := dl.directCSIClient.DirectV1beta3().DirectCSIDrives().Get(ctx, drive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+	if err != nil {
+		t.Fatalf("drive fetch error %v", err)
+	}
+	if !result.Status.BlockMode || result.Status.DriveStatus != directcsi.DriveStatusReady || result.Spec.RequestedFormat != nil {
+		t.Fatalf("unexpected drive %+v", result)
+	}
+	if result.Status.FreeCapacity != mb100 || result.Status.Filesystem != "" || result.Status.Mountpoint != "" {
+		t.Fatalf("unexpected drive status %+v", result.Status)
+	}
+	if !utils.IsConditionStatus(result.Status.Conditions, string(directcsi.DirectCSIDriveConditionOwned), metav1.ConditionTrue) {
+		t.Fatalf("unexpected conditions %v", result.Status.Conditions)
+	}
+}
+
 func TestDriveDelete(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -0,0 +1,129 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// HealthPolicy denotes thresholds of drive health at which the drive is cordoned, i.e. no more
+// volumes are placed on it. Zero threshold disables its check.
+type HealthPolicy struct {
+	// CriticalWarnings cordons a drive raising S.M.A.R.T. or NVMe critical warnings.
+	CriticalWarnings bool
+	// MediaErrors is number of media errors reported by the drive.
+	MediaErrors int64
+	// IOErrors is number of I/O errors of the drive found in kernel log.
+	IOErrors int64
+}
+
+// check returns reason and message of the drive being unhealthy; empty reason means healthy.
+func (policy HealthPolicy) check(drive *directcsi.DirectCSIDrive) (directcsi.DirectCSIDriveReason, string) {
+	// A drive having its filesystem shut down is faulty regardless of thresholds.
+	for _, condition := range drive.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSIDriveConditionDegraded) &&
+			condition.Status == metav1.ConditionTrue &&
+			condition.Reason == string(directcsi.DirectCSIDriveReasonFilesystemShutdown) {
+			return directcsi.DirectCSIDriveReasonFilesystemShutdown,
+				fmt.Sprintf("filesystem of the drive is shut down; %v", condition.Message)
+		}
+	}
+
+	if health := drive.Status.Health; health != nil {
+		if policy.CriticalWarnings && len(health.CriticalWarnings) > 0 {
+			return directcsi.DirectCSIDriveReasonCriticalWarning,
+				fmt.Sprintf("drive raised critical warnings %v", strings.Join(health.CriticalWarnings, ", "))
+		}
+
+		if policy.MediaErrors > 0 && health.MediaErrors >= policy.MediaErrors {
+			return directcsi.DirectCSIDriveReasonMediaErrorThreshold,
+				fmt.Sprintf("drive has %v media errors; threshold is %v", health.MediaErrors, policy.MediaErrors)
+		}
+	}
+
+	if policy.IOErrors > 0 && drive.Status.IOErrors >= policy.IOErrors {
+		return directcsi.DirectCSIDriveReasonIOErrorThreshold,
+			fmt.Sprintf("drive has %v I/O errors; threshold is %v", drive.Status.IOErrors, policy.IOErrors)
+	}
+
+	return "", ""
+}
+
+// checkHealth sets Healthy condition of the drive by health policy.
+func (handler *driveEventHandler) checkHealth(ctx context.Context, drive *directcsi.DirectCSIDrive) error {
+	reason, message := handler.healthPolicy.check(drive)
+	healthy := reason == ""
+	if healthy {
+		reason = directcsi.DirectCSIDriveReasonHealthy
+	}
+
+	var condition *metav1.Condition
+	for i := range drive.Status.Conditions {
+		if drive.Status.Conditions[i].Type == string(directcsi.DirectCSIDriveConditionHealthy) {
+			condition = &drive.Status.Conditions[i]
+			break
+		}
+	}
+
+	switch {
+	case condition == nil && healthy:
+		// Drives are healthy unless told otherwise; avoid updating every drive.
+		return nil
+	case condition != nil && condition.Status == utils.BoolToCondition(healthy) && condition.Reason == string(reason) && condition.Message == message:
+		return nil
+	}
+	wasHealthy := condition == nil || condition.Status != metav1.ConditionFalse
+
+	drive.Status.Conditions = utils.SetCondition(
+		drive.Status.Conditions,
+		string(directcsi.DirectCSIDriveConditionHealthy),
+		utils.BoolToCondition(healthy),
+		string(reason),
+		message,
+	)
+	drive, err := handler.directCSIClient.DirectV1beta3().DirectCSIDrives().Update(
+		ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()},
+	)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case wasHealthy && !healthy:
+		klog.V(3).InfoS("drive is cordoned by health policy", "drive", drive.Name, "reason", reason, "message", message)
+		volumes := utils.GetDriveVolumes(drive)
+		if len(volumes) == 0 {
+			utils.Eventf(drive, corev1.EventTypeWarning, string(reason), "drive is cordoned; %v", message)
+		} else {
+			utils.Eventf(drive, corev1.EventTypeWarning, string(reason), "drive is cordoned; %v; volumes %v are still on the drive", message, strings.Join(volumes, ", "))
+		}
+	case !wasHealthy && healthy:
+		klog.V(3).InfoS("drive is healthy", "drive", drive.Name)
+		utils.Eventf(drive, corev1.EventTypeNormal, string(reason), "drive is healthy")
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drive
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHealthPolicyCheck(t *testing.T) {
+	policy := HealthPolicy{CriticalWarnings: true, MediaErrors: 10, IOErrors: 100}
+	testCases := []struct {
+		policy         HealthPolicy
+		health         *directcsi.DriveHealth
+		ioErrors       int64
+		degradedReason directcsi.DirectCSIDriveReason
+		expectedReason directcsi.DirectCSIDriveReason
+	}{
+		{policy, nil, 0, "", ""},
+		{policy, &directcsi.DriveHealth{MediaErrors: 9}, 99, "", ""},
+		{policy, &directcsi.DriveHealth{CriticalWarnings: []string{"ReliabilityDegraded"}}, 0, "", directcsi.DirectCSIDriveReasonCriticalWarning},
+		{policy, &directcsi.DriveHealth{MediaErrors: 10}, 0, "", directcsi.DirectCSIDriveReasonMediaErrorThreshold},
+		{policy, nil, 100, "", directcsi.DirectCSIDriveReasonIOErrorThreshold},
+		{HealthPolicy{}, &directcsi.DriveHealth{MediaErrors: 1000, CriticalWarnings: []string{"ReadOnly"}}, 1000, "", ""},
+		{policy, nil, 1, directcsi.DirectCSIDriveReasonIOError, ""},
+		{policy, nil, 1, directcsi.DirectCSIDriveReasonFilesystemShutdown, directcsi.DirectCSIDriveReasonFilesystemShutdown},
+		{HealthPolicy{}, nil, 0, directcsi.DirectCSIDriveReasonFilesystemShutdown, directcsi.DirectCSIDriveReasonFilesystemShutdown},
+	}
+
+	for i, testCase := range testCases {
+		drive := &directcsi.DirectCSIDrive{
+			Status: directcsi.DirectCSIDriveStatus{Health: testCase.health, IOErrors: testCase.ioErrors},
+		}
+		if testCase.degradedReason != "" {
+			drive.Status.Conditions = []metav1.Condition{
+				{
+					Type:   string(directcsi.DirectCSIDriveConditionDegraded),
+					Status: metav1.ConditionTrue,
+					Reason: string(testCase.degradedReason),
+				},
+			}
+		}
+		if reason, _ := testCase.policy.check(drive); reason != testCase.expectedReason {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedReason, reason)
+		}
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	utils.FakeInit()
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-drive",
+			Finalizers: []string{
+				string(directcsi.DirectCSIDriveFinalizerDataProtection),
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-1",
+				directcsi.DirectCSIDriveFinalizerPrefix + "volume-2",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			DriveStatus: directcsi.DriveStatusInUse,
+			Health:      &directcsi.DriveHealth{MediaErrors: 2},
+		},
+	}
+
+	handler := createFakeDriveEventListener()
+	handler.directCSIClient = clientsetfake.NewSimpleClientset(drive)
+	handler.healthPolicy = HealthPolicy{CriticalWarnings: true, MediaErrors: 10}
+	driveInterface := handler.directCSIClient.DirectV1beta3().DirectCSIDrives()
+	getDrive := func() *directcsi.DirectCSIDrive {
+		result, err := driveInterface.Get(context.TODO(), drive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return result
+	}
+
+	// Healthy drive without Healthy condition is not updated.
+	if err := handler.checkHealth(context.TODO(), getDrive()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result := getDrive(); len(result.Status.Conditions) != 0 {
+		t.Fatalf("unexpected conditions %v", result.Status.Conditions)
+	}
+
+	unhealthy := getDrive()
+	unhealthy.Status.Health.MediaErrors = 12
+	if err := handler.checkHealth(context.TODO(), unhealthy); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	result := getDrive()
+	if !utils.IsCondition(result.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy), metav1.ConditionFalse,
+		string(directcsi.DirectCSIDriveReasonMediaErrorThreshold), "drive has 12 media errors; threshold is 10") {
+		t.Fatalf("unexpected conditions %v", result.Status.Conditions)
+	}
+	if volumes := utils.GetDriveVolumes(result); !reflect.DeepEqual(volumes, []string{"volume-1", "volume-2"}) {
+		t.Fatalf("volumes: expected: %v, got: %v", []string{"volume-1", "volume-2"}, volumes)
+	}
+
+	result.Status.Health = &directcsi.DriveHealth{}
+	if err := handler.checkHealth(context.TODO(), result); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result = getDrive(); !utils.IsCondition(result.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy), metav1.ConditionTrue,
+		string(directcsi.DirectCSIDriveReasonHealthy), "") {
+		t.Fatalf("unexpected conditions %v", result.Status.Conditions)
+	}
+}
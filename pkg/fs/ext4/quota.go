@@ -0,0 +1,59 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"context"
+	"fmt"
+
+	fserrors "github.com/minio/direct-csi/pkg/fs/errors"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
+)
+
+// GetQuota returns EXT4 project quota of given project ID.
+func GetQuota(ctx context.Context, device string, projectID uint32) (quota *xfs.Quota, err error) {
+	doneCh := make(chan struct{})
+	go func() {
+		quota, err = getQuota(device, projectID)
+		close(doneCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w; %v", fserrors.ErrCanceled, ctx.Err())
+	case <-doneCh:
+	}
+
+	return quota, err
+}
+
+// SetQuota sets EXT4 project quota of given project ID on the path.
+func SetQuota(ctx context.Context, device, path string, projectID uint32, quota xfs.Quota) (err error) {
+	doneCh := make(chan struct{})
+	go func() {
+		err = setQuota(device, path, projectID, quota)
+		close(doneCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w; %v", fserrors.ErrCanceled, ctx.Err())
+	case <-doneCh:
+	}
+
+	return err
+}
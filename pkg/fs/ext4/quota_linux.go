@@ -0,0 +1,164 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext4
+
+import (
+	"math"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/minio/direct-csi/pkg/fs/xfs"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Refer below links for more information about these constants and their calculations.
+	// - https://man7.org/linux/man-pages/man2/quotactl.2.html
+	// - https://github.com/torvalds/linux/blob/master/include/uapi/linux/quota.h
+	prjQuotaType = 2
+	subCmdShift  = 8
+	subCmdMask   = 0x00ff
+
+	getQuotaCmd = 0x800007 // Q_GETQUOTA
+	prjGetQuota = uintptr(getQuotaCmd<<subCmdShift | prjQuotaType&subCmdMask)
+
+	setQuotaCmd = 0x800008 // Q_SETQUOTA
+	prjSetQuota = uintptr(setQuotaCmd<<subCmdShift | prjQuotaType&subCmdMask)
+
+	setInfoCmd = 0x800006 // Q_SETINFO
+	prjSetInfo = uintptr(setInfoCmd<<subCmdShift | prjQuotaType&subCmdMask)
+
+	quotaBlockLimits = 1    // QIF_BLIMITS
+	quotaInodeLimits = 4    // QIF_ILIMITS
+	quotaBlockSize   = 1024 // QIF_DQBLKSIZE
+
+	infoBlockGrace = 1 // IIF_BGRACE
+	infoInodeGrace = 2 // IIF_IGRACE
+)
+
+type diskQuota struct {
+	hardLimitBlocks uint64 // dqb_bhardlimit: absolute limit on disk quota blocks
+	softLimitBlocks uint64 // dqb_bsoftlimit: preferred limit on disk quota blocks
+	currentSpace    uint64 // dqb_curspace: current space occupied in bytes
+	hardLimitInodes uint64 // dqb_ihardlimit: maximum number of allocated inodes
+	softLimitInodes uint64 // dqb_isoftlimit: preferred inode limit
+	currentInodes   uint64 // dqb_curinodes: current number of allocated inodes
+	_               uint64 // dqb_btime: time limit for excessive disk use
+	_               uint64 // dqb_itime: time limit for excessive files
+	valid           uint32 // dqb_valid: bit mask of QIF_* constants
+}
+
+type diskQuotaInfo struct {
+	blockGrace uint64 // dqi_bgrace: time before block soft limit becomes hard limit
+	inodeGrace uint64 // dqi_igrace: time before inode soft limit becomes hard limit
+	_          uint32 // dqi_flags: flags for quotafile
+	valid      uint32 // dqi_valid: bit mask of IIF_* constants
+}
+
+func quotactl(cmd uintptr, device string, projectID uint32, addr unsafe.Pointer) error {
+	deviceNamePtr, err := syscall.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_QUOTACTL,
+		cmd,
+		uintptr(unsafe.Pointer(deviceNamePtr)),
+		uintptr(projectID),
+		uintptr(addr),
+		0,
+		0,
+	)
+	if errno != 0 {
+		return os.NewSyscallError("quotactl", errno)
+	}
+
+	return nil
+}
+
+func getQuota(device string, projectID uint32) (*xfs.Quota, error) {
+	result := &diskQuota{}
+	if err := quotactl(prjGetQuota, device, projectID, unsafe.Pointer(result)); err != nil {
+		return nil, err
+	}
+
+	return &xfs.Quota{
+		HardLimit:      result.hardLimitBlocks * quotaBlockSize,
+		SoftLimit:      result.softLimitBlocks * quotaBlockSize,
+		CurrentSpace:   result.currentSpace,
+		HardInodeLimit: result.hardLimitInodes,
+		SoftInodeLimit: result.softLimitInodes,
+		CurrentInodes:  result.currentInodes,
+	}, nil
+}
+
+func setProjectQuota(device string, projectID uint32, quota xfs.Quota) error {
+	return quotactl(prjSetQuota, device, projectID, unsafe.Pointer(&diskQuota{
+		hardLimitBlocks: uint64(math.Ceil(float64(quota.HardLimit) / quotaBlockSize)),
+		softLimitBlocks: uint64(math.Ceil(float64(quota.SoftLimit) / quotaBlockSize)),
+		hardLimitInodes: quota.HardInodeLimit,
+		softLimitInodes: quota.SoftInodeLimit,
+		valid:           quotaBlockLimits | quotaInodeLimits,
+	}))
+}
+
+// setGracePeriods sets filesystem wide grace periods of project quota.
+func setGracePeriods(device string, quota xfs.Quota) error {
+	info := &diskQuotaInfo{}
+	if quota.BlockGracePeriod > 0 {
+		info.valid |= infoBlockGrace
+		info.blockGrace = uint64(quota.BlockGracePeriod.Seconds())
+	}
+	if quota.InodeGracePeriod > 0 {
+		info.valid |= infoInodeGrace
+		info.inodeGrace = uint64(quota.InodeGracePeriod.Seconds())
+	}
+	if info.valid == 0 {
+		return nil
+	}
+
+	return quotactl(prjSetInfo, device, 0, unsafe.Pointer(info))
+}
+
+func setQuota(device, path string, projectID uint32, quota xfs.Quota) error {
+	if err := setGracePeriods(device, quota); err != nil {
+		klog.ErrorS(err, "unable to set grace periods", "Device", device, "BlockGracePeriod", quota.BlockGracePeriod, "InodeGracePeriod", quota.InodeGracePeriod)
+		return err
+	}
+
+	if info, err := getQuota(device, projectID); err == nil && xfs.IsQuotaSet(info, quota) {
+		klog.V(3).InfoS("Quota is already set", "Device", device, "Path", path, "ProjectID", projectID, "HardLimitSet", info.HardLimit, "HardLimit", quota.HardLimit)
+		return nil
+	}
+
+	if err := xfs.SetProjectID(path, projectID); err != nil {
+		klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
+		return err
+	}
+
+	if err := setProjectQuota(device, projectID, quota); err != nil {
+		klog.ErrorS(err, "unable to set quota", "Device", device, "Path", path, "Limit", quota.HardLimit)
+		return err
+	}
+
+	klog.V(3).InfoS("SetQuota succeeded", "Device", device, "Path", path, "ProjectID", projectID, "HardLimit", quota.HardLimit, "HardInodeLimit", quota.HardInodeLimit)
+	return nil
+}
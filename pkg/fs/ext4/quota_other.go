@@ -1,3 +1,5 @@
+//go:build !linux
+
 // This file is part of MinIO Direct CSI
 // Copyright (c) 2021 MinIO, Inc.
 //
@@ -14,25 +16,19 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
-package node
+package ext4
 
 import (
-	"context"
+	"fmt"
+	"runtime"
 
 	"github.com/minio/direct-csi/pkg/fs/xfs"
 )
 
-type quotaFuncs interface {
-	GetQuota(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error)
-	SetQuota(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error)
-}
-
-type xfsQuotaFuncs struct{}
-
-func (q *xfsQuotaFuncs) GetQuota(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error) {
-	return xfs.GetQuota(ctx, device, volumeID)
+func getQuota(device string, projectID uint32) (*xfs.Quota, error) {
+	return nil, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
 
-func (q *xfsQuotaFuncs) SetQuota(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error) {
-	return xfs.SetQuota(ctx, device, path, volumeID, quota)
+func setQuota(device, path string, projectID uint32, quota xfs.Quota) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
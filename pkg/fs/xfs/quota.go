@@ -20,23 +20,116 @@ package xfs
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"strconv"
+	"time"
 
 	fserrors "github.com/minio/direct-csi/pkg/fs/errors"
+	simd "github.com/minio/sha256-simd"
+)
+
+// StorageClass parameters of project quota.
+const (
+	// InodeLimitKey holds the hard limit of inodes of a volume.
+	InodeLimitKey = "direct-csi-min-io/inode-limit"
+
+	// SoftLimitPercentageKey holds the soft limits of blocks and inodes as percentage of their hard limits.
+	SoftLimitPercentageKey = "direct-csi-min-io/soft-limit-percentage"
+
+	// BlockGracePeriodKey holds the duration a volume may stay above its block soft limit.
+	BlockGracePeriodKey = "direct-csi-min-io/block-grace-period"
+
+	// InodeGracePeriodKey holds the duration a volume may stay above its inode soft limit.
+	InodeGracePeriodKey = "direct-csi-min-io/inode-grace-period"
 )
 
 // Quota denotes XFS quota information.
 type Quota struct {
-	HardLimit    uint64
-	SoftLimit    uint64
-	CurrentSpace uint64
+	HardLimit      uint64
+	SoftLimit      uint64
+	CurrentSpace   uint64
+	HardInodeLimit uint64
+	SoftInodeLimit uint64
+	CurrentInodes  uint64
+
+	// Grace periods are set filesystem wide; zero leaves them unchanged.
+	BlockGracePeriod time.Duration
+	InodeGracePeriod time.Duration
+}
+
+func parseGracePeriod(parameters map[string]string, key string) (time.Duration, error) {
+	value, found := parameters[key]
+	if !found {
+		return 0, nil
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v parameter %v; %w", key, value, err)
+	}
+	if period < time.Second {
+		return 0, fmt.Errorf("invalid %v parameter %v; grace period must be at least one second", key, value)
+	}
+
+	return period, nil
+}
+
+// NewQuota returns quota of given size in bytes by project quota parameters.
+func NewQuota(size uint64, parameters map[string]string) (*Quota, error) {
+	quota := &Quota{HardLimit: size}
+
+	if value, found := parameters[InodeLimitKey]; found {
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 {
+			return nil, fmt.Errorf("invalid %v parameter %v; inode limit must be a positive integer", InodeLimitKey, value)
+		}
+		quota.HardInodeLimit = limit
+	}
+
+	percentage := uint64(100)
+	if value, found := parameters[SoftLimitPercentageKey]; found {
+		var err error
+		if percentage, err = strconv.ParseUint(value, 10, 64); err != nil || percentage == 0 || percentage > 100 {
+			return nil, fmt.Errorf("invalid %v parameter %v; percentage must be between 1 and 100", SoftLimitPercentageKey, value)
+		}
+	}
+	quota.SoftLimit = quota.HardLimit * percentage / 100
+	quota.SoftInodeLimit = quota.HardInodeLimit * percentage / 100
+
+	var err error
+	if quota.BlockGracePeriod, err = parseGracePeriod(parameters, BlockGracePeriodKey); err != nil {
+		return nil, err
+	}
+	if quota.InodeGracePeriod, err = parseGracePeriod(parameters, InodeGracePeriodKey); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+// GetProjectID returns project ID hashed from given volume ID. It is used by volumes
+// created before project IDs were allocated per drive.
+func GetProjectID(volumeID string) uint32 {
+	h := simd.Sum256([]byte(volumeID))
+	return binary.LittleEndian.Uint32(h[:8])
+}
+
+// IsQuotaSet returns whether the existing quota already satisfies the requested quota.
+// A lower existing hard limit is not satisfied, so that it gets raised on volume expansion.
+// Grace periods are not part of per volume quota, hence they are not compared.
+func IsQuotaSet(existing *Quota, quota Quota) bool {
+	return existing != nil &&
+		existing.HardLimit >= quota.HardLimit &&
+		existing.HardInodeLimit == quota.HardInodeLimit &&
+		existing.SoftInodeLimit == quota.SoftInodeLimit
 }
 
-// GetQuota returns XFS quota information of given volume ID.
-func GetQuota(ctx context.Context, device, volumeID string) (quota *Quota, err error) {
+// GetQuota returns XFS quota information of given project ID.
+func GetQuota(ctx context.Context, device string, projectID uint32) (quota *Quota, err error) {
 	doneCh := make(chan struct{})
 	go func() {
-		quota, err = getQuota(device, volumeID)
+		quota, err = getQuota(device, projectID)
 		close(doneCh)
 	}()
 
@@ -49,11 +142,11 @@ func GetQuota(ctx context.Context, device, volumeID string) (quota *Quota, err e
 	return quota, err
 }
 
-// SetQuota sets quota information on given path and volume ID.
-func SetQuota(ctx context.Context, device, path, volumeID string, quota Quota) (err error) {
+// SetQuota sets quota information on given path and project ID.
+func SetQuota(ctx context.Context, device, path string, projectID uint32, quota Quota) (err error) {
 	doneCh := make(chan struct{})
 	go func() {
-		err = setQuota(device, path, volumeID, quota)
+		err = setQuota(device, path, projectID, quota)
 		close(doneCh)
 	}()
 
@@ -19,13 +19,11 @@
 package xfs
 
 import (
-	"encoding/binary"
 	"math"
 	"os"
 	"syscall"
 	"unsafe"
 
-	simd "github.com/minio/sha256-simd"
 	"k8s.io/klog/v2"
 )
 
@@ -45,8 +43,12 @@ const (
 
 	fsDiskQuotaVersion  = 1
 	xfsProjectQuotaFlag = 2
-	fieldMaskBHard      = 8
+	fieldMaskISoft      = 1
+	fieldMaskIHard      = 2
 	fieldMaskBSoft      = 4
+	fieldMaskBHard      = 8
+	fieldMaskBTimer     = 64
+	fieldMaskITimer     = 128
 	blockSize           = 512
 
 	fsGetAttr          = 0x801c581f // FS_IOC_FSGETXATTR
@@ -63,12 +65,12 @@ type fsDiskQuota struct {
 	id              uint32  // User, project, or group ID
 	hardLimitBlocks uint64  // Absolute limit on disk blocks
 	softLimitBlocks uint64  // Preferred limit on disk blocks
-	_               uint64  // hardLimitInodes: Maximum allocated inodes
-	_               uint64  // softLimitInodes: Preferred inode limit
+	hardLimitInodes uint64  // Maximum allocated inodes
+	softLimitInodes uint64  // Preferred inode limit
 	blocksCount     uint64  // disk blocks owned by the project/user/group
-	_               uint64  // inodesCount: inodes owned by the project/user/group
-	_               int32   // inodeTimer: Zero if within inode limits, If not, we refuse service
-	_               int32   // blocksTimer: Similar to above; for disk blocks
+	inodesCount     uint64  // inodes owned by the project/user/group
+	inodeTimer      int32   // Zero if within inode limits, If not, we refuse service
+	blocksTimer     int32   // Similar to above; for disk blocks
 	_               uint16  // inodeWarnings: warnings issued with respect to number of inodes
 	_               uint16  // blockWarnings: warnings issued with respect to disk blocks
 	_               int32   // padding2: Padding - for future use
@@ -90,17 +92,11 @@ type fsXAttr struct {
 	_         [8]byte // fsXPad
 }
 
-func getProjectIDHash(id string) uint32 {
-	h := simd.Sum256([]byte(id))
-	return binary.LittleEndian.Uint32(h[:8])
-}
-
-func getQuota(device, volumeID string) (*Quota, error) {
+func getQuota(device string, projectID uint32) (*Quota, error) {
 	deviceNamePtr, err := syscall.BytePtrFromString(device)
 	if err != nil {
 		return nil, err
 	}
-	projectID := int(getProjectIDHash(volumeID))
 
 	result := &fsDiskQuota{}
 	_, _, errno := syscall.RawSyscall6(
@@ -117,13 +113,18 @@ func getQuota(device, volumeID string) (*Quota, error) {
 	}
 
 	return &Quota{
-		HardLimit:    result.hardLimitBlocks * blockSize,
-		SoftLimit:    result.softLimitBlocks * blockSize,
-		CurrentSpace: result.blocksCount * blockSize,
+		HardLimit:      result.hardLimitBlocks * blockSize,
+		SoftLimit:      result.softLimitBlocks * blockSize,
+		CurrentSpace:   result.blocksCount * blockSize,
+		HardInodeLimit: result.hardLimitInodes,
+		SoftInodeLimit: result.softLimitInodes,
+		CurrentInodes:  result.inodesCount,
 	}, nil
 }
 
-func setProjectID(path string, projectID uint32) error {
+// SetProjectID sets project ID on given directory and marks it to be inherited.
+// FS_IOC_FSSETXATTR is a generic ioctl, hence this works on EXT4 too.
+func SetProjectID(path string, projectID uint32) error {
 	targetDir, err := os.Open(path)
 	if err != nil {
 		return err
@@ -156,19 +157,7 @@ func setProjectID(path string, projectID uint32) error {
 	return nil
 }
 
-func setProjectQuota(device string, projectID uint32, quota Quota) error {
-	hardLimitBlocks := uint64(math.Ceil(float64(quota.HardLimit) / blockSize))
-	softLimitBlocks := uint64(math.Ceil(float64(quota.SoftLimit) / blockSize))
-
-	fsQuota := &fsDiskQuota{
-		version:         int8(fsDiskQuotaVersion),
-		flags:           int8(xfsProjectQuotaFlag),
-		fieldmask:       uint16(fieldMaskBHard | fieldMaskBSoft),
-		id:              uint32(projectID),
-		hardLimitBlocks: hardLimitBlocks,
-		softLimitBlocks: softLimitBlocks,
-	}
-
+func quotactlSetQuota(device string, projectID uint32, fsQuota *fsDiskQuota) error {
 	deviceNamePtr, err := syscall.BytePtrFromString(device)
 	if err != nil {
 		return err
@@ -190,14 +179,56 @@ func setProjectQuota(device string, projectID uint32, quota Quota) error {
 	return nil
 }
 
-func setQuota(device, path, volumeID string, quota Quota) error {
-	if info, err := getQuota(device, volumeID); err == nil {
-		klog.V(3).InfoS("Quota is already set", "Device", device, "Path", path, "VolumeID", volumeID, "ProjectID", "HardLimitSet", info.HardLimit, "HardLimit", info.HardLimit)
+func setProjectQuota(device string, projectID uint32, quota Quota) error {
+	hardLimitBlocks := uint64(math.Ceil(float64(quota.HardLimit) / blockSize))
+	softLimitBlocks := uint64(math.Ceil(float64(quota.SoftLimit) / blockSize))
+
+	return quotactlSetQuota(device, projectID, &fsDiskQuota{
+		version:         int8(fsDiskQuotaVersion),
+		flags:           int8(xfsProjectQuotaFlag),
+		fieldmask:       uint16(fieldMaskBHard | fieldMaskBSoft | fieldMaskIHard | fieldMaskISoft),
+		id:              uint32(projectID),
+		hardLimitBlocks: hardLimitBlocks,
+		softLimitBlocks: softLimitBlocks,
+		hardLimitInodes: quota.HardInodeLimit,
+		softLimitInodes: quota.SoftInodeLimit,
+	})
+}
+
+// setGracePeriods sets filesystem wide grace periods of project quota. Timers of ID
+// zero hold the grace periods applied to all projects.
+func setGracePeriods(device string, quota Quota) error {
+	fsQuota := &fsDiskQuota{
+		version: int8(fsDiskQuotaVersion),
+		flags:   int8(xfsProjectQuotaFlag),
+	}
+	if quota.BlockGracePeriod > 0 {
+		fsQuota.fieldmask |= fieldMaskBTimer
+		fsQuota.blocksTimer = int32(quota.BlockGracePeriod.Seconds())
+	}
+	if quota.InodeGracePeriod > 0 {
+		fsQuota.fieldmask |= fieldMaskITimer
+		fsQuota.inodeTimer = int32(quota.InodeGracePeriod.Seconds())
+	}
+	if fsQuota.fieldmask == 0 {
+		return nil
+	}
+
+	return quotactlSetQuota(device, 0, fsQuota)
+}
+
+func setQuota(device, path string, projectID uint32, quota Quota) error {
+	if err := setGracePeriods(device, quota); err != nil {
+		klog.ErrorS(err, "unable to set grace periods", "Device", device, "BlockGracePeriod", quota.BlockGracePeriod, "InodeGracePeriod", quota.InodeGracePeriod)
+		return err
+	}
+
+	if info, err := getQuota(device, projectID); err == nil && IsQuotaSet(info, quota) {
+		klog.V(3).InfoS("Quota is already set", "Device", device, "Path", path, "ProjectID", projectID, "HardLimitSet", info.HardLimit, "HardLimit", quota.HardLimit)
 		return nil
 	}
 
-	projectID := getProjectIDHash(volumeID)
-	if err := setProjectID(path, projectID); err != nil {
+	if err := SetProjectID(path, projectID); err != nil {
 		klog.ErrorS(err, "unable to set project ID", "Device", device, "Path", path)
 		return err
 	}
@@ -207,6 +238,6 @@ func setQuota(device, path, volumeID string, quota Quota) error {
 		return err
 	}
 
-	klog.V(3).InfoS("SetQuota succeeded", "Device", device, "Path", path, "VolumeID", volumeID, "ProjectID", projectID, "HardLimit", quota.HardLimit)
+	klog.V(3).InfoS("SetQuota succeeded", "Device", device, "Path", path, "ProjectID", projectID, "HardLimit", quota.HardLimit, "HardInodeLimit", quota.HardInodeLimit)
 	return nil
 }
@@ -23,10 +23,15 @@ import (
 	"runtime"
 )
 
-func getQuota(device, volumeID string) (*Quota, error) {
+func getQuota(device string, projectID uint32) (*Quota, error) {
 	return nil, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
 
-func setQuota(device, path, volumeID string, quota Quota) error {
+func setQuota(device, path string, projectID uint32, quota Quota) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// SetProjectID sets project ID on given directory and marks it to be inherited.
+func SetProjectID(path string, projectID uint32) error {
 	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
 }
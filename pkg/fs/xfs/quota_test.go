@@ -0,0 +1,87 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+package xfs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsQuotaSet(t *testing.T) {
+	testCases := []struct {
+		existing *Quota
+		quota    Quota
+		expected bool
+	}{
+		{nil, Quota{HardLimit: 100}, false},
+		{&Quota{HardLimit: 50}, Quota{HardLimit: 100}, false},
+		{&Quota{HardLimit: 100}, Quota{HardLimit: 100}, true},
+		{&Quota{HardLimit: 200}, Quota{HardLimit: 100}, true},
+		{&Quota{HardLimit: 100}, Quota{HardLimit: 100, HardInodeLimit: 10, SoftInodeLimit: 10}, false},
+		{&Quota{HardLimit: 100, HardInodeLimit: 10, SoftInodeLimit: 8}, Quota{HardLimit: 100, HardInodeLimit: 10, SoftInodeLimit: 8}, true},
+	}
+
+	for i, testCase := range testCases {
+		if result := IsQuotaSet(testCase.existing, testCase.quota); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestNewQuota(t *testing.T) {
+	testCases := []struct {
+		parameters    map[string]string
+		expectedQuota *Quota
+		expectErr     bool
+	}{
+		{nil, &Quota{HardLimit: 1000, SoftLimit: 1000}, false},
+		{
+			map[string]string{InodeLimitKey: "500", SoftLimitPercentageKey: "80", BlockGracePeriodKey: "1h", InodeGracePeriodKey: "30m"},
+			&Quota{
+				HardLimit:        1000,
+				SoftLimit:        800,
+				HardInodeLimit:   500,
+				SoftInodeLimit:   400,
+				BlockGracePeriod: time.Hour,
+				InodeGracePeriod: 30 * time.Minute,
+			},
+			false,
+		},
+		{map[string]string{InodeLimitKey: "0"}, nil, true},
+		{map[string]string{InodeLimitKey: "-1"}, nil, true},
+		{map[string]string{SoftLimitPercentageKey: "0"}, nil, true},
+		{map[string]string{SoftLimitPercentageKey: "101"}, nil, true},
+		{map[string]string{BlockGracePeriodKey: "1ms"}, nil, true},
+		{map[string]string{InodeGracePeriodKey: "invalid"}, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		quota, err := NewQuota(1000, testCase.parameters)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error, but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if *quota != *testCase.expectedQuota {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedQuota, quota)
+		}
+	}
+}
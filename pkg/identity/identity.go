@@ -72,9 +72,22 @@ func (i *identityServer) GetPluginCapabilities(ctx context.Context, req *csi.Get
 		}
 	}
 
+	volumeExpansionCap := func(cap csi.PluginCapability_VolumeExpansion_Type) *csi.PluginCapability {
+		klog.V(5).Infof("Using volume expansion capability %v", cap)
+
+		return &csi.PluginCapability{
+			Type: &csi.PluginCapability_VolumeExpansion_{
+				VolumeExpansion: &csi.PluginCapability_VolumeExpansion{
+					Type: cap,
+				},
+			},
+		}
+	}
+
 	caps := []*csi.PluginCapability{
 		serviceCap(csi.PluginCapability_Service_CONTROLLER_SERVICE),
 		serviceCap(csi.PluginCapability_Service_VOLUME_ACCESSIBILITY_CONSTRAINTS),
+		volumeExpansionCap(csi.PluginCapability_VolumeExpansion_ONLINE),
 	}
 
 	return &csi.GetPluginCapabilitiesResponse{
@@ -26,6 +26,12 @@ const (
 
 	// quay.io/minio/livenessprobe:v2.2.0-go1.17
 	CSIImageLivenessProbe = "livenessprobe@sha256:928a80be4d363e0e438ff28dcdb00d8d674d3059c6149a8cda64ce6016a9a3f8"
+
+	// quay.io/minio/csi-resizer:v1.2.0-go1.17
+	CSIImageCSIResizer = "csi-resizer:v1.2.0-go1.17"
+
+	// quay.io/minio/csi-snapshotter:v4.2.0-go1.17
+	CSIImageCSISnapshotter = "csi-snapshotter:v4.2.0-go1.17"
 )
 
 // Misc
@@ -73,12 +79,16 @@ const (
 	livenessProbeContainerName       = "liveness-probe"
 	nodeDriverRegistrarContainerName = "node-driver-registrar"
 	csiProvisionerContainerName      = "csi-provisioner"
+	csiResizerContainerName          = "csi-resizer"
+	csiSnapshotterContainerName      = "csi-snapshotter"
 
 	healthZContainerPortName = "healthz"
 	healthZContainerPortPath = "/healthz"
 
 	kubeNodeNameEnvVar = "KUBE_NODE_NAME"
 	endpointEnvVarCSI  = "CSI_ENDPOINT"
+	podNameEnvVar      = "POD_NAME"
+	podNamespaceEnvVar = "NAMESPACE"
 
 	kubeletDirPath = "/var/lib/kubelet"
 	csiRootPath    = "/var/lib/direct-csi/"
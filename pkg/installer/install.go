@@ -103,6 +103,10 @@ func CreateNamespace(ctx context.Context, identity string, dryRun bool, writer i
 func CreateCSIDriver(ctx context.Context, identity string, dryRun bool, writer io.Writer) error {
 	podInfoOnMount := true
 	attachRequired := false
+	storageCapacity := true
+	// Volumes are bind mounts of directories owned by root, hence kubelet applies fsGroup of the pod to them.
+	fsGroupPolicy := storagev1.FileFSGroupPolicy
+	fsGroupPolicyV1beta1 := storagev1beta1.FileFSGroupPolicy
 
 	gvk, err := utils.GetGroupKindVersions("storage.k8s.io", "CSIDriver", "v1", "v1beta1", "v1alpha1")
 	if err != nil {
@@ -119,8 +123,10 @@ func CreateCSIDriver(ctx context.Context, identity string, dryRun bool, writer i
 			},
 			ObjectMeta: objMeta(identity),
 			Spec: storagev1.CSIDriverSpec{
-				PodInfoOnMount: &podInfoOnMount,
-				AttachRequired: &attachRequired,
+				PodInfoOnMount:  &podInfoOnMount,
+				AttachRequired:  &attachRequired,
+				StorageCapacity: &storageCapacity,
+				FSGroupPolicy:   &fsGroupPolicy,
 				VolumeLifecycleModes: []storagev1.VolumeLifecycleMode{
 					storagev1.VolumeLifecyclePersistent,
 					storagev1.VolumeLifecycleEphemeral,
@@ -148,8 +154,10 @@ func CreateCSIDriver(ctx context.Context, identity string, dryRun bool, writer i
 			},
 			ObjectMeta: objMeta(identity),
 			Spec: storagev1beta1.CSIDriverSpec{
-				PodInfoOnMount: &podInfoOnMount,
-				AttachRequired: &attachRequired,
+				PodInfoOnMount:  &podInfoOnMount,
+				AttachRequired:  &attachRequired,
+				StorageCapacity: &storageCapacity,
+				FSGroupPolicy:   &fsGroupPolicyV1beta1,
 				VolumeLifecycleModes: []storagev1beta1.VolumeLifecycleMode{
 					storagev1beta1.VolumeLifecyclePersistent,
 					storagev1beta1.VolumeLifecycleEphemeral,
@@ -189,7 +197,7 @@ func getTopologySelectorTerm(identity string) corev1.TopologySelectorTerm {
 
 // CreateStorageClass creates storage class.
 func CreateStorageClass(ctx context.Context, identity string, dryRun bool, writer io.Writer) error {
-	allowExpansion := false
+	allowExpansion := true
 	allowedTopologies := []corev1.TopologySelectorTerm{
 		getTopologySelectorTerm(identity),
 	}
@@ -645,12 +653,33 @@ func CreateDeployment(ctx context.Context, identity string, directCSIContainerIm
 					"--leader-election",
 					"--feature-gates=Topology=true",
 					"--strict-topology",
+					"--enable-capacity",
+					"--capacity-ownerref-level=2",
+					"--extra-create-metadata",
 				},
 				Env: []corev1.EnvVar{
 					{
 						Name:  endpointEnvVarCSI,
 						Value: "unix:///csi/csi.sock",
 					},
+					{
+						Name: podNameEnvVar,
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								APIVersion: "v1",
+								FieldPath:  "metadata.name",
+							},
+						},
+					},
+					{
+						Name: podNamespaceEnvVar,
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								APIVersion: "v1",
+								FieldPath:  "metadata.namespace",
+							},
+						},
+					},
 				},
 				VolumeMounts: []corev1.VolumeMount{
 					newVolumeMount(volumeNameSocketDir, volumePathSocketDir, false, false),
@@ -674,6 +703,48 @@ func CreateDeployment(ctx context.Context, identity string, directCSIContainerIm
 					Privileged: &privileged,
 				},
 			},
+			{
+				Name:  csiResizerContainerName,
+				Image: filepath.Join(registry, org, CSIImageCSIResizer),
+				Args: []string{
+					fmt.Sprintf("--v=%d", logLevel),
+					"--timeout=300s",
+					fmt.Sprintf("--csi-address=$(%s)", endpointEnvVarCSI),
+					"--leader-election",
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  endpointEnvVarCSI,
+						Value: "unix:///csi/csi.sock",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					newVolumeMount(volumeNameSocketDir, volumePathSocketDir, false, false),
+				},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				TerminationMessagePath:   "/var/log/controller-resizer-termination-log",
+			},
+			{
+				Name:  csiSnapshotterContainerName,
+				Image: filepath.Join(registry, org, CSIImageCSISnapshotter),
+				Args: []string{
+					fmt.Sprintf("--v=%d", logLevel),
+					"--timeout=300s",
+					fmt.Sprintf("--csi-address=$(%s)", endpointEnvVarCSI),
+					"--leader-election",
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  endpointEnvVarCSI,
+						Value: "unix:///csi/csi.sock",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					newVolumeMount(volumeNameSocketDir, volumePathSocketDir, false, false),
+				},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				TerminationMessagePath:   "/var/log/controller-snapshotter-termination-log",
+			},
 			{
 				Name:  directCSIContainerName,
 				Image: filepath.Join(registry, org, directCSIContainerImage),
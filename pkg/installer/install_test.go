@@ -0,0 +1,79 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/direct-csi/pkg/matcher"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateDeploymentProvisioner(t *testing.T) {
+	utils.FakeInit()
+
+	identity := "direct.csi.min.io"
+	if err := CreateDeployment(context.TODO(), identity, "direct-csi:test", false, "quay.io", "minio", &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name := utils.SanitizeKubeResourceName(identity)
+	deployment, err := utils.GetKubeClient().AppsV1().Deployments(name).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get deployment: %v", err)
+	}
+
+	var provisioner *corev1.Container
+	for i, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == csiProvisionerContainerName {
+			provisioner = &deployment.Spec.Template.Spec.Containers[i]
+			break
+		}
+	}
+	if provisioner == nil {
+		t.Fatalf("container %v not found", csiProvisionerContainerName)
+	}
+
+	for _, arg := range []string{"--enable-capacity", "--capacity-ownerref-level=2", "--extra-create-metadata"} {
+		if !matcher.StringIn(provisioner.Args, arg) {
+			t.Errorf("argument %v not found in %v", arg, provisioner.Args)
+		}
+	}
+
+	fieldPaths := map[string]string{
+		podNameEnvVar:      "metadata.name",
+		podNamespaceEnvVar: "metadata.namespace",
+	}
+	for _, env := range provisioner.Env {
+		fieldPath, found := fieldPaths[env.Name]
+		if !found {
+			continue
+		}
+		if env.ValueFrom == nil || env.ValueFrom.FieldRef == nil || env.ValueFrom.FieldRef.FieldPath != fieldPath {
+			t.Errorf("env %v: expected field path %v, got %+v", env.Name, fieldPath, env.ValueFrom)
+		}
+		delete(fieldPaths, env.Name)
+	}
+	if len(fieldPaths) != 0 {
+		t.Errorf("env %v not found in %v", fieldPaths, provisioner.Env)
+	}
+}
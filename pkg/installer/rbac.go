@@ -116,6 +116,8 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 					clusterRoleVerbWatch,
 					clusterRoleVerbCreate,
 					clusterRoleVerbDelete,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
 				},
 				Resources: []string{
 					"persistentvolumes",
@@ -138,6 +140,18 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 					"",
 				},
 			},
+			{
+				Verbs: []string{
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
+				},
+				Resources: []string{
+					"persistentvolumeclaims/status",
+				},
+				APIGroups: []string{
+					"",
+				},
+			},
 			{
 				Verbs: []string{
 					clusterRoleVerbGet,
@@ -182,6 +196,9 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 				Verbs: []string{
 					clusterRoleVerbGet,
 					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
 				},
 				Resources: []string{
 					"volumesnapshotcontents",
@@ -190,12 +207,38 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 					"snapshot.storage.k8s.io",
 				},
 			},
+			{
+				Verbs: []string{
+					clusterRoleVerbUpdate,
+					clusterRoleVerbPatch,
+				},
+				Resources: []string{
+					"volumesnapshotcontents/status",
+				},
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+			},
 			{
 				Verbs: []string{
 					clusterRoleVerbGet,
 					clusterRoleVerbList,
 					clusterRoleVerbWatch,
 				},
+				Resources: []string{
+					"volumesnapshotclasses",
+				},
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+					clusterRoleVerbUpdate,
+				},
 				Resources: []string{
 					"csinodes",
 				},
@@ -203,6 +246,35 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 					"storage.k8s.io",
 				},
 			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+					clusterRoleVerbList,
+					clusterRoleVerbWatch,
+					clusterRoleVerbCreate,
+					clusterRoleVerbUpdate,
+					clusterRoleVerbDelete,
+					clusterRoleVerbPatch,
+				},
+				Resources: []string{
+					"csistoragecapacities",
+				},
+				APIGroups: []string{
+					"storage.k8s.io",
+				},
+			},
+			{
+				Verbs: []string{
+					clusterRoleVerbGet,
+				},
+				Resources: []string{
+					"replicasets",
+					"deployments",
+				},
+				APIGroups: []string{
+					"apps",
+				},
+			},
 			{
 				Verbs: []string{
 					clusterRoleVerbGet,
@@ -290,7 +362,7 @@ func createClusterRole(ctx context.Context, identity string, dryRun bool, writer
 					clusterRoleVerbDelete,
 				},
 				Resources: []string{
-					"directcsidrives", "directcsivolumes",
+					"directcsidrives", "directcsivolumes", "directcsisnapshots",
 				},
 				APIGroups: []string{
 					"direct.csi.min.io",
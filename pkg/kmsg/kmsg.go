@@ -0,0 +1,173 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmsg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecord denotes kernel log record is not in /dev/kmsg format.
+var ErrInvalidRecord = errors.New("invalid kmsg record")
+
+// Record is a kernel log record read from /dev/kmsg.
+type Record struct {
+	Facility  int
+	Priority  int
+	Sequence  uint64
+	Timestamp time.Duration
+	Message   string
+	Dict      map[string]string
+}
+
+// Parse parses a record in the format "<facility/priority>,<sequence>,<timestamp>,<flags>[,...];<message>"
+// followed by " KEY=VALUE" dictionary lines. Refer https://www.kernel.org/doc/Documentation/ABI/testing/dev-kmsg
+// to know about the format.
+func Parse(data []byte) (*Record, error) {
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	tokens := strings.SplitN(lines[0], ";", 2)
+	if len(tokens) != 2 {
+		return nil, fmt.Errorf("%w; message not found in %q", ErrInvalidRecord, lines[0])
+	}
+
+	fields := strings.Split(tokens[0], ",")
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("%w; unknown prefix %q", ErrInvalidRecord, tokens[0])
+	}
+
+	prefix, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid priority %q", ErrInvalidRecord, fields[0])
+	}
+	sequence, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid sequence %q", ErrInvalidRecord, fields[1])
+	}
+	timestamp, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid timestamp %q", ErrInvalidRecord, fields[2])
+	}
+
+	record := &Record{
+		Facility:  prefix >> 3,
+		Priority:  prefix & 7,
+		Sequence:  sequence,
+		Timestamp: time.Duration(timestamp) * time.Microsecond,
+		Message:   tokens[1],
+		Dict:      map[string]string{},
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, " ") {
+			continue
+		}
+		if kv := strings.SplitN(line[1:], "=", 2); len(kv) == 2 {
+			record.Dict[kv[0]] = kv[1]
+		}
+	}
+
+	return record, nil
+}
+
+// ErrorType denotes type of device error reported by kernel.
+type ErrorType string
+
+const (
+	// IOError denotes I/O error reported by block layer.
+	IOError ErrorType = "IOError"
+
+	// FilesystemError denotes error reported by filesystem.
+	FilesystemError ErrorType = "FilesystemError"
+
+	// FilesystemShutdown denotes filesystem shut down or remounted read-only on error.
+	FilesystemShutdown ErrorType = "FilesystemShutdown"
+)
+
+// DeviceError is a device error reported in kernel log.
+type DeviceError struct {
+	// Device is kernel name of the device e.g. sda, sda1, nvme0n1p1 or dm-0.
+	Device string
+	// MajorMinor is major:minor number of the device if available in record dictionary.
+	MajorMinor string
+	Type       ErrorType
+	Message    string
+}
+
+var (
+	blockErrorRegexp     = regexp.MustCompile(`error, dev ([^,\s]+), sector`)
+	bufferErrorRegexp    = regexp.MustCompile(`^Buffer I/O error on dev ([^,\s]+),`)
+	xfsRegexp            = regexp.MustCompile(`^XFS \(([^)]+)\): (.*)$`)
+	xfsShutdownRegexp    = regexp.MustCompile(`(?i)shutting down filesystem|filesystem has been shut down|force_shutdown`)
+	xfsErrorRegexp       = regexp.MustCompile(`(?i)i/o error|corruption`)
+	ext4ErrorRegexp      = regexp.MustCompile(`^EXT4-fs error \(device ([^)]+)\)`)
+	ext4Regexp           = regexp.MustCompile(`^EXT4-fs \(([^)]+)\): (.*)$`)
+	ext4ReadOnlyRegexp   = regexp.MustCompile(`(?i)remounting filesystem read-only`)
+	ext4IOErrorRegexp    = regexp.MustCompile(`(?i)i/o error`)
+	blockDeviceDictRegex = regexp.MustCompile(`^b(\d+:\d+)$`)
+)
+
+// ParseDeviceError returns device error in the record, or nil if the record does not report
+// a device error.
+func ParseDeviceError(record *Record) *DeviceError {
+	newDeviceError := func(device string, errorType ErrorType) *DeviceError {
+		deviceError := &DeviceError{
+			Device:  device,
+			Type:    errorType,
+			Message: record.Message,
+		}
+		if matches := blockDeviceDictRegex.FindStringSubmatch(record.Dict["DEVICE"]); matches != nil {
+			deviceError.MajorMinor = matches[1]
+		}
+		return deviceError
+	}
+
+	message := record.Message
+	if matches := bufferErrorRegexp.FindStringSubmatch(message); matches != nil {
+		return newDeviceError(matches[1], IOError)
+	}
+	if matches := blockErrorRegexp.FindStringSubmatch(message); matches != nil {
+		return newDeviceError(matches[1], IOError)
+	}
+
+	if matches := xfsRegexp.FindStringSubmatch(message); matches != nil {
+		switch {
+		case xfsShutdownRegexp.MatchString(matches[2]):
+			return newDeviceError(matches[1], FilesystemShutdown)
+		case xfsErrorRegexp.MatchString(matches[2]):
+			return newDeviceError(matches[1], FilesystemError)
+		}
+		return nil
+	}
+
+	if matches := ext4ErrorRegexp.FindStringSubmatch(message); matches != nil {
+		return newDeviceError(matches[1], FilesystemError)
+	}
+	if matches := ext4Regexp.FindStringSubmatch(message); matches != nil {
+		switch {
+		case ext4ReadOnlyRegexp.MatchString(matches[2]):
+			return newDeviceError(matches[1], FilesystemShutdown)
+		case ext4IOErrorRegexp.MatchString(matches[2]):
+			return newDeviceError(matches[1], FilesystemError)
+		}
+	}
+
+	return nil
+}
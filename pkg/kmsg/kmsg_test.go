@@ -0,0 +1,116 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmsg
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readRecords returns records in the file where dictionary lines follow their record.
+func readRecords(t *testing.T, filename string) [][]byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read %v; %v", filename, err)
+	}
+
+	var records [][]byte
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if strings.HasPrefix(line, " ") && len(records) > 0 {
+			records[len(records)-1] = append(records[len(records)-1], []byte(line+"\n")...)
+			continue
+		}
+		records = append(records, []byte(line+"\n"))
+	}
+	return records
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedRecord *Record
+		expectErr      bool
+	}{
+		{"", nil, true},
+		{"6,1234,5123456,-\n", nil, true},
+		{"6,1234;message\n", nil, true},
+		{"x,1234,5123456,-;message\n", nil, true},
+		{
+			"6,1234,5123456,-;message\n",
+			&Record{Facility: 0, Priority: 6, Sequence: 1234, Timestamp: 5123456 * time.Microsecond, Message: "message", Dict: map[string]string{}},
+			false,
+		},
+		{
+			"27,1235,100,c,extra;disk error\n SUBSYSTEM=block\n DEVICE=b8:0\n",
+			&Record{Facility: 3, Priority: 3, Sequence: 1235, Timestamp: 100 * time.Microsecond, Message: "disk error", Dict: map[string]string{"SUBSYSTEM": "block", "DEVICE": "b8:0"}},
+			false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		record, err := Parse([]byte(testCase.data))
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error; but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(record, testCase.expectedRecord) {
+			t.Fatalf("case %v: expected: %+v; got: %+v", i+1, testCase.expectedRecord, record)
+		}
+	}
+}
+
+func TestParseDeviceError(t *testing.T) {
+	expectedErrors := []DeviceError{
+		{Device: "sda", Type: IOError},
+		{Device: "sda1", Type: IOError},
+		{Device: "sdb", Type: FilesystemError},
+		{Device: "sdb", Type: FilesystemShutdown},
+		{Device: "nvme0n1p1", Type: FilesystemError},
+		{Device: "nvme0n1p1", Type: FilesystemShutdown},
+		{Device: "nvme1n1", MajorMinor: "259:1", Type: IOError},
+		{Device: "dm-0", Type: FilesystemShutdown},
+		{Device: "sdd", Type: FilesystemShutdown},
+		{Device: "sde", Type: FilesystemError},
+	}
+
+	var deviceErrors []DeviceError
+	for _, data := range readRecords(t, "kmsg.testdata") {
+		record, err := Parse(data)
+		if err != nil {
+			t.Fatalf("unable to parse %q; %v", data, err)
+		}
+		if deviceError := ParseDeviceError(record); deviceError != nil {
+			if deviceError.Message != record.Message {
+				t.Fatalf("message: expected: %v, got: %v", record.Message, deviceError.Message)
+			}
+			deviceError.Message = ""
+			deviceErrors = append(deviceErrors, *deviceError)
+		}
+	}
+
+	if !reflect.DeepEqual(deviceErrors, expectedErrors) {
+		t.Fatalf("expected: %+v; got: %+v", expectedErrors, deviceErrors)
+	}
+}
@@ -0,0 +1,75 @@
+//go:build linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmsg
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+const (
+	devKmsg = "/dev/kmsg"
+
+	// maxRecordSize is the maximum size of a record returned by /dev/kmsg.
+	maxRecordSize = 8192
+)
+
+// Reader reads kernel log records from /dev/kmsg.
+type Reader struct {
+	file *os.File
+	buf  []byte
+}
+
+// Open opens /dev/kmsg to read records logged from now on.
+func Open() (*Reader, error) {
+	file, err := os.Open(devKmsg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records logged before are already accounted by earlier readers.
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Reader{file: file, buf: make([]byte, maxRecordSize)}, nil
+}
+
+// Read blocks until next record is available and returns it.
+func (reader *Reader) Read() (*Record, error) {
+	for {
+		n, err := reader.file.Read(reader.buf)
+		if err != nil {
+			// Records overwritten in the ring buffer before reading are skipped.
+			if errors.Is(err, syscall.EPIPE) {
+				continue
+			}
+			return nil, err
+		}
+		return Parse(reader.buf[:n])
+	}
+}
+
+// Close closes the reader.
+func (reader *Reader) Close() error {
+	return reader.file.Close()
+}
@@ -0,0 +1,42 @@
+//go:build !linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmsg
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Reader reads kernel log records.
+type Reader struct{}
+
+// Open is unsupported on this operating system.
+func Open() (*Reader, error) {
+	return nil, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// Read is unsupported on this operating system.
+func (reader *Reader) Read() (*Record, error) {
+	return nil, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// Close is unsupported on this operating system.
+func (reader *Reader) Close() error {
+	return nil
+}
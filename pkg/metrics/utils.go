@@ -20,6 +20,7 @@ import (
 	"context"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/fs/ext4"
 	"github.com/minio/direct-csi/pkg/fs/xfs"
 	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
@@ -50,7 +51,14 @@ func (c *metricsCollector) getxfsVolumeStats(ctx context.Context, vol *directcsi
 	if err != nil {
 		return xfsVolumeStats{}, err
 	}
-	quota, err := xfs.GetQuota(ctx, sys.GetDirectCSIPath(drive.Status.FilesystemUUID), vol.Name)
+	var quota *xfs.Quota
+	device := sys.GetDirectCSIPath(drive.Status.FilesystemUUID)
+	switch sys.FSType(drive.Status.Filesystem) {
+	case sys.FSTypeExt4:
+		quota, err = ext4.GetQuota(ctx, device, utils.GetProjectID(vol))
+	default:
+		quota, err = xfs.GetQuota(ctx, device, utils.GetProjectID(vol))
+	}
 	if err != nil {
 		return xfsVolumeStats{}, err
 	}
@@ -64,7 +72,7 @@ func (c *metricsCollector) getxfsVolumeStats(ctx context.Context, vol *directcsi
 func publishVolumeStats(ctx context.Context, vol *directcsi.DirectCSIVolume, ch chan<- prometheus.Metric, xfsStatsFn xfsVolumeStatsGetter) {
 	volStats, err := xfsStatsFn(ctx, vol)
 	if err != nil {
-		klog.V(3).Infof("Error while getting xfs volume stats: %v", err)
+		klog.V(3).Infof("Error while getting volume stats: %v", err)
 		return
 	}
 
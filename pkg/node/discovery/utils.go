@@ -37,6 +37,11 @@ func (d *Discovery) getMountInfo(major, minor uint32) []sys.MountInfo {
 }
 
 func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) error {
+	// Block mode drive is not mounted.
+	if existingDrive.Status.BlockMode {
+		return nil
+	}
+
 	driveMounter := &sys.DefaultDriveMounter{}
 	switch existingDrive.Status.DriveStatus {
 	case directcsi.DriveStatusInUse, directcsi.DriveStatusReady:
@@ -61,7 +66,7 @@ func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) er
 
 		// Mount if umounted
 		if !mounted {
-			if err := driveMounter.MountDrive(mountSource, mountTarget, []string{}); err != nil {
+			if err := driveMounter.MountDrive(mountSource, mountTarget, sys.FSType(existingDrive.Status.Filesystem), []string{}); err != nil {
 				return err
 			}
 			existingDrive.Status.Mountpoint = mountTarget
@@ -104,8 +109,8 @@ func syncDriveStatesOnDiscovery(existingObj *directcsi.DirectCSIDrive, localDriv
 	}
 	// Capacity sync
 	allocatedCapacity := localDrive.Status.AllocatedCapacity
-	if existingObj.Status.DriveStatus == directcsi.DriveStatusInUse {
-		// size reserved for allocated volumes
+	if existingObj.Status.DriveStatus == directcsi.DriveStatusInUse || existingObj.Status.BlockMode {
+		// size reserved for allocated volumes or partition table of block mode drive
 		allocatedCapacity = existingObj.Status.AllocatedCapacity
 	}
 	existingObj.Status.FreeCapacity = localDrive.Status.TotalCapacity - allocatedCapacity
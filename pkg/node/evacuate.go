@@ -0,0 +1,528 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const driveEvacuationInterval = time.Minute
+
+var errVolumeInUse = errors.New("volume is in use")
+
+// isVolumeEvacuating returns whether the volume is being copied to another drive.
+func isVolumeEvacuating(volume *directcsi.DirectCSIVolume) bool {
+	for _, condition := range volume.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSIVolumeConditionEvacuated) {
+			return condition.Status == metav1.ConditionFalse && condition.Reason == string(directcsi.DirectCSIVolumeReasonEvacuating)
+		}
+	}
+	return false
+}
+
+// isVolumeInUse returns whether the volume is staged or published.
+func isVolumeInUse(volume *directcsi.DirectCSIVolume) bool {
+	return volume.Status.StagingPath != "" || volume.Status.ContainerPath != ""
+}
+
+// isEvacuationTarget returns whether the volume of given size can be moved to the drive.
+func isEvacuationTarget(drive *directcsi.DirectCSIDrive, size int64) bool {
+	if drive.Status.BlockMode || drive.Status.Mountpoint == "" || drive.Spec.Evacuate {
+		return false
+	}
+	if drive.Status.FreeCapacity < size {
+		return false
+	}
+	// getDriveMaxVolumes accounts drive state, cordon and health.
+	return int64(len(utils.GetDriveVolumes(drive))) < getDriveMaxVolumes(drive)
+}
+
+// selectEvacuationTarget returns the drive to move the volume of the source drive to; drives of the
+// same access tier are preferred, then drives having more free capacity. Drives holding another volume
+// of the volume group are skipped.
+func selectEvacuationTarget(drives []directcsi.DirectCSIDrive, source *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume, groupDrives map[string]struct{}) *directcsi.DirectCSIDrive {
+	var target *directcsi.DirectCSIDrive
+	for i := range drives {
+		drive := &drives[i]
+		if drive.Name == source.Name || !isEvacuationTarget(drive, volume.Status.TotalCapacity) {
+			continue
+		}
+		if _, found := groupDrives[drive.Name]; found {
+			continue
+		}
+
+		if target != nil {
+			sameTier := drive.Status.AccessTier == source.Status.AccessTier
+			targetSameTier := target.Status.AccessTier == source.Status.AccessTier
+			if sameTier != targetSameTier {
+				if !sameTier {
+					continue
+				}
+			} else if drive.Status.FreeCapacity <= target.Status.FreeCapacity {
+				continue
+			}
+		}
+		target = drive
+	}
+	return target
+}
+
+// getGroupDrives returns the drives holding a volume of the volume group other than the volume.
+func getGroupDrives(volumes []directcsi.DirectCSIVolume, volume *directcsi.DirectCSIVolume) map[string]struct{} {
+	groupDrives := map[string]struct{}{}
+	group := volume.GetLabels()[utils.VolumeGroupLabel]
+	if group == "" {
+		return groupDrives
+	}
+	for _, other := range volumes {
+		if other.Name != volume.Name && other.GetLabels()[utils.VolumeGroupLabel] == group {
+			groupDrives[other.Status.Drive] = struct{}{}
+		}
+	}
+	return groupDrives
+}
+
+// setEvacuationCondition sets Evacuated condition of the volume; the volume must not be in use.
+func (n *NodeServer) setEvacuationCondition(ctx context.Context, volumeName string, reason directcsi.DirectCSIVolumeReason, message string) error {
+	volumeClient := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := volumeClient.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if reason == directcsi.DirectCSIVolumeReasonEvacuating && isVolumeInUse(volume) {
+			return errVolumeInUse
+		}
+
+		volume.Status.Conditions = utils.SetCondition(
+			volume.Status.Conditions,
+			string(directcsi.DirectCSIVolumeConditionEvacuated),
+			utils.BoolToCondition(reason == directcsi.DirectCSIVolumeReasonEvacuated),
+			string(reason),
+			message,
+		)
+		_, err = volumeClient.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+}
+
+// reserveEvacuationTarget adds the volume finalizer and capacity of the volume to the target drive.
+func (n *NodeServer) reserveEvacuationTarget(ctx context.Context, driveName string, volume *directcsi.DirectCSIVolume) error {
+	driveClient := n.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	finalizer := directcsi.DirectCSIDriveFinalizerPrefix + volume.Name
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveClient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		for _, f := range drive.GetFinalizers() {
+			if f == finalizer {
+				return nil
+			}
+		}
+		if !isEvacuationTarget(drive, volume.Status.TotalCapacity) {
+			return fmt.Errorf("drive %v cannot hold volume %v anymore", drive.Name, volume.Name)
+		}
+
+		drive.Status.FreeCapacity -= volume.Status.TotalCapacity
+		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+		drive.Status.DriveStatus = directcsi.DriveStatusInUse
+		drive.SetFinalizers(append(drive.GetFinalizers(), finalizer))
+		_, err = driveClient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+}
+
+// releaseEvacuatedDrive removes the volume finalizer and capacity of the volume from the drive.
+func (n *NodeServer) releaseEvacuatedDrive(ctx context.Context, driveName string, volume *directcsi.DirectCSIVolume) error {
+	driveClient := n.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveClient.Get(ctx, driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			return err
+		}
+
+		finalizers, found := utils.ExcludeFinalizer(drive.GetFinalizers(), directcsi.DirectCSIDriveFinalizerPrefix+volume.Name)
+		if !found {
+			return nil
+		}
+		if len(finalizers) == 1 && finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+			drive.Status.DriveStatus = directcsi.DriveStatusReady
+		}
+
+		drive.SetFinalizers(finalizers)
+		drive.Status.FreeCapacity += volume.Status.TotalCapacity
+		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+		_, err = driveClient.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		return err
+	})
+}
+
+// moveVolume re-points the volume to the target drive; the volume must not have been staged meanwhile.
+func (n *NodeServer) moveVolume(ctx context.Context, volumeName string, source, target *directcsi.DirectCSIDrive, hostPath string, projectID uint32) error {
+	volumeClient := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		volume, err := volumeClient.Get(ctx, volumeName, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			return err
+		}
+		if isVolumeInUse(volume) {
+			return errVolumeInUse
+		}
+
+		volume.Status.Drive = target.Name
+		volume.Status.HostPath = hostPath
+		volume.Status.ProjectID = int64(projectID)
+		labels := volume.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[utils.DriveLabel] = utils.SanitizeLabelV(target.Name)
+		labels[utils.ReservedDrivePathLabel] = utils.SanitizeDrivePath(target.Status.Path)
+		volume.SetLabels(labels)
+		volume.Status.Conditions = utils.SetCondition(
+			volume.Status.Conditions,
+			string(directcsi.DirectCSIVolumeConditionEvacuated),
+			metav1.ConditionTrue,
+			string(directcsi.DirectCSIVolumeReasonEvacuated),
+			fmt.Sprintf("evacuated from drive %v", source.Name),
+		)
+		_, err = volumeClient.Update(ctx, volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		return err
+	})
+}
+
+// copyVolume copies content of the volume to the target drive under a new project quota and returns
+// the host path and project ID of the copy.
+func (n *NodeServer) copyVolume(ctx context.Context, volume *directcsi.DirectCSIVolume, source, target *directcsi.DirectCSIDrive) (string, uint32, error) {
+	sourcePath := volume.Status.HostPath
+	if sourcePath == "" {
+		sourcePath = filepath.Join(source.Status.Mountpoint, volume.Name)
+	}
+	targetPath := filepath.Join(target.Status.Mountpoint, volume.Name)
+
+	// Remove leftover of earlier failed attempt.
+	if err := os.RemoveAll(targetPath); err != nil {
+		return "", 0, err
+	}
+	if err := os.MkdirAll(targetPath, 0755); err != nil {
+		return "", 0, err
+	}
+
+	// Quota is set before copying so that copied files are accounted in the project.
+	existing, err := n.quotaFuncs.GetQuota(ctx, source.Status.Filesystem, sys.GetDirectCSIPath(source.Status.FilesystemUUID), utils.GetProjectID(volume))
+	if err != nil {
+		klog.V(3).InfoS("unable to read quota of volume; inode limits are not carried over", "volume", volume.Name, "err", err)
+		existing = nil
+	}
+	quota := expandQuota(existing, uint64(volume.Status.TotalCapacity))
+
+	n.projectIDMutex.Lock()
+	projectID, err := n.allocateProjectID(ctx, volume, target)
+	if err == nil {
+		err = n.quotaFuncs.SetQuota(ctx, target.Status.Filesystem, sys.GetDirectCSIPath(target.Status.FilesystemUUID), targetPath, projectID, quota)
+	}
+	n.projectIDMutex.Unlock()
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err := n.copyDir(ctx, sourcePath, targetPath); err != nil {
+		return "", 0, err
+	}
+	return targetPath, projectID, nil
+}
+
+// evacuateVolume copies the volume to the target drive and moves the volume, its drive finalizer and
+// capacity from the source drive to the target drive.
+func (n *NodeServer) evacuateVolume(ctx context.Context, volume *directcsi.DirectCSIVolume, source, target *directcsi.DirectCSIDrive) error {
+	// Stage holds the read lock of mount mutex for its whole run; holding the write lock here ensures
+	// the volume is not staged between in-use check and setting Evacuating condition.
+	message := fmt.Sprintf("copying to drive %v", target.Name)
+	n.mountMutex.Lock()
+	err := n.setEvacuationCondition(ctx, volume.Name, directcsi.DirectCSIVolumeReasonEvacuating, message)
+	n.mountMutex.Unlock()
+	if err != nil {
+		return err
+	}
+	klog.V(3).InfoS("evacuating volume", "volume", volume.Name, "source", source.Name, "target", target.Name)
+
+	err = n.reserveEvacuationTarget(ctx, target.Name, volume)
+	var hostPath string
+	var projectID uint32
+	if err == nil {
+		if hostPath, projectID, err = n.copyVolume(ctx, volume, source, target); err == nil {
+			n.mountMutex.Lock()
+			err = n.moveVolume(ctx, volume.Name, source, target, hostPath, projectID)
+			n.mountMutex.Unlock()
+		}
+		if err != nil {
+			if rErr := n.releaseEvacuatedDrive(ctx, target.Name, volume); rErr != nil {
+				klog.ErrorS(rErr, "unable to release evacuation target", "volume", volume.Name, "drive", target.Name)
+			}
+			if rErr := os.RemoveAll(filepath.Join(target.Status.Mountpoint, volume.Name)); rErr != nil {
+				klog.ErrorS(rErr, "unable to remove copy of volume", "volume", volume.Name, "drive", target.Name)
+			}
+		}
+	}
+	if err != nil {
+		utils.Eventf(volume, corev1.EventTypeWarning, string(directcsi.DirectCSIVolumeReasonEvacuationFailed), "unable to evacuate volume from drive %v; %v", source.Name, err)
+		if cErr := n.setEvacuationCondition(ctx, volume.Name, directcsi.DirectCSIVolumeReasonEvacuationFailed, err.Error()); cErr != nil {
+			klog.ErrorS(cErr, "unable to set evacuation condition", "volume", volume.Name)
+		}
+		return err
+	}
+
+	if err := n.releaseEvacuatedDrive(ctx, source.Name, volume); err != nil {
+		return err
+	}
+	sourcePath := volume.Status.HostPath
+	if sourcePath == "" {
+		sourcePath = filepath.Join(source.Status.Mountpoint, volume.Name)
+	}
+	if err := os.RemoveAll(sourcePath); err != nil {
+		klog.ErrorS(err, "unable to remove evacuated volume directory", "volume", volume.Name, "path", sourcePath)
+	}
+
+	utils.Eventf(volume, corev1.EventTypeNormal, string(directcsi.DirectCSIVolumeReasonEvacuated), "volume is evacuated from drive %v to drive %v", source.Name, target.Name)
+	return nil
+}
+
+// evacuateDrive moves volumes not in use off the drive; it returns the number of volumes left on the drive.
+func (n *NodeServer) evacuateDrive(ctx context.Context, drive *directcsi.DirectCSIDrive, drives []directcsi.DirectCSIDrive, volumes []directcsi.DirectCSIVolume) int {
+	volumeMap := map[string]*directcsi.DirectCSIVolume{}
+	for i := range volumes {
+		volumeMap[volumes[i].Name] = &volumes[i]
+	}
+
+	left := 0
+	for _, name := range utils.GetDriveVolumes(drive) {
+		volume, found := volumeMap[name]
+		switch {
+		case !found:
+			klog.V(3).InfoS("volume of evacuating drive not found", "drive", drive.Name, "volume", name)
+		case volume.Status.BlockMode:
+			klog.V(3).InfoS("block volume cannot be evacuated", "drive", drive.Name, "volume", name)
+		case isVolumeInUse(volume):
+			klog.V(3).InfoS("waiting for volume to be unstaged to evacuate", "drive", drive.Name, "volume", name)
+		default:
+			target := selectEvacuationTarget(drives, drive, volume, getGroupDrives(volumes, volume))
+			if target == nil {
+				klog.V(3).InfoS("no drive found to evacuate volume to", "drive", drive.Name, "volume", name)
+				break
+			}
+			if err := n.evacuateVolume(ctx, volume, drive, target); err != nil {
+				if !errors.Is(err, errVolumeInUse) {
+					klog.ErrorS(err, "unable to evacuate volume", "drive", drive.Name, "volume", name)
+				}
+				break
+			}
+			// Account the moved volume on the target for next volumes.
+			target.Status.FreeCapacity -= volume.Status.TotalCapacity
+			target.SetFinalizers(append(target.GetFinalizers(), directcsi.DirectCSIDriveFinalizerPrefix+name))
+			volume.Status.Drive = target.Name
+			continue
+		}
+		left++
+	}
+	return left
+}
+
+// evacuateDrives moves volumes off the drives of the node requested to be evacuated.
+func (n *NodeServer) evacuateDrives(ctx context.Context) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(n.NodeID)
+	if err != nil {
+		return err
+	}
+
+	driveInterface := n.directcsiClient.DirectV1beta3().DirectCSIDrives()
+	driveCh, err := utils.ListDrives(ctx, driveInterface, []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+	var drives []directcsi.DirectCSIDrive
+	evacuate := false
+	for result := range driveCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		drives = append(drives, result.Drive)
+		evacuate = evacuate || result.Drive.Spec.Evacuate
+	}
+	if !evacuate {
+		return nil
+	}
+
+	volumeCh, err := utils.ListVolumes(ctx, n.directcsiClient.DirectV1beta3().DirectCSIVolumes(), []utils.LabelValue{nodeLabelValue}, nil, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+	var volumes []directcsi.DirectCSIVolume
+	for result := range volumeCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		volumes = append(volumes, result.Volume)
+	}
+
+	for i := range drives {
+		drive := &drives[i]
+		if !drive.Spec.Evacuate {
+			continue
+		}
+		if left := n.evacuateDrive(ctx, drive, drives, volumes); left > 0 {
+			klog.V(3).InfoS("volumes are left on evacuating drive", "drive", drive.Name, "volumes", left)
+			continue
+		}
+
+		evacuated := false
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest, err := driveInterface.Get(ctx, drive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			if len(utils.GetDriveVolumes(latest)) != 0 {
+				return nil
+			}
+			latest.Spec.Evacuate = false
+			_, err = driveInterface.Update(ctx, latest, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			evacuated = err == nil
+			return err
+		})
+		if err != nil {
+			klog.ErrorS(err, "unable to complete drive evacuation", "drive", drive.Name)
+			continue
+		}
+		if !evacuated {
+			continue
+		}
+		klog.V(3).InfoS("drive is evacuated", "drive", drive.Name)
+		utils.Eventf(drive, corev1.EventTypeNormal, "Evacuated", "all volumes are moved off the drive")
+	}
+	return nil
+}
+
+// clearStaleEvacuations fails evacuations interrupted by node server restart; reservation and copy of the
+// volume on other drives are released so that the volume can be staged and evacuated again.
+func (n *NodeServer) clearStaleEvacuations(ctx context.Context) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(n.NodeID)
+	if err != nil {
+		return err
+	}
+
+	volumeCh, err := utils.ListVolumes(ctx, n.directcsiClient.DirectV1beta3().DirectCSIVolumes(), []utils.LabelValue{nodeLabelValue}, nil, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+	var volumes []directcsi.DirectCSIVolume
+	for result := range volumeCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		if isVolumeEvacuating(&result.Volume) {
+			volumes = append(volumes, result.Volume)
+		}
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	driveCh, err := utils.ListDrives(ctx, n.directcsiClient.DirectV1beta3().DirectCSIDrives(), []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+	var drives []directcsi.DirectCSIDrive
+	for result := range driveCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		drives = append(drives, result.Drive)
+	}
+
+	for i := range volumes {
+		volume := &volumes[i]
+		for j := range drives {
+			drive := &drives[j]
+			if drive.Name == volume.Status.Drive {
+				continue
+			}
+			for _, name := range utils.GetDriveVolumes(drive) {
+				if name != volume.Name {
+					continue
+				}
+				if err := n.releaseEvacuatedDrive(ctx, drive.Name, volume); err != nil {
+					return err
+				}
+				if drive.Status.Mountpoint != "" {
+					if err := os.RemoveAll(filepath.Join(drive.Status.Mountpoint, volume.Name)); err != nil {
+						klog.ErrorS(err, "unable to remove copy of volume", "volume", volume.Name, "drive", drive.Name)
+					}
+				}
+				break
+			}
+		}
+
+		if err := n.setEvacuationCondition(ctx, volume.Name, directcsi.DirectCSIVolumeReasonEvacuationFailed, "evacuation is interrupted by node server restart"); err != nil {
+			return err
+		}
+		klog.V(3).InfoS("cleared stale evacuation of volume", "volume", volume.Name)
+	}
+	return nil
+}
+
+// startDriveEvacuator periodically moves volumes off the drives requested to be evacuated.
+func (n *NodeServer) startDriveEvacuator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if err := n.clearStaleEvacuations(ctx); err != nil {
+		klog.ErrorS(err, "unable to clear stale volume evacuations")
+	}
+
+	for {
+		if err := n.evacuateDrives(ctx); err != nil {
+			klog.ErrorS(err, "unable to evacuate drives")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEvacuationTestDrive(name string, freeCapacity int64, volumes ...string) *directcsi.DirectCSIDrive {
+	finalizers := []string{directcsi.DirectCSIDriveFinalizerDataProtection}
+	driveStatus := directcsi.DriveStatusReady
+	for _, volume := range volumes {
+		finalizers = append(finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume)
+		driveStatus = directcsi.DriveStatusInUse
+	}
+	return &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Labels:     map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+			Finalizers: finalizers,
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:          testNodeName,
+			Path:              "/dev/" + name,
+			DriveStatus:       driveStatus,
+			Filesystem:        "xfs",
+			FilesystemUUID:    name,
+			Mountpoint:        "/var/lib/direct-csi/mnt/" + name,
+			TotalCapacity:     100,
+			FreeCapacity:      freeCapacity,
+			AllocatedCapacity: 100 - freeCapacity,
+			AccessTier:        directcsi.AccessTierUnknown,
+		},
+	}
+}
+
+func newEvacuationTestVolume(name, drive string, capacity int64) *directcsi.DirectCSIVolume {
+	return &directcsi.DirectCSIVolume{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+		},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         drive,
+			TotalCapacity: capacity,
+		},
+	}
+}
+
+func TestSelectEvacuationTarget(t *testing.T) {
+	source := newEvacuationTestDrive("source", 50, "volume")
+	source.Status.AccessTier = directcsi.AccessTierHot
+	volume := newEvacuationTestVolume("volume", "source", 20)
+
+	cordoned := newEvacuationTestDrive("cordoned", 90)
+	cordoned.Spec.Unschedulable = true
+	unhealthy := newEvacuationTestDrive("unhealthy", 90)
+	unhealthy.Status.Conditions = []metav1.Condition{{Type: string(directcsi.DirectCSIDriveConditionHealthy), Status: metav1.ConditionFalse}}
+	evacuating := newEvacuationTestDrive("evacuating", 90)
+	evacuating.Spec.Evacuate = true
+	unmounted := newEvacuationTestDrive("unmounted", 90)
+	unmounted.Status.Mountpoint = ""
+	full := newEvacuationTestDrive("full", 90, "volume-1")
+	full.Spec.MaxVolumes = 1
+	small := newEvacuationTestDrive("small", 10)
+	hot := newEvacuationTestDrive("hot", 30)
+	hot.Status.AccessTier = directcsi.AccessTierHot
+	large := newEvacuationTestDrive("large", 80)
+
+	testCases := []struct {
+		drives         []*directcsi.DirectCSIDrive
+		groupDrives    map[string]struct{}
+		expectedTarget string
+	}{
+		{[]*directcsi.DirectCSIDrive{source}, nil, ""},
+		{[]*directcsi.DirectCSIDrive{source, cordoned, unhealthy, evacuating, unmounted, full, small}, nil, ""},
+		{[]*directcsi.DirectCSIDrive{source, small, large}, nil, "large"},
+		// same access tier is preferred over free capacity
+		{[]*directcsi.DirectCSIDrive{source, large, hot}, nil, "hot"},
+		// drive holding a volume of the group is skipped
+		{[]*directcsi.DirectCSIDrive{source, large, hot}, map[string]struct{}{"hot": {}}, "large"},
+	}
+
+	for i, testCase := range testCases {
+		var drives []directcsi.DirectCSIDrive
+		for _, drive := range testCase.drives {
+			drives = append(drives, *drive)
+		}
+		target := selectEvacuationTarget(drives, source, volume, testCase.groupDrives)
+		name := ""
+		if target != nil {
+			name = target.Name
+		}
+		if name != testCase.expectedTarget {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedTarget, name)
+		}
+	}
+}
+
+func TestGetGroupDrives(t *testing.T) {
+	newGroupVolume := func(name, drive, group string) directcsi.DirectCSIVolume {
+		volume := newEvacuationTestVolume(name, drive, 10)
+		if group != "" {
+			volume.Labels[utils.VolumeGroupLabel] = group
+		}
+		return *volume
+	}
+	volumes := []directcsi.DirectCSIVolume{
+		newGroupVolume("volume-1", "drive-1", "group"),
+		newGroupVolume("volume-2", "drive-2", "group"),
+		newGroupVolume("volume-3", "drive-3", "other"),
+		newGroupVolume("volume-4", "drive-4", ""),
+	}
+
+	if groupDrives := getGroupDrives(volumes, &volumes[0]); len(groupDrives) != 1 {
+		t.Fatalf("expected: [drive-2], got: %v", groupDrives)
+	} else if _, found := groupDrives["drive-2"]; !found {
+		t.Fatalf("expected: [drive-2], got: %v", groupDrives)
+	}
+	if groupDrives := getGroupDrives(volumes, &volumes[3]); len(groupDrives) != 0 {
+		t.Fatalf("expected: [], got: %v", groupDrives)
+	}
+}
+
+func TestEvacuateDrives(t *testing.T) {
+	utils.FakeInit()
+
+	mountRoot := t.TempDir()
+	source := newEvacuationTestDrive("source", 40, "volume-1", "volume-2")
+	source.Spec.Unschedulable = true
+	source.Spec.Evacuate = true
+	source.Status.Mountpoint = filepath.Join(mountRoot, "source")
+	target := newEvacuationTestDrive("target", 100)
+	target.Status.Mountpoint = filepath.Join(mountRoot, "target")
+
+	volume1 := newEvacuationTestVolume("volume-1", "source", 20)
+	volume1.Status.HostPath = filepath.Join(source.Status.Mountpoint, "volume-1")
+	volume2 := newEvacuationTestVolume("volume-2", "source", 40)
+	volume2.Status.HostPath = filepath.Join(source.Status.Mountpoint, "volume-2")
+	volume2.Status.StagingPath = "/path/to/staging"
+	if err := os.MkdirAll(volume1.Status.HostPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(source, target, volume1, volume2)
+	var copied []string
+	ns.copyDir = func(_ context.Context, source, target string) error {
+		copied = append(copied, source, target)
+		return nil
+	}
+
+	getDrive := func(name string) *directcsi.DirectCSIDrive {
+		drive, err := ns.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return drive
+	}
+	getVolume := func(name string) *directcsi.DirectCSIVolume {
+		volume, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return volume
+	}
+
+	if err := ns.evacuateDrives(context.TODO()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	targetPath := filepath.Join(target.Status.Mountpoint, "volume-1")
+	if len(copied) != 2 || copied[0] != volume1.Status.HostPath || copied[1] != targetPath {
+		t.Fatalf("copy: expected: [%v %v], got: %v", volume1.Status.HostPath, targetPath, copied)
+	}
+	if _, err := os.Stat(volume1.Status.HostPath); !os.IsNotExist(err) {
+		t.Fatalf("expected source directory to be removed; %v", err)
+	}
+	if ns.quotaFuncs.(*fakeQuotaFuncs).setQuotaArgs.path != targetPath {
+		t.Fatalf("quota: expected path: %v, got: %v", targetPath, ns.quotaFuncs.(*fakeQuotaFuncs).setQuotaArgs.path)
+	}
+
+	volume := getVolume("volume-1")
+	if volume.Status.Drive != "target" || volume.Status.HostPath != targetPath || volume.Status.ProjectID == 0 {
+		t.Fatalf("volume-1: unexpected status %+v", volume.Status)
+	}
+	if volume.Labels[utils.DriveLabel] != "target" {
+		t.Fatalf("volume-1: expected drive label: target, got: %v", volume.Labels[utils.DriveLabel])
+	}
+	if !utils.IsConditionStatus(volume.Status.Conditions, string(directcsi.DirectCSIVolumeConditionEvacuated), metav1.ConditionTrue) {
+		t.Fatalf("volume-1: expected Evacuated condition, got: %v", volume.Status.Conditions)
+	}
+	if volume = getVolume("volume-2"); volume.Status.Drive != "source" || len(volume.Status.Conditions) != 0 {
+		t.Fatalf("volume-2: in use volume must not be evacuated; %+v", volume.Status)
+	}
+
+	drive := getDrive("target")
+	if drive.Status.DriveStatus != directcsi.DriveStatusInUse || drive.Status.FreeCapacity != 80 || len(utils.GetDriveVolumes(drive)) != 1 {
+		t.Fatalf("target: unexpected drive %+v", drive)
+	}
+	drive = getDrive("source")
+	if drive.Status.FreeCapacity != 60 || len(utils.GetDriveVolumes(drive)) != 1 || !drive.Spec.Evacuate {
+		t.Fatalf("source: unexpected drive %+v", drive)
+	}
+
+	// Evacuation completes once the volume is unstaged.
+	volume = getVolume("volume-2")
+	volume.Status.StagingPath = ""
+	if _, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Update(context.TODO(), volume, metav1.UpdateOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ns.evacuateDrives(context.TODO()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	drive = getDrive("source")
+	if drive.Status.DriveStatus != directcsi.DriveStatusReady || drive.Status.FreeCapacity != 100 || len(utils.GetDriveVolumes(drive)) != 0 || drive.Spec.Evacuate {
+		t.Fatalf("source: unexpected drive %+v", drive)
+	}
+	if drive = getDrive("target"); drive.Status.FreeCapacity != 40 || len(utils.GetDriveVolumes(drive)) != 2 {
+		t.Fatalf("target: unexpected drive %+v", drive)
+	}
+}
+
+func TestClearStaleEvacuations(t *testing.T) {
+	mountRoot := t.TempDir()
+	source := newEvacuationTestDrive("source", 60, "volume-1")
+	source.Status.Mountpoint = filepath.Join(mountRoot, "source")
+	target := newEvacuationTestDrive("target", 60, "volume-1")
+	target.Status.Mountpoint = filepath.Join(mountRoot, "target")
+
+	volume1 := newEvacuationTestVolume("volume-1", "source", 40)
+	volume1.Status.Conditions = []metav1.Condition{
+		{
+			Type:   string(directcsi.DirectCSIVolumeConditionEvacuated),
+			Status: metav1.ConditionFalse,
+			Reason: string(directcsi.DirectCSIVolumeReasonEvacuating),
+		},
+	}
+	copyPath := filepath.Join(target.Status.Mountpoint, "volume-1")
+	if err := os.MkdirAll(copyPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(source, target, volume1)
+	if err := ns.clearStaleEvacuations(context.TODO()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	volume, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), "volume-1", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(volume.Status.Conditions) != 1 || volume.Status.Conditions[0].Reason != string(directcsi.DirectCSIVolumeReasonEvacuationFailed) {
+		t.Fatalf("expected EvacuationFailed condition, got: %v", volume.Status.Conditions)
+	}
+	if _, err := os.Stat(copyPath); !os.IsNotExist(err) {
+		t.Fatalf("expected copy of volume to be removed; %v", err)
+	}
+
+	for _, testCase := range []struct {
+		name         string
+		freeCapacity int64
+		volumes      int
+	}{
+		{"source", 60, 1},
+		{"target", 100, 0},
+	} {
+		drive, err := ns.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), testCase.name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if drive.Status.FreeCapacity != testCase.freeCapacity || len(utils.GetDriveVolumes(drive)) != testCase.volumes {
+			t.Fatalf("%v: unexpected drive %+v", testCase.name, drive)
+		}
+	}
+}
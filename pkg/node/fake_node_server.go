@@ -19,8 +19,11 @@ package node
 import (
 	"context"
 
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
 	"github.com/minio/direct-csi/pkg/fs/xfs"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
 )
 
 const (
@@ -32,13 +35,22 @@ type fakeVolumeMounter struct {
 		source      string
 		destination string
 		readOnly    bool
+		mountOpts   []sys.MountOption
 	}
 	unmountArgs struct {
 		target string
 	}
 }
 
-func (f *fakeVolumeMounter) MountVolume(_ context.Context, src, dest string, readOnly bool) error {
+func (f *fakeVolumeMounter) MountVolume(_ context.Context, src, dest string, readOnly bool, mountOpts []sys.MountOption) error {
+	f.mountArgs.source = src
+	f.mountArgs.destination = dest
+	f.mountArgs.readOnly = readOnly
+	f.mountArgs.mountOpts = mountOpts
+	return nil
+}
+
+func (f *fakeVolumeMounter) MountBlockVolume(_ context.Context, src, dest string, readOnly bool) error {
 	f.mountArgs.source = src
 	f.mountArgs.destination = dest
 	f.mountArgs.readOnly = readOnly
@@ -50,25 +62,71 @@ func (f *fakeVolumeMounter) UnmountVolume(targetPath string) error {
 	return nil
 }
 
-type fakeQuotaFuncs struct{}
+type fakeQuotaFuncs struct {
+	setQuotaArgs struct {
+		path      string
+		projectID uint32
+		quota     xfs.Quota
+	}
+	usedProjectIDs map[uint32]bool
+	quota          *xfs.Quota
+	getQuotaErr    error
+}
 
-func (q *fakeQuotaFuncs) GetQuota(ctx context.Context, device, volumeID string) (quota *xfs.Quota, err error) {
+func (q *fakeQuotaFuncs) GetQuota(ctx context.Context, fsType, device string, projectID uint32) (quota *xfs.Quota, err error) {
+	if q.getQuotaErr != nil {
+		return nil, q.getQuotaErr
+	}
+	if q.usedProjectIDs[projectID] {
+		return &xfs.Quota{CurrentInodes: 1}, nil
+	}
+	if q.quota != nil {
+		return q.quota, nil
+	}
 	return &xfs.Quota{}, nil
 }
 
-func (q *fakeQuotaFuncs) SetQuota(ctx context.Context, device, path, volumeID string, quota xfs.Quota) (err error) {
+func (q *fakeQuotaFuncs) SetQuota(ctx context.Context, fsType, device, path string, projectID uint32, quota xfs.Quota) (err error) {
+	q.setQuotaArgs.path = path
+	q.setQuotaArgs.projectID = projectID
+	q.setQuotaArgs.quota = quota
 	return nil
 }
 
+type fakeDriveStatter struct {
+	readOnly bool
+}
+
+func (s *fakeDriveStatter) GetFreeCapacityFromStatfs(path string) (int64, error) {
+	return 0, nil
+}
+
+func (s *fakeDriveStatter) IsReadOnly(path string) (bool, error) {
+	return s.readOnly, nil
+}
+
 func createFakeNodeServer() *NodeServer {
 	return &NodeServer{
-		NodeID:          testNodeName,
-		Identity:        "test-identity",
-		Rack:            "test-rack",
-		Zone:            "test-zone",
-		Region:          "test-region",
+		NodeID:   testNodeName,
+		Identity: "test-identity",
+		topology: newNodeTopology(
+			map[string]string{
+				utils.TopologyDriverIdentity: "test-identity",
+				utils.TopologyDriverRack:     "test-rack",
+				utils.TopologyDriverZone:     "test-zone",
+				utils.TopologyDriverRegion:   "test-region",
+				utils.TopologyDriverNode:     testNodeName,
+			},
+			nil,
+		),
 		directcsiClient: fakedirect.NewSimpleClientset(),
 		mounter:         &fakeVolumeMounter{},
 		quotaFuncs:      &fakeQuotaFuncs{},
+		statter:         &fakeDriveStatter{},
+		reflinkCopy:     func(_ context.Context, _, _ string) error { return nil },
+		copyDir:         func(_ context.Context, _, _ string) error { return nil },
+		makeBlockDevice: func(_ *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (string, int, error) {
+			return sys.GetDirectCSIPath(volume.Name), 1, nil
+		},
 	}
 }
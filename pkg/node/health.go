@@ -0,0 +1,150 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/sys/smart"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const driveHealthInterval = 10 * time.Minute
+
+// getHealthDevicePath returns path of the device holding health of the drive, i.e. the device
+// the drive is a partition of.
+func getHealthDevicePath(drive *directcsi.DirectCSIDrive) string {
+	path := utils.GetDrivePath(drive)
+	if drive.Status.PartitionNum <= 0 {
+		return path
+	}
+
+	// Partition of a device name ending with digit has "p" separator e.g. nvme0n1p1.
+	path = strings.TrimSuffix(path, strconv.Itoa(drive.Status.PartitionNum))
+	if len(path) > 1 && path[len(path)-1] == 'p' && unicode.IsDigit(rune(path[len(path)-2])) {
+		path = path[:len(path)-1]
+	}
+	return path
+}
+
+func toDriveHealth(health *smart.Health) *directcsi.DriveHealth {
+	return &directcsi.DriveHealth{
+		Temperature:        health.Temperature,
+		PowerOnHours:       health.PowerOnHours,
+		PercentageUsed:     health.PercentageUsed,
+		MediaErrors:        health.MediaErrors,
+		ReallocatedSectors: health.ReallocatedSectors,
+		PendingSectors:     health.PendingSectors,
+		CriticalWarnings:   health.CriticalWarnings,
+	}
+}
+
+// updateDriveHealth refreshes health of drives of the node.
+func updateDriveHealth(ctx context.Context, directcsiClient clientset.Interface, nodeID string, getHealth func(devicePath string) (*smart.Health, error)) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(nodeID)
+	if err != nil {
+		return err
+	}
+
+	driveInterface := directcsiClient.DirectV1beta3().DirectCSIDrives()
+	resultCh, err := utils.ListDrives(ctx, driveInterface, []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+
+	var drives []directcsi.DirectCSIDrive
+	for result := range resultCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		drives = append(drives, result.Drive)
+	}
+
+	// Partitions of a device share its health, hence it is read once per device.
+	healthMap := map[string]*directcsi.DriveHealth{}
+	for i := range drives {
+		drive := &drives[i]
+		if drive.Status.Virtual || drive.Status.DMName != "" || drive.Status.MDUUID != "" || drive.Status.DriveStatus == directcsi.DriveStatusUnavailable {
+			continue
+		}
+
+		devicePath := getHealthDevicePath(drive)
+		health, found := healthMap[devicePath]
+		if !found {
+			result, err := getHealth(devicePath)
+			if err != nil {
+				klog.V(5).InfoS("unable to read drive health", "drive", drive.Name, "device", devicePath, "err", err)
+			} else {
+				health = toDriveHealth(result)
+			}
+			healthMap[devicePath] = health
+		}
+		if health == nil || reflect.DeepEqual(drive.Status.Health, health) {
+			continue
+		}
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest, err := driveInterface.Get(ctx, drive.Name, metav1.GetOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			})
+			if err != nil {
+				return err
+			}
+			latest.Status.Health = health
+			_, err = driveInterface.Update(ctx, latest, metav1.UpdateOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			})
+			return err
+		}); err != nil {
+			klog.ErrorS(err, "unable to update drive health", "drive", drive.Name)
+		}
+	}
+
+	return nil
+}
+
+// startDriveHealthMonitor refreshes health of drives of the node periodically.
+func startDriveHealthMonitor(ctx context.Context, nodeID string, directcsiClient clientset.Interface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := updateDriveHealth(ctx, directcsiClient, nodeID, smart.GetHealth); err != nil {
+			klog.ErrorS(err, "unable to update drive health")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
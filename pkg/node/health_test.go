@@ -0,0 +1,130 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/sys/smart"
+	"github.com/minio/direct-csi/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetHealthDevicePath(t *testing.T) {
+	testCases := []struct {
+		path           string
+		partitionNum   int
+		expectedResult string
+	}{
+		{"/dev/sda", 0, "/dev/sda"},
+		{"/dev/sda1", 1, "/dev/sda"},
+		{"/dev/sdb12", 12, "/dev/sdb"},
+		{"/dev/nvme0n1", 0, "/dev/nvme0n1"},
+		{"/dev/nvme0n1p2", 2, "/dev/nvme0n1"},
+	}
+
+	for i, testCase := range testCases {
+		drive := &directcsi.DirectCSIDrive{
+			Status: directcsi.DirectCSIDriveStatus{Path: testCase.path, PartitionNum: testCase.partitionNum},
+		}
+		if result := getHealthDevicePath(drive); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestUpdateDriveHealth(t *testing.T) {
+	newDrive := func(name, path string, partitionNum int, virtual bool) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:     testNodeName,
+				Path:         path,
+				PartitionNum: partitionNum,
+				DriveStatus:  directcsi.DriveStatusReady,
+				Virtual:      virtual,
+			},
+		}
+	}
+
+	healthMap := map[string]*smart.Health{
+		"/dev/sda": {Temperature: 36, PowerOnHours: 8123, ReallocatedSectors: 8},
+		"/dev/nvme0n1": {
+			Temperature:      85,
+			PercentageUsed:   104,
+			MediaErrors:      17,
+			CriticalWarnings: []string{smart.WarningReliabilityDegraded},
+		},
+	}
+	calls := map[string]int{}
+	getHealth := func(devicePath string) (*smart.Health, error) {
+		calls[devicePath]++
+		if health, found := healthMap[devicePath]; found {
+			return health, nil
+		}
+		return nil, errors.New("not supported")
+	}
+
+	client := fakedirect.NewSimpleClientset(
+		newDrive("drive-sda1", "/dev/sda1", 1, false),
+		newDrive("drive-sda2", "/dev/sda2", 2, false),
+		newDrive("drive-nvme", "/dev/nvme0n1", 0, false),
+		newDrive("drive-loop", "/dev/loop0", 0, true),
+		newDrive("drive-sdc", "/dev/sdc", 0, false),
+	)
+	if err := updateDriveHealth(context.TODO(), client, testNodeName, getHealth); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedCalls := map[string]int{"/dev/sda": 1, "/dev/nvme0n1": 1, "/dev/sdc": 1}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Fatalf("calls: expected: %v, got: %v", expectedCalls, calls)
+	}
+
+	expectedHealth := map[string]*directcsi.DriveHealth{
+		"drive-sda1": {Temperature: 36, PowerOnHours: 8123, ReallocatedSectors: 8},
+		"drive-sda2": {Temperature: 36, PowerOnHours: 8123, ReallocatedSectors: 8},
+		"drive-nvme": {
+			Temperature:      85,
+			PercentageUsed:   104,
+			MediaErrors:      17,
+			CriticalWarnings: []string{smart.WarningReliabilityDegraded},
+		},
+		"drive-loop": nil,
+		"drive-sdc":  nil,
+	}
+	for name, health := range expectedHealth {
+		drive, err := client.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), name, metav1.GetOptions{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		})
+		if err != nil {
+			t.Fatalf("drive %v: unexpected error %v", name, err)
+		}
+		if !reflect.DeepEqual(drive.Status.Health, health) {
+			t.Fatalf("drive %v: expected: %+v, got: %+v", name, health, drive.Status.Health)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/kmsg"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// deviceErrorFlushInterval is the interval device errors of a burst are coalesced in.
+	deviceErrorFlushInterval = 5 * time.Second
+
+	// deviceErrorEventInterval is the minimum interval between events of a device error type.
+	deviceErrorEventInterval = time.Minute
+)
+
+var deviceErrorReasons = map[kmsg.ErrorType]directcsi.DirectCSIDriveReason{
+	kmsg.IOError:            directcsi.DirectCSIDriveReasonIOError,
+	kmsg.FilesystemError:    directcsi.DirectCSIDriveReasonFilesystemError,
+	kmsg.FilesystemShutdown: directcsi.DirectCSIDriveReasonFilesystemShutdown,
+}
+
+// matchDeviceError checks whether the device error is reported on the drive, or on the device
+// the drive is a partition of.
+func matchDeviceError(drive *directcsi.DirectCSIDrive, deviceError *kmsg.DeviceError) bool {
+	if deviceError.MajorMinor != "" && deviceError.MajorMinor == fmt.Sprintf("%v:%v", drive.Status.MajorNumber, drive.Status.MinorNumber) {
+		return true
+	}
+
+	name := filepath.Base(drive.Status.Path)
+	if name == deviceError.Device {
+		return true
+	}
+
+	if drive.Status.PartitionNum > 0 {
+		partNum := fmt.Sprint(drive.Status.PartitionNum)
+		return name == deviceError.Device+partNum || name == deviceError.Device+"p"+partNum
+	}
+
+	return false
+}
+
+// setDriveDegraded sets the drive degraded by count occurrences of the device error. A drive
+// having its filesystem shut down is faulty and stays so on further errors.
+func setDriveDegraded(drive *directcsi.DirectCSIDrive, deviceError *kmsg.DeviceError, count int64) {
+	if deviceError.Type == kmsg.IOError {
+		drive.Status.IOErrors += count
+	}
+
+	for _, condition := range drive.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSIDriveConditionDegraded) &&
+			condition.Status == metav1.ConditionTrue &&
+			condition.Reason == string(directcsi.DirectCSIDriveReasonFilesystemShutdown) &&
+			deviceError.Type != kmsg.FilesystemShutdown {
+			return
+		}
+	}
+
+	drive.Status.Conditions = utils.SetCondition(
+		drive.Status.Conditions,
+		string(directcsi.DirectCSIDriveConditionDegraded),
+		metav1.ConditionTrue,
+		string(deviceErrorReasons[deviceError.Type]),
+		deviceError.Message,
+	)
+}
+
+// handleDeviceError marks drives of the node having count occurrences of the device error as degraded.
+func handleDeviceError(ctx context.Context, directcsiClient clientset.Interface, nodeID string, deviceError *kmsg.DeviceError, count int64, sendEvent bool) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(nodeID)
+	if err != nil {
+		return err
+	}
+
+	driveInterface := directcsiClient.DirectV1beta3().DirectCSIDrives()
+	resultCh, err := utils.ListDrives(ctx, driveInterface, []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+
+	var driveNames []string
+	for result := range resultCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		if matchDeviceError(&result.Drive, deviceError) {
+			driveNames = append(driveNames, result.Drive.Name)
+		}
+	}
+
+	for _, driveName := range driveNames {
+		var drive *directcsi.DirectCSIDrive
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if drive, err = driveInterface.Get(ctx, driveName, metav1.GetOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			}); err != nil {
+				return err
+			}
+			setDriveDegraded(drive, deviceError, count)
+			drive, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if sendEvent {
+			utils.Eventf(drive, corev1.EventTypeWarning, string(deviceError.Type), "device %v: %v; %v errors", deviceError.Device, deviceError.Message, count)
+		}
+	}
+
+	return nil
+}
+
+type deviceErrorKey struct {
+	device     string
+	majorMinor string
+	errorType  kmsg.ErrorType
+}
+
+type pendingDeviceError struct {
+	deviceError *kmsg.DeviceError
+	count       int64
+}
+
+// deviceErrorCoalescer accumulates device errors to update the drives once per flush, as
+// a failing device logs errors in bursts. Events of a device error type are rate limited.
+type deviceErrorCoalescer struct {
+	mutex      sync.Mutex
+	pending    map[deviceErrorKey]*pendingDeviceError
+	lastEvents map[deviceErrorKey]time.Time
+}
+
+func newDeviceErrorCoalescer() *deviceErrorCoalescer {
+	return &deviceErrorCoalescer{
+		pending:    map[deviceErrorKey]*pendingDeviceError{},
+		lastEvents: map[deviceErrorKey]time.Time{},
+	}
+}
+
+// add accounts the device error; the latest message of the device error type is kept.
+func (coalescer *deviceErrorCoalescer) add(deviceError *kmsg.DeviceError) {
+	coalescer.mutex.Lock()
+	defer coalescer.mutex.Unlock()
+
+	key := deviceErrorKey{deviceError.Device, deviceError.MajorMinor, deviceError.Type}
+	if pending, found := coalescer.pending[key]; found {
+		pending.deviceError = deviceError
+		pending.count++
+		return
+	}
+	coalescer.pending[key] = &pendingDeviceError{deviceError: deviceError, count: 1}
+}
+
+// flush handles device errors accumulated since previous flush.
+func (coalescer *deviceErrorCoalescer) flush(ctx context.Context, directcsiClient clientset.Interface, nodeID string, now time.Time) {
+	coalescer.mutex.Lock()
+	pending := coalescer.pending
+	coalescer.pending = map[deviceErrorKey]*pendingDeviceError{}
+	coalescer.mutex.Unlock()
+
+	for key, value := range pending {
+		sendEvent := now.Sub(coalescer.lastEvents[key]) >= deviceErrorEventInterval
+		if err := handleDeviceError(ctx, directcsiClient, nodeID, value.deviceError, value.count, sendEvent); err != nil {
+			klog.ErrorS(err, "unable to handle device error", "device", value.deviceError.Device)
+			continue
+		}
+		if sendEvent {
+			coalescer.lastEvents[key] = now
+		}
+	}
+}
+
+// startKmsgWatcher watches kernel log for I/O errors and filesystem shutdowns of drives of the node.
+func startKmsgWatcher(ctx context.Context, nodeID string, directcsiClient clientset.Interface) {
+	reader, err := kmsg.Open()
+	if err != nil {
+		klog.ErrorS(err, "unable to open kernel log; device errors are not watched")
+		return
+	}
+
+	go func() {
+		<-ctx.Done()
+		reader.Close()
+	}()
+
+	coalescer := newDeviceErrorCoalescer()
+	go func() {
+		ticker := time.NewTicker(deviceErrorFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				coalescer.flush(ctx, directcsiClient, nodeID, now)
+			}
+		}
+	}()
+
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, kmsg.ErrInvalidRecord) {
+				klog.V(5).InfoS("invalid kernel log record is ignored", "err", err)
+				continue
+			}
+			if ctx.Err() == nil {
+				klog.ErrorS(err, "unable to read kernel log")
+			}
+			return
+		}
+
+		deviceError := kmsg.ParseDeviceError(record)
+		if deviceError == nil {
+			continue
+		}
+
+		klog.V(5).InfoS("Device error found in kernel log", "device", deviceError.Device, "type", deviceError.Type, "message", deviceError.Message)
+		coalescer.add(deviceError)
+	}
+}
@@ -0,0 +1,190 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/kmsg"
+	"github.com/minio/direct-csi/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchDeviceError(t *testing.T) {
+	newDrive := func(path string, partNum int, major, minor uint32) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			Status: directcsi.DirectCSIDriveStatus{
+				Path:         path,
+				PartitionNum: partNum,
+				MajorNumber:  major,
+				MinorNumber:  minor,
+			},
+		}
+	}
+
+	testCases := []struct {
+		drive         *directcsi.DirectCSIDrive
+		deviceError   *kmsg.DeviceError
+		expectedMatch bool
+	}{
+		{newDrive("/dev/sda", 0, 8, 0), &kmsg.DeviceError{Device: "sda"}, true},
+		{newDrive("/dev/sda1", 1, 8, 1), &kmsg.DeviceError{Device: "sda"}, true},
+		{newDrive("/dev/sda1", 1, 8, 1), &kmsg.DeviceError{Device: "sda1"}, true},
+		{newDrive("/dev/sda11", 11, 8, 11), &kmsg.DeviceError{Device: "sda1"}, false},
+		{newDrive("/dev/nvme0n1p2", 2, 259, 2), &kmsg.DeviceError{Device: "nvme0n1"}, true},
+		{newDrive("/dev/sdb", 0, 8, 16), &kmsg.DeviceError{Device: "sda"}, false},
+		{newDrive("/dev/dm-0", 0, 253, 0), &kmsg.DeviceError{Device: "unknown", MajorMinor: "253:0"}, true},
+		{newDrive("/dev/sda", 0, 8, 0), &kmsg.DeviceError{Device: "sdb", MajorMinor: "8:16"}, false},
+	}
+
+	for i, testCase := range testCases {
+		if match := matchDeviceError(testCase.drive, testCase.deviceError); match != testCase.expectedMatch {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedMatch, match)
+		}
+	}
+}
+
+func TestHandleDeviceError(t *testing.T) {
+	utils.FakeInit()
+
+	newDrive := func(name, node, path string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(node)},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName: node,
+				Path:     path,
+			},
+		}
+	}
+	client := fakedirect.NewSimpleClientset(
+		newDrive("drive-1", testNodeName, "/dev/sda"),
+		newDrive("drive-2", testNodeName, "/dev/sdb"),
+		newDrive("drive-3", "other-node", "/dev/sda"),
+	)
+
+	testCases := []struct {
+		deviceError      *kmsg.DeviceError
+		expectedIOErrors int64
+		expectedReason   directcsi.DirectCSIDriveReason
+	}{
+		{&kmsg.DeviceError{Device: "sda", Type: kmsg.IOError, Message: "I/O error, dev sda, sector 2048"}, 1, directcsi.DirectCSIDriveReasonIOError},
+		{&kmsg.DeviceError{Device: "sda", Type: kmsg.FilesystemShutdown, Message: "XFS (sda): Log I/O Error Detected. Shutting down filesystem"}, 1, directcsi.DirectCSIDriveReasonFilesystemShutdown},
+		{&kmsg.DeviceError{Device: "sda", Type: kmsg.IOError, Message: "I/O error, dev sda, sector 4096"}, 2, directcsi.DirectCSIDriveReasonFilesystemShutdown},
+	}
+
+	for i, testCase := range testCases {
+		if err := handleDeviceError(context.TODO(), client, testNodeName, testCase.deviceError, 1, true); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		drive, err := client.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), "drive-1", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if drive.Status.IOErrors != testCase.expectedIOErrors {
+			t.Fatalf("case %v: I/O errors: expected: %v, got: %v", i+1, testCase.expectedIOErrors, drive.Status.IOErrors)
+		}
+		if !utils.IsConditionStatus(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionDegraded), metav1.ConditionTrue) ||
+			drive.Status.Conditions[0].Reason != string(testCase.expectedReason) {
+			t.Fatalf("case %v: expected degraded by %v, got: %+v", i+1, testCase.expectedReason, drive.Status.Conditions)
+		}
+	}
+
+	for _, driveName := range []string{"drive-2", "drive-3"} {
+		drive, err := client.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if drive.Status.IOErrors != 0 || len(drive.Status.Conditions) != 0 {
+			t.Fatalf("drive %v: unexpected status %+v", driveName, drive.Status)
+		}
+	}
+}
+
+func TestDeviceErrorCoalescer(t *testing.T) {
+	utils.FakeInit()
+
+	client := fakedirect.NewSimpleClientset(&directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "drive-1",
+			Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName: testNodeName,
+			Path:     "/dev/sda",
+		},
+	})
+	getDrive := func() *directcsi.DirectCSIDrive {
+		drive, err := client.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), "drive-1", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return drive
+	}
+	ioError := &kmsg.DeviceError{Device: "sda", Type: kmsg.IOError, Message: "I/O error, dev sda, sector 2048"}
+	key := deviceErrorKey{"sda", "", kmsg.IOError}
+
+	coalescer := newDeviceErrorCoalescer()
+	for i := 0; i < 3; i++ {
+		coalescer.add(ioError)
+	}
+	coalescer.add(&kmsg.DeviceError{Device: "sda", Type: kmsg.FilesystemShutdown, Message: "XFS (sda): Log I/O Error Detected. Shutting down filesystem"})
+
+	now := time.Now()
+	coalescer.flush(context.TODO(), client, testNodeName, now)
+	drive := getDrive()
+	if drive.Status.IOErrors != 3 {
+		t.Fatalf("I/O errors: expected: 3, got: %v", drive.Status.IOErrors)
+	}
+	if !utils.IsCondition(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionDegraded), metav1.ConditionTrue, string(directcsi.DirectCSIDriveReasonFilesystemShutdown), drive.Status.Conditions[0].Message) {
+		t.Fatalf("expected degraded by filesystem shutdown, got: %+v", drive.Status.Conditions)
+	}
+	if !coalescer.lastEvents[key].Equal(now) {
+		t.Fatalf("expected event at %v, got %v", now, coalescer.lastEvents[key])
+	}
+
+	// Nothing is pending after flush.
+	coalescer.flush(context.TODO(), client, testNodeName, now.Add(time.Second))
+	if drive = getDrive(); drive.Status.IOErrors != 3 {
+		t.Fatalf("I/O errors: expected: 3, got: %v", drive.Status.IOErrors)
+	}
+
+	// Events are rate limited while errors are still counted.
+	coalescer.add(ioError)
+	coalescer.flush(context.TODO(), client, testNodeName, now.Add(deviceErrorFlushInterval))
+	if drive = getDrive(); drive.Status.IOErrors != 4 {
+		t.Fatalf("I/O errors: expected: 4, got: %v", drive.Status.IOErrors)
+	}
+	if !coalescer.lastEvents[key].Equal(now) {
+		t.Fatalf("expected no event after %v, got %v", now, coalescer.lastEvents[key])
+	}
+
+	coalescer.add(ioError)
+	coalescer.flush(context.TODO(), client, testNodeName, now.Add(deviceErrorEventInterval))
+	if !coalescer.lastEvents[key].Equal(now.Add(deviceErrorEventInterval)) {
+		t.Fatalf("expected event at %v, got %v", now.Add(deviceErrorEventInterval), coalescer.lastEvents[key])
+	}
+}
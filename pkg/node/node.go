@@ -18,14 +18,19 @@ package node
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/clientset"
 	"github.com/minio/direct-csi/pkg/drive"
 	"github.com/minio/direct-csi/pkg/metrics"
+	"github.com/minio/direct-csi/pkg/snapshot"
 	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
 	"github.com/minio/direct-csi/pkg/volume"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -35,7 +40,7 @@ import (
 )
 
 // NewNodeServer creates node server.
-func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region string, enableDynamicDiscovery bool) (*NodeServer, error) {
+func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region string, topologyLabels []string, enableDynamicDiscovery bool, healthPolicy drive.HealthPolicy) (*NodeServer, error) {
 	config, err := utils.GetKubeConfig()
 	if err != nil {
 		return &NodeServer{}, err
@@ -46,20 +51,43 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 		return &NodeServer{}, err
 	}
 
+	topology := newNodeTopology(
+		map[string]string{
+			utils.TopologyDriverIdentity: identity,
+			utils.TopologyDriverRack:     rack,
+			utils.TopologyDriverZone:     zone,
+			utils.TopologyDriverRegion:   region,
+			utils.TopologyDriverNode:     nodeID,
+		},
+		topologyLabels,
+	)
+	if len(topologyLabels) != 0 {
+		node, err := utils.GetKubeClient().CoreV1().Nodes().Get(ctx, nodeID, metav1.GetOptions{})
+		if err != nil {
+			return &NodeServer{}, err
+		}
+		topology.update(node.GetLabels())
+		if err := syncDriveTopology(ctx, directClientset, nodeID, topology); err != nil {
+			return &NodeServer{}, err
+		}
+	}
+
 	nodeServer := &NodeServer{
 		NodeID:          nodeID,
 		Identity:        identity,
-		Rack:            rack,
-		Zone:            zone,
-		Region:          region,
+		topology:        topology,
 		directcsiClient: directClientset,
 		mounter:         &sys.DefaultVolumeMounter{},
-		quotaFuncs:      &xfsQuotaFuncs{},
+		quotaFuncs:      &fsQuotaFuncs{},
+		statter:         &sys.DefaultDriveStatter{},
+		reflinkCopy:     sys.ReflinkCopy,
+		copyDir:         sys.CopyDir,
+		makeBlockDevice: makeBlockDevice,
 	}
 
 	// Start background tasks
 	go func() {
-		if err := drive.StartController(ctx, nodeID); err != nil {
+		if err := drive.StartController(ctx, nodeID, healthPolicy); err != nil {
 			klog.Error(err)
 		}
 	}()
@@ -70,18 +98,34 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 		}
 	}()
 
+	go func() {
+		if err := snapshot.StartController(ctx, nodeID); err != nil {
+			klog.Error(err)
+		}
+	}()
+
+	if len(topologyLabels) != 0 {
+		go func() {
+			if err := startTopologyController(ctx, nodeID, topology); err != nil {
+				klog.Error(err)
+			}
+		}()
+	}
+
+	go func() {
+		if err := startVolumeLimitController(ctx, identity, nodeID); err != nil {
+			klog.Error(err)
+		}
+	}()
+
+	go nodeServer.startMountReconciler(ctx, mountReconcileInterval)
 	go metrics.ServeMetrics(ctx, nodeID)
 	if enableDynamicDiscovery {
-		go startUeventHandler(
-			ctx, nodeID, map[string]string{
-				utils.TopologyDriverIdentity: identity,
-				utils.TopologyDriverRack:     rack,
-				utils.TopologyDriverZone:     zone,
-				utils.TopologyDriverRegion:   region,
-				utils.TopologyDriverNode:     nodeID,
-			},
-		)
+		go startUeventHandler(ctx, nodeID, topology)
 	}
+	go startKmsgWatcher(ctx, nodeID, directClientset)
+	go startDriveHealthMonitor(ctx, nodeID, directClientset, driveHealthInterval)
+	go nodeServer.startDriveEvacuator(ctx, driveEvacuationInterval)
 
 	return nodeServer, nil
 }
@@ -90,12 +134,16 @@ func NewNodeServer(ctx context.Context, identity, nodeID, rack, zone, region str
 type NodeServer struct { //revive:disable-line:exported
 	NodeID          string
 	Identity        string
-	Rack            string
-	Zone            string
-	Region          string
+	topology        *nodeTopology
 	directcsiClient clientset.Interface
 	mounter         sys.VolumeMounter
 	quotaFuncs      quotaFuncs
+	projectIDMutex  sync.Mutex
+	mountMutex      sync.RWMutex
+	statter         sys.DriveStatter
+	reflinkCopy     func(ctx context.Context, source, target string) error
+	copyDir         func(ctx context.Context, source, target string) error
+	makeBlockDevice func(drive *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (string, int, error)
 }
 
 //revive:enable-line:exported
@@ -103,18 +151,18 @@ type NodeServer struct { //revive:disable-line:exported
 // NodeGetInfo gets node information.
 func (ns *NodeServer) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
 	topology := &csi.Topology{
-		Segments: map[string]string{
-			utils.TopologyDriverIdentity: ns.Identity,
-			utils.TopologyDriverRack:     ns.Rack,
-			utils.TopologyDriverZone:     ns.Zone,
-			utils.TopologyDriverRegion:   ns.Region,
-			utils.TopologyDriverNode:     ns.NodeID,
-		},
+		Segments: ns.topology.get(),
+	}
+
+	maxVolumes, err := getMaxVolumesPerNode(ctx, ns.directcsiClient.DirectV1beta3(), ns.NodeID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to compute max volumes of node %v: %v", ns.NodeID, err)
 	}
+	klog.V(3).InfoS("Computed max volumes per node", "NodeID", ns.NodeID, "MaxVolumesPerNode", maxVolumes)
 
 	return &csi.NodeGetInfoResponse{
 		NodeId:             ns.NodeID,
-		MaxVolumesPerNode:  int64(100),
+		MaxVolumesPerNode:  maxVolumes,
 		AccessibleTopology: topology,
 	}, nil
 }
@@ -137,12 +185,58 @@ func (ns *NodeServer) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetC
 		Capabilities: []*csi.NodeServiceCapability{
 			nodeCap(csi.NodeServiceCapability_RPC_GET_VOLUME_STATS),
 			nodeCap(csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME),
+			nodeCap(csi.NodeServiceCapability_RPC_EXPAND_VOLUME),
+			nodeCap(csi.NodeServiceCapability_RPC_VOLUME_CONDITION),
+			nodeCap(csi.NodeServiceCapability_RPC_VOLUME_MOUNT_GROUP),
 		},
 	}, nil
 }
 
-// NodeGetVolumeStats gets node volume stats.
-func (ns *NodeServer) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
+// volumeCondition returns abnormal condition of the volume if its drive is missing, terminating or
+// degraded, its staging mount has disappeared or its drive has gone read-only.
+func (ns *NodeServer) volumeCondition(vol *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive, probeMounts func() (map[string][]sys.MountInfo, error)) *csi.VolumeCondition {
+	abnormal := func(format string, args ...interface{}) *csi.VolumeCondition {
+		return &csi.VolumeCondition{
+			Abnormal: true,
+			Message:  fmt.Sprintf(format, args...),
+		}
+	}
+
+	if drive == nil {
+		return abnormal("drive %v of volume %v is missing", vol.Status.Drive, vol.Name)
+	}
+	if drive.DeletionTimestamp != nil || drive.Status.DriveStatus == directcsi.DriveStatusTerminating {
+		return abnormal("drive %v of volume %v is terminating", drive.Name, vol.Name)
+	}
+	for _, condition := range drive.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSIDriveConditionDegraded) && condition.Status == metav1.ConditionTrue {
+			return abnormal("drive %v of volume %v is degraded by %v; %v", drive.Name, vol.Name, condition.Reason, condition.Message)
+		}
+	}
+
+	if !vol.Status.BlockMode {
+		if vol.Status.StagingPath != "" {
+			if err := checkStagingTargetPath(vol.Status.StagingPath, probeMounts); err != nil {
+				return abnormal("staging mount of volume %v is missing; %v", vol.Name, err)
+			}
+		}
+
+		readOnly, err := ns.statter.IsReadOnly(drive.Status.Mountpoint)
+		if err != nil {
+			return abnormal("unable to stat drive %v of volume %v; %v", drive.Name, vol.Name, err)
+		}
+		if readOnly {
+			return abnormal("drive %v of volume %v is read-only", drive.Name, vol.Name)
+		}
+	}
+
+	return &csi.VolumeCondition{
+		Abnormal: false,
+		Message:  "",
+	}
+}
+
+func (ns *NodeServer) nodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest, probeMounts func() (map[string][]sys.MountInfo, error)) (*csi.NodeGetVolumeStatsResponse, error) {
 	vID := req.GetVolumeId()
 	volumePath := req.GetVolumePath()
 
@@ -163,12 +257,52 @@ func (ns *NodeServer) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVo
 	drive, err := dclient.Get(ctx, vol.Status.Drive, metav1.GetOptions{
 		TypeMeta: utils.DirectCSIDriveTypeMeta(),
 	})
-	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+	switch {
+	case errors.IsNotFound(err):
+		drive = nil
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	quota, err := ns.quotaFuncs.GetQuota(ctx, sys.GetDirectCSIPath(drive.Status.FilesystemUUID), vID)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "Error while getting xfs volume stats: %v", err)
+
+	condition := ns.volumeCondition(vol, drive, probeMounts)
+
+	// Usage of block volume is not known; report its total capacity.
+	if vol.Status.BlockMode {
+		return &csi.NodeGetVolumeStatsResponse{
+			Usage: []*csi.VolumeUsage{
+				{
+					Total: vol.Status.TotalCapacity,
+					Unit:  csi.VolumeUsage_BYTES,
+				},
+			},
+			VolumeCondition: condition,
+		}, nil
+	}
+
+	if drive == nil {
+		return &csi.NodeGetVolumeStatsResponse{
+			VolumeCondition: condition,
+		}, nil
+	}
+
+	quota, err := ns.quotaFuncs.GetQuota(ctx, drive.Status.Filesystem, sys.GetDirectCSIPath(drive.Status.FilesystemUUID), utils.GetProjectID(vol))
+	switch {
+	case err != nil:
+		klog.ErrorS(err, "unable to get quota", "volume", vID, "drive", drive.Name)
+		if !condition.Abnormal {
+			condition = &csi.VolumeCondition{
+				Abnormal: true,
+				Message:  fmt.Sprintf("unable to get quota of volume %v; %v", vID, err),
+			}
+		}
+		return &csi.NodeGetVolumeStatsResponse{
+			VolumeCondition: condition,
+		}, nil
+	case quota.HardLimit == 0 && !condition.Abnormal:
+		condition = &csi.VolumeCondition{
+			Abnormal: true,
+			Message:  fmt.Sprintf("quota of volume %v is missing on drive %v", vID, drive.Name),
+		}
 	}
 
 	volUsage := &csi.VolumeUsage{
@@ -178,18 +312,94 @@ func (ns *NodeServer) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVo
 		Unit:      csi.VolumeUsage_BYTES,
 	}
 
+	// Total and available inodes are known only if the volume has an inode limit.
+	inodeUsage := &csi.VolumeUsage{
+		Used: int64(quota.CurrentInodes),
+		Unit: csi.VolumeUsage_INODES,
+	}
+	if quota.HardInodeLimit != 0 {
+		inodeUsage.Total = int64(quota.HardInodeLimit)
+		inodeUsage.Available = int64(quota.HardInodeLimit) - int64(quota.CurrentInodes)
+	}
+
 	return &csi.NodeGetVolumeStatsResponse{
 		Usage: []*csi.VolumeUsage{
 			volUsage,
+			inodeUsage,
 		},
-		VolumeCondition: &csi.VolumeCondition{
-			Abnormal: false,
-			Message:  "",
-		},
+		VolumeCondition: condition,
 	}, nil
 }
 
-// NodeExpandVolume returns unimplemented error.
-func (ns *NodeServer) NodeExpandVolume(ctx context.Context, in *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+// NodeGetVolumeStats gets node volume stats along with the volume condition.
+func (ns *NodeServer) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
+	return ns.nodeGetVolumeStats(ctx, req, sys.ProbeMounts)
+}
+
+// NodeExpandVolume raises quota of the volume to its expanded capacity.
+func (ns *NodeServer) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
+	klog.V(3).InfoS("NodeExpandVolumeRequest",
+		"volumeID", req.GetVolumeId(),
+		"volumePath", req.GetVolumePath(),
+		"requiredBytes", req.GetCapacityRange().GetRequiredBytes())
+
+	vID := req.GetVolumeId()
+	if vID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+	if req.GetVolumePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume path missing in request")
+	}
+
+	directCSIClient := ns.directcsiClient.DirectV1beta3()
+	vol, err := directCSIClient.DirectCSIVolumes().Get(ctx, vID, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Controller expansion must be completed before the node expansion.
+	size := req.GetCapacityRange().GetRequiredBytes()
+	if size > vol.Status.TotalCapacity {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v is not yet expanded to %v bytes", vID, size)
+	}
+
+	drive, err := directCSIClient.DirectCSIDrives().Get(ctx, vol.Status.Drive, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	path := req.GetStagingTargetPath()
+	if path == "" {
+		path = vol.Status.StagingPath
+	}
+	if path == "" {
+		path = vol.Status.HostPath
+	}
+
+	// Expansion request does not carry the volume context; keep inode limits
+	// and the soft limit percentage of the existing quota.
+	device := sys.GetDirectCSIPath(drive.Status.FilesystemUUID)
+	projectID := utils.GetProjectID(vol)
+	existing, err := ns.quotaFuncs.GetQuota(ctx, drive.Status.Filesystem, device, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while getting quota limits: %v", err)
+	}
+	quota := expandQuota(existing, uint64(vol.Status.TotalCapacity))
+	if err := ns.quotaFuncs.SetQuota(ctx, drive.Status.Filesystem, device, path, projectID, quota); err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while setting quota limits: %v", err)
+	}
+
+	return &csi.NodeExpandVolumeResponse{
+		CapacityBytes: vol.Status.TotalCapacity,
+	}, nil
 }
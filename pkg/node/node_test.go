@@ -17,11 +17,22 @@
 package node
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestGetLatestStatus(t1 *testing.T) {
@@ -152,3 +163,328 @@ func TestGetLatestStatus(t1 *testing.T) {
 	}
 
 }
+
+func TestNodeGetCapabilities(t *testing.T) {
+	ns := createFakeNodeServer()
+	result, err := ns.NodeGetCapabilities(context.TODO(), &csi.NodeGetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expectedCapability := range []csi.NodeServiceCapability_RPC_Type{
+		csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
+		csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+		csi.NodeServiceCapability_RPC_VOLUME_MOUNT_GROUP,
+	} {
+		found := false
+		for _, capability := range result.GetCapabilities() {
+			if capability.GetRpc().GetType() == expectedCapability {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected capability %v not found in %v", expectedCapability, result.GetCapabilities())
+		}
+	}
+}
+
+func TestNodeGetInfo(t *testing.T) {
+	newDrive := func(name, node string, driveStatus directcsi.DriveStatus, freeCapacity int64, maxVolumes int32, volumes ...string) *directcsi.DirectCSIDrive {
+		finalizers := []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)}
+		for _, volume := range volumes {
+			finalizers = append(finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume)
+		}
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: finalizers,
+				Labels:     map[string]string{utils.NodeLabel: utils.SanitizeLabelV(node)},
+			},
+			Spec: directcsi.DirectCSIDriveSpec{MaxVolumes: maxVolumes},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:     node,
+				DriveStatus:  driveStatus,
+				FreeCapacity: freeCapacity,
+			},
+		}
+	}
+
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(
+		newDrive("ready-drive", testNodeName, directcsi.DriveStatusReady, mb100, 0),
+		newDrive("capped-drive", testNodeName, directcsi.DriveStatusInUse, mb50, 4, "volume-1", "volume-2"),
+		newDrive("full-drive", testNodeName, directcsi.DriveStatusInUse, 0, 0, "volume-3", "volume-4", "volume-5"),
+		newDrive("available-drive", testNodeName, directcsi.DriveStatusAvailable, mb100, 0),
+		newDrive("other-node-drive", "other-node", directcsi.DriveStatusReady, mb100, 0),
+	)
+
+	result, err := ns.NodeGetInfo(context.TODO(), &csi.NodeGetInfoRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := int64(defaultMaxVolumesPerDrive + 4 + 3); result.MaxVolumesPerNode != expected {
+		t.Fatalf("expected max volumes per node: %v, got: %v", expected, result.MaxVolumesPerNode)
+	}
+
+	// A node without drives must not report zero, i.e. unlimited.
+	ns.directcsiClient = fakedirect.NewSimpleClientset()
+	if result, err = ns.NodeGetInfo(context.TODO(), &csi.NodeGetInfoRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MaxVolumesPerNode != 1 {
+		t.Fatalf("expected max volumes per node: 1, got: %v", result.MaxVolumesPerNode)
+	}
+}
+
+func TestNodeExpandVolume(t *testing.T) {
+	testDrive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-drive",
+			Finalizers: []string{
+				string(directcsi.DirectCSIDriveFinalizerDataProtection),
+				directcsi.DirectCSIDriveFinalizerPrefix + "test-volume",
+			},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:       testNodeName,
+			DriveStatus:    directcsi.DriveStatusInUse,
+			FilesystemUUID: "test-fsuuid",
+			TotalCapacity:  mb100,
+		},
+	}
+	testVolume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "test-volume"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "test-drive",
+			StagingPath:   "/path/to/staging",
+			TotalCapacity: mb50,
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		req          *csi.NodeExpandVolumeRequest
+		expectedCode codes.Code
+	}{
+		{
+			name:         "missing volume ID",
+			req:          &csi.NodeExpandVolumeRequest{VolumePath: "/path/to/target"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "missing volume path",
+			req:          &csi.NodeExpandVolumeRequest{VolumeId: "test-volume"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "unknown volume",
+			req:          &csi.NodeExpandVolumeRequest{VolumeId: "unknown-volume", VolumePath: "/path/to/target"},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "controller expansion pending",
+			req: &csi.NodeExpandVolumeRequest{
+				VolumeId:      "test-volume",
+				VolumePath:    "/path/to/target",
+				CapacityRange: &csi.CapacityRange{RequiredBytes: mb100},
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "expanded",
+			req: &csi.NodeExpandVolumeRequest{
+				VolumeId:      "test-volume",
+				VolumePath:    "/path/to/target",
+				CapacityRange: &csi.CapacityRange{RequiredBytes: mb50},
+			},
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ns := createFakeNodeServer()
+			ns.directcsiClient = fakedirect.NewSimpleClientset(testDrive.DeepCopy(), testVolume.DeepCopy())
+			quotaFuncs := &fakeQuotaFuncs{}
+			ns.quotaFuncs = quotaFuncs
+
+			result, err := ns.NodeExpandVolume(context.TODO(), testCase.req)
+			if code := status.Code(err); code != testCase.expectedCode {
+				t.Fatalf("expected code: %v, got: %v, err: %v", testCase.expectedCode, code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.GetCapacityBytes() != mb50 {
+				t.Errorf("expected capacity: %v, got: %v", mb50, result.GetCapacityBytes())
+			}
+			if quotaFuncs.setQuotaArgs.path != testVolume.Status.StagingPath {
+				t.Errorf("expected quota path: %v, got: %v", testVolume.Status.StagingPath, quotaFuncs.setQuotaArgs.path)
+			}
+			if quotaFuncs.setQuotaArgs.quota.HardLimit != uint64(mb50) {
+				t.Errorf("expected hard limit: %v, got: %v", mb50, quotaFuncs.setQuotaArgs.quota.HardLimit)
+			}
+		})
+	}
+}
+
+func TestNodeGetVolumeStats(t *testing.T) {
+	newDrive := func(driveStatus directcsi.DriveStatus) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: "test-drive"},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:       testNodeName,
+				DriveStatus:    driveStatus,
+				FilesystemUUID: "test-fsuuid",
+				Mountpoint:     "/var/lib/direct-csi/mnt/test-fsuuid",
+			},
+		}
+	}
+	testVolume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "test-volume"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "test-drive",
+			StagingPath:   "/path/to/staging",
+			TotalCapacity: mb50,
+		},
+	}
+	mounted := func() (map[string][]sys.MountInfo, error) {
+		return map[string][]sys.MountInfo{"0:0": {{MountPoint: "/path/to/staging"}}}, nil
+	}
+	notMounted := func() (map[string][]sys.MountInfo, error) {
+		return map[string][]sys.MountInfo{}, nil
+	}
+	quota := &xfs.Quota{HardLimit: uint64(mb50), SoftLimit: uint64(mb50), CurrentSpace: uint64(mb20), HardInodeLimit: 100, CurrentInodes: 10}
+
+	testCases := []struct {
+		name             string
+		drive            *directcsi.DirectCSIDrive
+		probeMounts      func() (map[string][]sys.MountInfo, error)
+		readOnly         bool
+		quotaFuncs       *fakeQuotaFuncs
+		expectedAbnormal bool
+		expectedUsage    []*csi.VolumeUsage
+	}{
+		{
+			name:        "healthy",
+			drive:       newDrive(directcsi.DriveStatusInUse),
+			probeMounts: mounted,
+			quotaFuncs:  &fakeQuotaFuncs{quota: quota},
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50 - mb20, Total: mb50, Used: mb20, Unit: csi.VolumeUsage_BYTES},
+				{Available: 90, Total: 100, Used: 10, Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name:             "missing drive",
+			probeMounts:      mounted,
+			quotaFuncs:       &fakeQuotaFuncs{quota: quota},
+			expectedAbnormal: true,
+		},
+		{
+			name:             "terminating drive",
+			drive:            newDrive(directcsi.DriveStatusTerminating),
+			probeMounts:      mounted,
+			quotaFuncs:       &fakeQuotaFuncs{quota: quota},
+			expectedAbnormal: true,
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50 - mb20, Total: mb50, Used: mb20, Unit: csi.VolumeUsage_BYTES},
+				{Available: 90, Total: 100, Used: 10, Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name: "degraded drive",
+			drive: func() *directcsi.DirectCSIDrive {
+				drive := newDrive(directcsi.DriveStatusInUse)
+				drive.Status.Conditions = []metav1.Condition{
+					{Type: string(directcsi.DirectCSIDriveConditionDegraded), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIDriveReasonIOError)},
+				}
+				return drive
+			}(),
+			probeMounts:      mounted,
+			quotaFuncs:       &fakeQuotaFuncs{quota: quota},
+			expectedAbnormal: true,
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50 - mb20, Total: mb50, Used: mb20, Unit: csi.VolumeUsage_BYTES},
+				{Available: 90, Total: 100, Used: 10, Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name:             "missing staging mount",
+			drive:            newDrive(directcsi.DriveStatusInUse),
+			probeMounts:      notMounted,
+			quotaFuncs:       &fakeQuotaFuncs{quota: quota},
+			expectedAbnormal: true,
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50 - mb20, Total: mb50, Used: mb20, Unit: csi.VolumeUsage_BYTES},
+				{Available: 90, Total: 100, Used: 10, Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name:             "read-only drive",
+			drive:            newDrive(directcsi.DriveStatusInUse),
+			probeMounts:      mounted,
+			readOnly:         true,
+			quotaFuncs:       &fakeQuotaFuncs{quota: quota},
+			expectedAbnormal: true,
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50 - mb20, Total: mb50, Used: mb20, Unit: csi.VolumeUsage_BYTES},
+				{Available: 90, Total: 100, Used: 10, Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name:             "missing quota",
+			drive:            newDrive(directcsi.DriveStatusInUse),
+			probeMounts:      mounted,
+			quotaFuncs:       &fakeQuotaFuncs{},
+			expectedAbnormal: true,
+			expectedUsage: []*csi.VolumeUsage{
+				{Available: mb50, Total: mb50, Unit: csi.VolumeUsage_BYTES},
+				{Unit: csi.VolumeUsage_INODES},
+			},
+		},
+		{
+			name:             "quota error",
+			drive:            newDrive(directcsi.DriveStatusInUse),
+			probeMounts:      mounted,
+			quotaFuncs:       &fakeQuotaFuncs{getQuotaErr: errors.New("no such process")},
+			expectedAbnormal: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			objects := []runtime.Object{testVolume.DeepCopy()}
+			if testCase.drive != nil {
+				objects = append(objects, testCase.drive)
+			}
+			ns := createFakeNodeServer()
+			ns.directcsiClient = fakedirect.NewSimpleClientset(objects...)
+			ns.quotaFuncs = testCase.quotaFuncs
+			ns.statter = &fakeDriveStatter{readOnly: testCase.readOnly}
+
+			result, err := ns.nodeGetVolumeStats(context.TODO(), &csi.NodeGetVolumeStatsRequest{VolumeId: "test-volume", VolumePath: "/path/to/target"}, testCase.probeMounts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if condition := result.GetVolumeCondition(); condition.GetAbnormal() != testCase.expectedAbnormal {
+				t.Fatalf("abnormal: expected: %v, got: %v, message: %v", testCase.expectedAbnormal, condition.GetAbnormal(), condition.GetMessage())
+			}
+			if testCase.expectedAbnormal && result.GetVolumeCondition().GetMessage() == "" {
+				t.Fatalf("expected message in abnormal condition")
+			}
+			if !reflect.DeepEqual(result.GetUsage(), testCase.expectedUsage) {
+				t.Fatalf("usage: expected: %v, got: %v", testCase.expectedUsage, result.GetUsage())
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
@@ -97,6 +98,11 @@ func (n *NodeServer) nodePublishVolume(ctx context.Context, req *csi.NodePublish
 		return nil, status.Error(codes.InvalidArgument, "target path must not be empty")
 	}
 
+	mountOpts, err := sys.ParseVolumeMountFlags(req.GetVolumeCapability().GetMount().GetMountFlags())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	podName, podNS, podLabels := getPodInfo(ctx, req)
 
 	volumeInterface := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
@@ -123,16 +129,22 @@ func (n *NodeServer) nodePublishVolume(ctx context.Context, req *csi.NodePublish
 	}
 	vol.Labels = volumeLabels
 
-	if err := checkStagingTargetPath(req.GetStagingTargetPath(), probeMounts); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
+	if vol.Status.BlockMode {
+		if err := publishBlockVolume(ctx, n.mounter, vol.Status.HostPath, req.GetTargetPath(), req.GetReadonly()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+		}
+	} else {
+		if err := checkStagingTargetPath(req.GetStagingTargetPath(), probeMounts); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 
-	if err := os.MkdirAll(req.GetTargetPath(), 0755); err != nil {
-		return nil, err
-	}
+		if err := os.MkdirAll(req.GetTargetPath(), 0755); err != nil {
+			return nil, err
+		}
 
-	if err := n.mounter.MountVolume(ctx, req.GetStagingTargetPath(), req.GetTargetPath(), req.GetReadonly()); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+		if err := n.mounter.MountVolume(ctx, req.GetStagingTargetPath(), req.GetTargetPath(), req.GetReadonly(), mountOpts); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed volume publish: %v", err)
+		}
 	}
 
 	conditions := vol.Status.Conditions
@@ -140,6 +152,7 @@ func (n *NodeServer) nodePublishVolume(ctx context.Context, req *csi.NodePublish
 		if c.Type == string(directcsi.DirectCSIVolumeConditionPublished) {
 			conditions[i].Status = utils.BoolToCondition(true)
 			conditions[i].Reason = string(directcsi.DirectCSIVolumeReasonInUse)
+			conditions[i].Message = ""
 		}
 	}
 	vol.Status.ContainerPath = req.GetTargetPath()
@@ -154,13 +167,36 @@ func (n *NodeServer) nodePublishVolume(ctx context.Context, req *csi.NodePublish
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
+// publishBlockVolume bind mounts block device file of the volume on target file.
+func publishBlockVolume(ctx context.Context, mounter sys.VolumeMounter, device, targetPath string, readOnly bool) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return mounter.MountBlockVolume(ctx, device, targetPath, readOnly)
+}
+
 // NodePublishVolume is node publish volume request handler.
 func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
+	n.mountMutex.RLock()
+	defer n.mountMutex.RUnlock()
+
 	return n.nodePublishVolume(ctx, req, sys.ProbeMounts)
 }
 
 // NodeUnpublishVolume is node unpublish volume handler.
 func (n *NodeServer) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
+	n.mountMutex.RLock()
+	defer n.mountMutex.RUnlock()
+
 	klog.V(3).InfoS("NodeUnPublishVolumeRequest",
 		"volumeID", req.GetVolumeId(),
 		"ContainerPath", req.GetTargetPath())
@@ -19,6 +19,7 @@ package node
 import (
 	"context"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -27,6 +28,8 @@ import (
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,6 +62,38 @@ func TestNodePublishVolume(t *testing.T) {
 	}
 }
 
+func TestNodePublishVolumeInvalidMountFlags(t *testing.T) {
+	req := &csi.NodePublishVolumeRequest{
+		VolumeId:          "volume-id-1",
+		StagingTargetPath: "volume-id-1-staging-target-path",
+		TargetPath:        "volume-id-1-target-path",
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs", MountFlags: []string{"noexec", "suid"}}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+	}
+
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-id-1"},
+		Status:     directcsi.DirectCSIVolumeStatus{StagingPath: "volume-id-1-staging-target-path"},
+	}
+
+	nodeServer := createFakeNodeServer()
+	nodeServer.directcsiClient = fakedirect.NewSimpleClientset(volume)
+	_, err := nodeServer.nodePublishVolume(
+		context.TODO(),
+		req,
+		func() (map[string][]sys.MountInfo, error) { return map[string][]sys.MountInfo{}, nil },
+	)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument error, got: %v", err)
+	}
+	if nodeServer.mounter.(*fakeVolumeMounter).mountArgs.destination != "" {
+		t.Fatalf("unexpected mount of volume to %v", nodeServer.mounter.(*fakeVolumeMounter).mountArgs.destination)
+	}
+}
+
 func TestPublishUnpublishVolume(t *testing.T) {
 	testVolumeName50MB := "test_volume_50MB"
 
@@ -127,7 +162,8 @@ func TestPublishUnpublishVolume(t *testing.T) {
 		VolumeCapability: &csi.VolumeCapability{
 			AccessType: &csi.VolumeCapability_Mount{
 				Mount: &csi.VolumeCapability_MountVolume{
-					FsType: "xfs",
+					FsType:     "xfs",
+					MountFlags: []string{"noexec,nosuid", "noatime"},
 				},
 			},
 			AccessMode: &csi.VolumeCapability_AccessMode{
@@ -172,6 +208,10 @@ func TestPublishUnpublishVolume(t *testing.T) {
 	if ns.mounter.(*fakeVolumeMounter).mountArgs.readOnly != publishVolumeRequest.GetReadonly() {
 		t.Errorf("Wrong readOnly argument passed for mounting. Expected: %v, Got: %v", publishVolumeRequest.GetReadonly(), ns.mounter.(*fakeVolumeMounter).mountArgs.readOnly)
 	}
+	expectedMountOpts := []sys.MountOption{sys.MountOptionMSNoExec, sys.MountOptionMSNoSUID, sys.MountOptionMSNoATime}
+	if !reflect.DeepEqual(ns.mounter.(*fakeVolumeMounter).mountArgs.mountOpts, expectedMountOpts) {
+		t.Errorf("Wrong mount options passed for mounting. Expected: %v, Got: %v", expectedMountOpts, ns.mounter.(*fakeVolumeMounter).mountArgs.mountOpts)
+	}
 
 	// Check if status fields were set correctly
 	if volObj.Status.ContainerPath != testContainerPath {
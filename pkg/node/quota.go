@@ -0,0 +1,167 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/fs/ext4"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+type quotaFuncs interface {
+	GetQuota(ctx context.Context, fsType, device string, projectID uint32) (quota *xfs.Quota, err error)
+	SetQuota(ctx context.Context, fsType, device, path string, projectID uint32, quota xfs.Quota) (err error)
+}
+
+// fsQuotaFuncs dispatches project quota calls to the backend of drive filesystem.
+type fsQuotaFuncs struct{}
+
+func (q *fsQuotaFuncs) GetQuota(ctx context.Context, fsType, device string, projectID uint32) (quota *xfs.Quota, err error) {
+	switch sys.FSType(fsType) {
+	case sys.FSTypeXFS:
+		return xfs.GetQuota(ctx, device, projectID)
+	case sys.FSTypeExt4:
+		return ext4.GetQuota(ctx, device, projectID)
+	default:
+		return nil, fmt.Errorf("project quota is not supported on filesystem %v", fsType)
+	}
+}
+
+func (q *fsQuotaFuncs) SetQuota(ctx context.Context, fsType, device, path string, projectID uint32, quota xfs.Quota) (err error) {
+	switch sys.FSType(fsType) {
+	case sys.FSTypeXFS:
+		return xfs.SetQuota(ctx, device, path, projectID, quota)
+	case sys.FSTypeExt4:
+		return ext4.SetQuota(ctx, device, path, projectID, quota)
+	default:
+		return fmt.Errorf("project quota is not supported on filesystem %v", fsType)
+	}
+}
+
+// expandQuota returns the quota of given size keeping inode limits and the soft limit
+// percentage of the existing quota.
+func expandQuota(existing *xfs.Quota, size uint64) xfs.Quota {
+	quota := xfs.Quota{
+		HardLimit: size,
+		SoftLimit: size,
+	}
+	if existing == nil {
+		return quota
+	}
+
+	if existing.HardLimit != 0 && existing.SoftLimit < existing.HardLimit {
+		quota.SoftLimit = uint64(float64(size) * float64(existing.SoftLimit) / float64(existing.HardLimit))
+	}
+	quota.HardInodeLimit = existing.HardInodeLimit
+	quota.SoftInodeLimit = existing.SoftInodeLimit
+	return quota
+}
+
+// getUsedProjectIDs returns project IDs of volumes, other than given volume, on the drive.
+func (n *NodeServer) getUsedProjectIDs(ctx context.Context, volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) (map[uint32]struct{}, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(n.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultCh, err := utils.ListVolumes(ctx, n.directcsiClient.DirectV1beta3().DirectCSIVolumes(), []utils.LabelValue{nodeLabelValue}, nil, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return nil, err
+	}
+
+	usedIDs := map[uint32]struct{}{}
+	for result := range resultCh {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if result.Volume.Name == volume.Name || result.Volume.Status.Drive != drive.Name || result.Volume.Status.BlockMode {
+			continue
+		}
+		usedIDs[utils.GetProjectID(&result.Volume)] = struct{}{}
+	}
+	return usedIDs, nil
+}
+
+// allocateProjectID returns a project ID not used by any other volume on the drive. Probing
+// starts from the hash of the volume name; an ID having quota usage on the filesystem is also
+// considered to be in use.
+func (n *NodeServer) allocateProjectID(ctx context.Context, volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) (uint32, error) {
+	usedIDs, err := n.getUsedProjectIDs(ctx, volume, drive)
+	if err != nil {
+		return 0, err
+	}
+
+	device := sys.GetDirectCSIPath(drive.Status.FilesystemUUID)
+	projectID := xfs.GetProjectID(volume.Name)
+	for i := uint64(0); i <= math.MaxUint32; i, projectID = i+1, projectID+1 {
+		if projectID == 0 {
+			continue
+		}
+		if _, found := usedIDs[projectID]; found {
+			continue
+		}
+		quota, err := n.quotaFuncs.GetQuota(ctx, drive.Status.Filesystem, device, projectID)
+		if err == nil && (quota.CurrentInodes != 0 || quota.HardLimit != 0) {
+			continue
+		}
+		return projectID, nil
+	}
+	return 0, fmt.Errorf("no free project ID found on drive %v", drive.Name)
+}
+
+// assignProjectID records the project ID of the volume in its status. Volumes staged before
+// project ID allocation keep the project ID hashed from their name.
+func (n *NodeServer) assignProjectID(ctx context.Context, volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) (*directcsi.DirectCSIVolume, error) {
+	if volume.Status.ProjectID != 0 {
+		return volume, nil
+	}
+
+	n.projectIDMutex.Lock()
+	defer n.projectIDMutex.Unlock()
+
+	var projectID uint32
+	if volume.Status.HostPath != "" {
+		projectID = xfs.GetProjectID(volume.Name)
+	} else {
+		var err error
+		if projectID, err = n.allocateProjectID(ctx, volume, drive); err != nil {
+			return nil, err
+		}
+	}
+
+	volume.Status.ProjectID = int64(projectID)
+	updatedVolume, err := n.directcsiClient.DirectV1beta3().DirectCSIVolumes().Update(ctx, volume, metav1.UpdateOptions{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	klog.V(3).InfoS("Assigned project ID", "volume", volume.Name, "drive", drive.Name, "ProjectID", projectID)
+	return updatedVolume, nil
+}
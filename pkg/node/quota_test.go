@@ -0,0 +1,122 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
+	"github.com/minio/direct-csi/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestExpandQuota(t *testing.T) {
+	testCases := []struct {
+		existing      *xfs.Quota
+		size          uint64
+		expectedQuota xfs.Quota
+	}{
+		{nil, 200, xfs.Quota{HardLimit: 200, SoftLimit: 200}},
+		{&xfs.Quota{}, 200, xfs.Quota{HardLimit: 200, SoftLimit: 200}},
+		{&xfs.Quota{HardLimit: 100, SoftLimit: 100}, 200, xfs.Quota{HardLimit: 200, SoftLimit: 200}},
+		{
+			&xfs.Quota{HardLimit: 100, SoftLimit: 80, HardInodeLimit: 50, SoftInodeLimit: 40, CurrentInodes: 10},
+			200,
+			xfs.Quota{HardLimit: 200, SoftLimit: 160, HardInodeLimit: 50, SoftInodeLimit: 40},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if quota := expandQuota(testCase.existing, testCase.size); quota != testCase.expectedQuota {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedQuota, quota)
+		}
+	}
+}
+
+func TestAssignProjectID(t *testing.T) {
+	newVolume := func(name, drive, hostPath string, projectID int64) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:  testNodeName,
+				Drive:     drive,
+				HostPath:  hostPath,
+				ProjectID: projectID,
+			},
+		}
+	}
+
+	hashID := xfs.GetProjectID("test-volume")
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "test-drive"},
+		Status:     directcsi.DirectCSIDriveStatus{NodeName: testNodeName},
+	}
+
+	testCases := []struct {
+		volume            *directcsi.DirectCSIVolume
+		otherVolumes      []*directcsi.DirectCSIVolume
+		usedProjectIDs    map[uint32]bool
+		expectedProjectID uint32
+	}{
+		{newVolume("test-volume", "test-drive", "", 0), nil, nil, hashID},
+		{newVolume("test-volume", "test-drive", "", 10), nil, nil, 10},
+		// legacy volume keeps the hashed project ID
+		{newVolume("test-volume", "test-drive", "/var/lib/direct-csi/mnt/test-volume", 0), []*directcsi.DirectCSIVolume{newVolume("volume-1", "test-drive", "", int64(hashID))}, nil, hashID},
+		// colliding project ID on the same drive
+		{newVolume("test-volume", "test-drive", "", 0), []*directcsi.DirectCSIVolume{newVolume("volume-1", "test-drive", "", int64(hashID))}, nil, hashID + 1},
+		{newVolume("test-volume", "test-drive", "", 0), []*directcsi.DirectCSIVolume{newVolume("volume-1", "test-drive", "", int64(hashID)), newVolume("volume-2", "test-drive", "", int64(hashID+1))}, nil, hashID + 2},
+		// colliding project ID on other drive
+		{newVolume("test-volume", "test-drive", "", 0), []*directcsi.DirectCSIVolume{newVolume("volume-1", "other-drive", "", int64(hashID))}, nil, hashID},
+		// project ID having quota usage on the filesystem
+		{newVolume("test-volume", "test-drive", "", 0), nil, map[uint32]bool{hashID: true}, hashID + 1},
+	}
+
+	for i, testCase := range testCases {
+		objects := []runtime.Object{testCase.volume}
+		for _, volume := range testCase.otherVolumes {
+			objects = append(objects, volume)
+		}
+		ns := createFakeNodeServer()
+		ns.directcsiClient = fakedirect.NewSimpleClientset(objects...)
+		ns.quotaFuncs = &fakeQuotaFuncs{usedProjectIDs: testCase.usedProjectIDs}
+
+		volume, err := ns.assignProjectID(context.TODO(), testCase.volume.DeepCopy(), drive)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if volume.Status.ProjectID != int64(testCase.expectedProjectID) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedProjectID, volume.Status.ProjectID)
+		}
+
+		volume, err = ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), testCase.volume.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if volume.Status.ProjectID != int64(testCase.expectedProjectID) {
+			t.Fatalf("case %v: persisted: expected: %v, got: %v", i+1, testCase.expectedProjectID, volume.Status.ProjectID)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const mountReconcileInterval = time.Minute
+
+type conditionUpdate struct {
+	condType directcsi.DirectCSIVolumeCondition
+	status   bool
+	reason   directcsi.DirectCSIVolumeReason
+	message  string
+}
+
+// reconcileVolumeMounts re-establishes missing staging mount of the volume. Missing staging mount which
+// cannot be repaired and missing container mount are reported in volume conditions and events.
+func (n *NodeServer) reconcileVolumeMounts(ctx context.Context, vol *directcsi.DirectCSIVolume, mountPoints map[string]struct{}, probeMounts func() (map[string][]sys.MountInfo, error)) (updates []conditionUpdate) {
+	setCondition := func(condType directcsi.DirectCSIVolumeCondition, status bool, reason directcsi.DirectCSIVolumeReason, message string) bool {
+		if utils.IsCondition(vol.Status.Conditions, string(condType), utils.BoolToCondition(status), string(reason), message) {
+			return false
+		}
+		updates = append(updates, conditionUpdate{condType, status, reason, message})
+		return true
+	}
+
+	stagingMounted := true
+	if !vol.Status.BlockMode && vol.Status.StagingPath != "" {
+		_, stagingMounted = mountPoints[vol.Status.StagingPath]
+	}
+
+	if !stagingMounted {
+		err := n.restoreStagingMount(ctx, vol, probeMounts)
+		if err != nil {
+			klog.ErrorS(err, "unable to restore staging mount", "volume", vol.Name, "StagingPath", vol.Status.StagingPath)
+			if setCondition(directcsi.DirectCSIVolumeConditionStaged, false, directcsi.DirectCSIVolumeReasonNotReady, err.Error()) {
+				utils.Eventf(vol, corev1.EventTypeWarning, "StagingMountFailed", "unable to restore staging mount %v; %v", vol.Status.StagingPath, err)
+			}
+		} else {
+			klog.V(3).InfoS("Restored staging mount", "volume", vol.Name, "StagingPath", vol.Status.StagingPath)
+			setCondition(directcsi.DirectCSIVolumeConditionStaged, true, directcsi.DirectCSIVolumeReasonInUse, "")
+			utils.Eventf(vol, corev1.EventTypeNormal, "StagingMountRestored", "staging mount %v is restored", vol.Status.StagingPath)
+			stagingMounted = true
+		}
+	}
+
+	// Container mounts are owned by kubelet, which republishes the volume.
+	if vol.Status.ContainerPath != "" {
+		if _, found := mountPoints[vol.Status.ContainerPath]; !found {
+			message := fmt.Sprintf("container path %v is not mounted", vol.Status.ContainerPath)
+			if !stagingMounted {
+				message = fmt.Sprintf("%v; staging path %v is not mounted", message, vol.Status.StagingPath)
+			}
+			if setCondition(directcsi.DirectCSIVolumeConditionPublished, false, directcsi.DirectCSIVolumeReasonNotReady, message) {
+				utils.Eventf(vol, corev1.EventTypeWarning, "ContainerMountMissing", "%v", message)
+			}
+		}
+	}
+
+	return updates
+}
+
+// restoreStagingMount bind mounts the volume on its staging path if its drive is in use and mounted.
+func (n *NodeServer) restoreStagingMount(ctx context.Context, vol *directcsi.DirectCSIVolume, probeMounts func() (map[string][]sys.MountInfo, error)) error {
+	drive, err := n.directcsiClient.DirectV1beta3().DirectCSIDrives().Get(ctx, vol.Status.Drive, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := checkDrive(drive, vol.Name, probeMounts); err != nil {
+		return err
+	}
+	if vol.Status.HostPath == "" {
+		return fmt.Errorf("host path of volume %v is not set", vol.Name)
+	}
+	mountOpts, err := sys.ParseVolumeMountFlags(vol.Status.MountOptions)
+	if err != nil {
+		return err
+	}
+	return n.mounter.MountVolume(ctx, vol.Status.HostPath, vol.Status.StagingPath, false, mountOpts)
+}
+
+// getMountPoints returns mount points of the node.
+func getMountPoints(probeMounts func() (map[string][]sys.MountInfo, error)) (map[string]struct{}, error) {
+	mounts, err := probeMounts()
+	if err != nil {
+		return nil, err
+	}
+	mountPoints := map[string]struct{}{}
+	for _, mountInfos := range mounts {
+		for _, mountInfo := range mountInfos {
+			mountPoints[mountInfo.MountPoint] = struct{}{}
+		}
+	}
+	return mountPoints, nil
+}
+
+// isMountMissing returns whether staging or container path of the volume is not mounted.
+func isMountMissing(vol *directcsi.DirectCSIVolume, mountPoints map[string]struct{}) bool {
+	if !vol.Status.BlockMode && vol.Status.StagingPath != "" {
+		if _, found := mountPoints[vol.Status.StagingPath]; !found {
+			return true
+		}
+	}
+	if vol.Status.ContainerPath != "" {
+		if _, found := mountPoints[vol.Status.ContainerPath]; !found {
+			return true
+		}
+	}
+	return false
+}
+
+// repairVolumeMounts reconciles mounts of the volume. Node requests must not change
+// the volume or its mounts meanwhile, hence both are read again under mount mutex.
+func (n *NodeServer) repairVolumeMounts(ctx context.Context, volumeName string, probeMounts func() (map[string][]sys.MountInfo, error)) ([]conditionUpdate, error) {
+	n.mountMutex.Lock()
+	defer n.mountMutex.Unlock()
+
+	vol, err := n.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, volumeName, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mountPoints, err := getMountPoints(probeMounts)
+	if err != nil {
+		return nil, err
+	}
+	if !isMountMissing(vol, mountPoints) {
+		return nil, nil
+	}
+
+	return n.reconcileVolumeMounts(ctx, vol, mountPoints, probeMounts), nil
+}
+
+// reconcileMounts compares mounts of the node against staging and container paths of its volumes.
+func (n *NodeServer) reconcileMounts(ctx context.Context, probeMounts func() (map[string][]sys.MountInfo, error)) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(n.NodeID)
+	if err != nil {
+		return err
+	}
+
+	vclient := n.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	resultCh, err := utils.ListVolumes(ctx, vclient, []utils.LabelValue{nodeLabelValue}, nil, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+
+	var volumes []directcsi.DirectCSIVolume
+	for result := range resultCh {
+		if result.Err != nil {
+			return result.Err
+		}
+		if result.Volume.Status.StagingPath != "" || result.Volume.Status.ContainerPath != "" {
+			volumes = append(volumes, result.Volume)
+		}
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	mountPoints, err := getMountPoints(probeMounts)
+	if err != nil {
+		return err
+	}
+
+	for i := range volumes {
+		// Only volumes missing a mount are repaired under mount mutex.
+		if !isMountMissing(&volumes[i], mountPoints) {
+			continue
+		}
+
+		updates, err := n.repairVolumeMounts(ctx, volumes[i].Name, probeMounts)
+		if err != nil {
+			klog.ErrorS(err, "unable to reconcile volume mounts", "volume", volumes[i].Name)
+			continue
+		}
+		if len(updates) == 0 {
+			continue
+		}
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			vol, err := vclient.Get(ctx, volumes[i].Name, metav1.GetOptions{
+				TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			})
+			if err != nil {
+				return err
+			}
+			for _, update := range updates {
+				utils.UpdateCondition(vol.Status.Conditions, string(update.condType), utils.BoolToCondition(update.status), string(update.reason), update.message)
+			}
+			_, err = vclient.Update(ctx, vol, metav1.UpdateOptions{
+				TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			})
+			return err
+		}); err != nil {
+			klog.ErrorS(err, "unable to update volume conditions", "volume", volumes[i].Name)
+		}
+	}
+
+	return nil
+}
+
+// startMountReconciler reconciles mounts at startup, i.e. after node reboot, and periodically thereafter.
+func (n *NodeServer) startMountReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.reconcileMounts(ctx, sys.ProbeMounts); err != nil {
+			klog.ErrorS(err, "unable to reconcile volume mounts")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
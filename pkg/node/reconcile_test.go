@@ -0,0 +1,160 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileMounts(t *testing.T) {
+	utils.FakeInit()
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-drive",
+			Finalizers: []string{directcsi.DirectCSIDriveFinalizerPrefix + "test-volume"},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:       testNodeName,
+			DriveStatus:    directcsi.DriveStatusInUse,
+			FilesystemUUID: "test-fsuuid",
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-volume",
+			Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+		},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "test-drive",
+			HostPath:      "/var/lib/direct-csi/mnt/test-fsuuid/test-volume",
+			StagingPath:   "/path/to/staging",
+			ContainerPath: "/path/to/container",
+			MountOptions:  []string{"nodev", "noatime"},
+			Conditions: []metav1.Condition{
+				{Type: string(directcsi.DirectCSIVolumeConditionStaged), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIVolumeReasonInUse)},
+				{Type: string(directcsi.DirectCSIVolumeConditionPublished), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIVolumeReasonInUse)},
+				{Type: string(directcsi.DirectCSIVolumeConditionReady), Status: metav1.ConditionTrue, Reason: string(directcsi.DirectCSIVolumeReasonReady)},
+			},
+		},
+	}
+	driveMount := sys.MountInfo{MountPoint: filepath.Join(sys.MountRoot, "test-fsuuid")}
+	stagingMount := sys.MountInfo{MountPoint: "/path/to/staging"}
+	containerMount := sys.MountInfo{MountPoint: "/path/to/container"}
+
+	testCases := []struct {
+		name              string
+		mounts            map[string][]sys.MountInfo
+		expectedMount     bool
+		expectedStaged    bool
+		expectedPublished bool
+	}{
+		{"mounted", map[string][]sys.MountInfo{"0:0": {driveMount, stagingMount, containerMount}}, false, true, true},
+		{"missing staging mount", map[string][]sys.MountInfo{"0:0": {driveMount, containerMount}}, true, true, true},
+		{"missing drive mount", map[string][]sys.MountInfo{"0:0": {containerMount}}, false, false, true},
+		{"missing container mount", map[string][]sys.MountInfo{"0:0": {driveMount, stagingMount}}, false, true, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ns := createFakeNodeServer()
+			ns.directcsiClient = fakedirect.NewSimpleClientset(drive.DeepCopy(), volume.DeepCopy())
+			mounter := &fakeVolumeMounter{}
+			ns.mounter = mounter
+
+			probeMounts := func() (map[string][]sys.MountInfo, error) { return testCase.mounts, nil }
+			if err := ns.reconcileMounts(context.TODO(), probeMounts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if mounted := mounter.mountArgs.destination != ""; mounted != testCase.expectedMount {
+				t.Fatalf("mount: expected: %v, got: %+v", testCase.expectedMount, mounter.mountArgs)
+			}
+			if testCase.expectedMount && (mounter.mountArgs.source != volume.Status.HostPath || mounter.mountArgs.destination != volume.Status.StagingPath ||
+				!reflect.DeepEqual(mounter.mountArgs.mountOpts, []sys.MountOption{sys.MountOptionMSNoDev, sys.MountOptionMSNoATime})) {
+				t.Fatalf("unexpected mount arguments %+v", mounter.mountArgs)
+			}
+
+			vol, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), "test-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			conditions := vol.Status.Conditions
+			if staged := utils.IsConditionStatus(conditions, string(directcsi.DirectCSIVolumeConditionStaged), metav1.ConditionTrue); staged != testCase.expectedStaged {
+				t.Fatalf("staged: expected: %v, got: %+v", testCase.expectedStaged, conditions)
+			}
+			if published := utils.IsConditionStatus(conditions, string(directcsi.DirectCSIVolumeConditionPublished), metav1.ConditionTrue); published != testCase.expectedPublished {
+				t.Fatalf("published: expected: %v, got: %+v", testCase.expectedPublished, conditions)
+			}
+		})
+	}
+}
+
+func TestReconcileMountsUnstagedVolume(t *testing.T) {
+	utils.FakeInit()
+
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-volume",
+			Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+		},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:    testNodeName,
+			Drive:       "test-drive",
+			HostPath:    "/var/lib/direct-csi/mnt/test-fsuuid/test-volume",
+			StagingPath: "/path/to/staging",
+		},
+	}
+
+	ns := createFakeNodeServer()
+	ns.directcsiClient = fakedirect.NewSimpleClientset(volume)
+	mounter := &fakeVolumeMounter{}
+	ns.mounter = mounter
+
+	probed := false
+	probeMounts := func() (map[string][]sys.MountInfo, error) {
+		if !probed {
+			probed = true
+			// Simulate the volume being unstaged after the mounts are probed.
+			vol := volume.DeepCopy()
+			vol.Status.StagingPath = ""
+			if _, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Update(context.TODO(), vol, metav1.UpdateOptions{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		return map[string][]sys.MountInfo{}, nil
+	}
+	if err := ns.reconcileMounts(context.TODO(), probeMounts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mounter.mountArgs.destination != "" {
+		t.Fatalf("unstaged volume must not be mounted; got %+v", mounter.mountArgs)
+	}
+}
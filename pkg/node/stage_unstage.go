@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/fs/xfs"
@@ -48,6 +49,23 @@ func (n *NodeServer) nodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 	if stagingTargetPath == "" {
 		return nil, status.Error(codes.InvalidArgument, "stagingTargetPath missing in request")
 	}
+	mountFlags, err := sys.ParseVolumeMountFlags(req.GetVolumeCapability().GetMount().GetMountFlags())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	mountGroup := -1
+	if group := req.GetVolumeCapability().GetMount().GetVolumeMountGroup(); group != "" {
+		if mountGroup, err = strconv.Atoi(group); err != nil || mountGroup < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid volume mount group %v", group)
+		}
+	}
+	// Staging mount is kept writable to set quota; read-only is applied on publish.
+	var mountOpts []sys.MountOption
+	for _, mountFlag := range mountFlags {
+		if mountFlag != sys.MountOptionMSReadOnly {
+			mountOpts = append(mountOpts, mountFlag)
+		}
+	}
 
 	directCSIClient := n.directcsiClient.DirectV1beta3()
 	dclient := directCSIClient.DirectCSIDrives()
@@ -59,6 +77,9 @@ func (n *NodeServer) nodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	if isVolumeEvacuating(vol) {
+		return nil, status.Errorf(codes.Unavailable, "volume %v is being evacuated", vID)
+	}
 
 	drive, err := dclient.Get(ctx, vol.Status.Drive, metav1.GetOptions{
 		TypeMeta: utils.DirectCSIDriveTypeMeta(),
@@ -71,21 +92,47 @@ func (n *NodeServer) nodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	path := filepath.Join(drive.Status.Mountpoint, vID)
-	if err := os.MkdirAll(path, 0755); err != nil {
-		return nil, err
-	}
+	var path string
+	if vol.Status.BlockMode {
+		var partNum int
+		if path, partNum, err = n.makeBlockDevice(drive, vol); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to create block device of volume %v: %v", vID, err)
+		}
+		vol.Status.PartitionNum = partNum
+	} else {
+		path = filepath.Join(drive.Status.Mountpoint, vID)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return nil, err
+		}
 
-	if err := n.mounter.MountVolume(ctx, path, stagingTargetPath, false); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed stage volume: %v", err)
-	}
+		if err := n.mounter.MountVolume(ctx, path, stagingTargetPath, false, mountOpts); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed stage volume: %v", err)
+		}
 
-	quota := xfs.Quota{
-		HardLimit: uint64(vol.Status.TotalCapacity),
-		SoftLimit: uint64(vol.Status.TotalCapacity),
-	}
-	if err := n.quotaFuncs.SetQuota(ctx, sys.GetDirectCSIPath(drive.Status.FilesystemUUID), stagingTargetPath, vID, quota); err != nil {
-		return nil, status.Errorf(codes.Internal, "Error while setting xfs limits: %v", err)
+		quota, err := xfs.NewQuota(uint64(vol.Status.TotalCapacity), req.GetVolumeContext())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if vol, err = n.assignProjectID(ctx, vol, drive); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to allocate project ID of volume %v: %v", vID, err)
+		}
+		if err := n.quotaFuncs.SetQuota(ctx, drive.Status.Filesystem, sys.GetDirectCSIPath(drive.Status.FilesystemUUID), stagingTargetPath, uint32(vol.Status.ProjectID), *quota); err != nil {
+			return nil, status.Errorf(codes.Internal, "Error while setting quota limits: %v", err)
+		}
+
+		if (vol.Status.SourceVolume != "" || vol.Status.SourceSnapshot != "") && !vol.Status.ContentPopulated {
+			if err := n.populateVolume(ctx, vol, drive, path); err != nil {
+				return nil, err
+			}
+			vol.Status.ContentPopulated = true
+		}
+
+		// kubelet delegates fsGroup of the pod to the driver by volume mount group.
+		if mountGroup >= 0 {
+			if err := setVolumeMountGroup(path, mountGroup); err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to set group %v of volume %v: %v", mountGroup, vID, err)
+			}
+		}
 	}
 
 	conditions := vol.Status.Conditions
@@ -98,11 +145,19 @@ func (n *NodeServer) nodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 		case string(directcsi.DirectCSIVolumeConditionStaged):
 			conditions[i].Status = utils.BoolToCondition(true)
 			conditions[i].Reason = string(directcsi.DirectCSIVolumeReasonInUse)
+			conditions[i].Message = ""
 		}
 	}
 
 	vol.Status.HostPath = path
 	vol.Status.StagingPath = stagingTargetPath
+	// Mount options are kept to restore the staging mount with them.
+	vol.Status.MountOptions = nil
+	if !vol.Status.BlockMode {
+		for _, mountOpt := range mountOpts {
+			vol.Status.MountOptions = append(vol.Status.MountOptions, string(mountOpt))
+		}
+	}
 
 	if _, err := vclient.Update(ctx, vol, metav1.UpdateOptions{
 		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
@@ -113,13 +168,53 @@ func (n *NodeServer) nodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 	return &csi.NodeStageVolumeResponse{}, nil
 }
 
+// populateVolume copies the content of volume's source into path.
+func (n *NodeServer) populateVolume(ctx context.Context, vol *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive, path string) error {
+	var source string
+	switch {
+	case vol.Status.SourceVolume != "":
+		if _, err := n.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(ctx, vol.Status.SourceVolume, metav1.GetOptions{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+		}); err != nil {
+			if errors.IsNotFound(err) {
+				return status.Errorf(codes.FailedPrecondition, "source volume %v not found", vol.Status.SourceVolume)
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		source = filepath.Join(drive.Status.Mountpoint, vol.Status.SourceVolume)
+	default:
+		snapshot, err := n.directcsiClient.DirectV1beta3().DirectCSISnapshots().Get(ctx, vol.Status.SourceSnapshot, metav1.GetOptions{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+		})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return status.Errorf(codes.FailedPrecondition, "source snapshot %v not found", vol.Status.SourceSnapshot)
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		source = snapshot.Status.HostPath
+	}
+
+	klog.V(3).InfoS("Populating volume", "volumeID", vol.Name, "source", source)
+	if err := n.reflinkCopy(ctx, source, path); err != nil {
+		return status.Errorf(codes.Internal, "unable to populate volume %v from %v; %v", vol.Name, source, err)
+	}
+	return nil
+}
+
 // NodeStageVolume is node stage volume request handler.
 func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolumeRequest) (*csi.NodeStageVolumeResponse, error) {
+	n.mountMutex.RLock()
+	defer n.mountMutex.RUnlock()
+
 	return n.nodeStageVolume(ctx, req, sys.ProbeMounts)
 }
 
 // NodeUnstageVolume is node unstage volume request handler.
 func (n *NodeServer) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstageVolumeRequest) (*csi.NodeUnstageVolumeResponse, error) {
+	n.mountMutex.RLock()
+	defer n.mountMutex.RUnlock()
+
 	klog.V(3).InfoS("NodeUnstageVolumeRequest",
 		"volumeID", req.GetVolumeId(),
 		"StagingTargetPath", req.GetStagingTargetPath())
@@ -163,6 +258,7 @@ func (n *NodeServer) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstage
 	}
 
 	vol.Status.StagingPath = ""
+	vol.Status.MountOptions = nil
 	if _, err := directCSIClient.DirectCSIVolumes().Update(ctx, vol, metav1.UpdateOptions{
 		TypeMeta: utils.DirectCSIVolumeTypeMeta(),
 	}); err != nil {
@@ -20,11 +20,16 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
 	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
@@ -180,13 +185,19 @@ func TestStageUnstageVolume(t *testing.T) {
 		VolumeCapability: &csi.VolumeCapability{
 			AccessType: &csi.VolumeCapability_Mount{
 				Mount: &csi.VolumeCapability_MountVolume{
-					FsType: "xfs",
+					FsType:           "xfs",
+					MountFlags:       []string{"ro", "nodev"},
+					VolumeMountGroup: strconv.Itoa(os.Getgid()),
 				},
 			},
 			AccessMode: &csi.VolumeCapability_AccessMode{
 				Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
 			},
 		},
+		VolumeContext: map[string]string{
+			xfs.InodeLimitKey:          "1000",
+			xfs.SoftLimitPercentageKey: "90",
+		},
 	}
 
 	unstageVolumeRequest := csi.NodeUnstageVolumeRequest{
@@ -225,6 +236,18 @@ func TestStageUnstageVolume(t *testing.T) {
 	if ns.mounter.(*fakeVolumeMounter).mountArgs.readOnly {
 		t.Errorf("Wrong readOnly argument passed for mounting. Expected: False, Got: %v", ns.mounter.(*fakeVolumeMounter).mountArgs.readOnly)
 	}
+	if mountOpts := ns.mounter.(*fakeVolumeMounter).mountArgs.mountOpts; !reflect.DeepEqual(mountOpts, []sys.MountOption{sys.MountOptionMSNoDev}) {
+		t.Errorf("Wrong mount options passed for mounting. Expected: [nodev], Got: %v", mountOpts)
+	}
+
+	// Check if quota was set by volume context
+	expectedQuota := xfs.Quota{HardLimit: uint64(mb20), SoftLimit: uint64(mb20) * 90 / 100, HardInodeLimit: 1000, SoftInodeLimit: 900}
+	if quota := ns.quotaFuncs.(*fakeQuotaFuncs).setQuotaArgs.quota; quota != expectedQuota {
+		t.Errorf("Wrong quota set. Expected: %+v, Got: %+v", expectedQuota, quota)
+	}
+	if projectID := ns.quotaFuncs.(*fakeQuotaFuncs).setQuotaArgs.projectID; projectID == 0 || int64(projectID) != volObj.Status.ProjectID {
+		t.Errorf("Wrong project ID. Expected: %v, Got: %v", volObj.Status.ProjectID, projectID)
+	}
 
 	// Check if status fields were set correctly
 	if volObj.Status.HostPath != hostPath {
@@ -233,6 +256,16 @@ func TestStageUnstageVolume(t *testing.T) {
 	if volObj.Status.StagingPath != stageVolumeRequest.GetStagingTargetPath() {
 		t.Errorf("Wrong StagingPath set in the volume object. Expected %v, Got: %v", stageVolumeRequest.GetStagingTargetPath(), volObj.Status.StagingPath)
 	}
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		t.Fatalf("unable to stat %v; %v", hostPath, err)
+	}
+	if info.Mode()&os.ModeSetgid == 0 || info.Mode().Perm()&0070 != 0070 {
+		t.Errorf("Volume mount group not applied to %v; mode: %v", hostPath, info.Mode())
+	}
+	if !reflect.DeepEqual(volObj.Status.MountOptions, []string{"nodev"}) {
+		t.Errorf("Wrong MountOptions set in the volume object. Expected [nodev], Got: %v", volObj.Status.MountOptions)
+	}
 
 	// Check if conditions were toggled correctly
 	if !utils.IsCondition(volObj.Status.Conditions, string(directcsi.DirectCSIVolumeConditionStaged), metav1.ConditionTrue, string(directcsi.DirectCSIVolumeReasonInUse), "") {
@@ -260,9 +293,252 @@ func TestStageUnstageVolume(t *testing.T) {
 	if volObj.Status.StagingPath != "" {
 		t.Errorf("StagingPath was not set to empty. Got: %v", volObj.Status.StagingPath)
 	}
+	if volObj.Status.MountOptions != nil {
+		t.Errorf("MountOptions was not cleared. Got: %v", volObj.Status.MountOptions)
+	}
 
 	// Check if conditions were toggled correctly
 	if !utils.IsCondition(volObj.Status.Conditions, string(directcsi.DirectCSIVolumeConditionStaged), metav1.ConditionFalse, string(directcsi.DirectCSIVolumeReasonNotInUse), "") {
 		t.Errorf("unexpected status.conditions after unstaging = %v", volObj.Status.Conditions)
 	}
 }
+
+func TestStageVolumeFromContentSource(t *testing.T) {
+	testMountPointDir, err := os.MkdirTemp("", "test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testMountPointDir)
+
+	newDrive := func() *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-drive",
+				Finalizers: []string{
+					directcsi.DirectCSIDriveFinalizerPrefix + "source-volume",
+					directcsi.DirectCSIDriveFinalizerPrefix + "clone-volume",
+				},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				Mountpoint:  testMountPointDir,
+				NodeName:    testNodeName,
+				DriveStatus: directcsi.DriveStatusInUse,
+			},
+		}
+	}
+	newVolume := func(name, sourceVolume, sourceSnapshot string) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:       testNodeName,
+				Drive:          "test-drive",
+				TotalCapacity:  mb20,
+				SourceVolume:   sourceVolume,
+				SourceSnapshot: sourceSnapshot,
+			},
+		}
+	}
+	snapshot := &directcsi.DirectCSISnapshot{
+		TypeMeta:   utils.DirectCSISnapshotTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "test-snapshot"},
+		Status: directcsi.DirectCSISnapshotStatus{
+			Drive:    "test-drive",
+			HostPath: filepath.Join(testMountPointDir, ".snapshots", "test-snapshot"),
+		},
+	}
+	probeMounts := func() (map[string][]sys.MountInfo, error) {
+		return map[string][]sys.MountInfo{"0:0": {{MountPoint: "/var/lib/direct-csi/mnt"}}}, nil
+	}
+
+	testCases := []struct {
+		objects        []runtime.Object
+		expectedSource string
+		expectErr      bool
+	}{
+		{
+			objects:        []runtime.Object{newDrive(), newVolume("source-volume", "", ""), newVolume("clone-volume", "source-volume", "")},
+			expectedSource: filepath.Join(testMountPointDir, "source-volume"),
+		},
+		{
+			objects:        []runtime.Object{newDrive(), snapshot, newVolume("clone-volume", "", "test-snapshot")},
+			expectedSource: snapshot.Status.HostPath,
+		},
+		{
+			objects:   []runtime.Object{newDrive(), newVolume("clone-volume", "source-volume", "")},
+			expectErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var copyArgs []string
+		ns := createFakeNodeServer()
+		ns.directcsiClient = fakedirect.NewSimpleClientset(testCase.objects...)
+		ns.reflinkCopy = func(_ context.Context, source, target string) error {
+			copyArgs = append(copyArgs, source, target)
+			return nil
+		}
+
+		req := &csi.NodeStageVolumeRequest{VolumeId: "clone-volume", StagingTargetPath: "/path/to/target"}
+		// Stage twice to ensure the content is populated only once.
+		for j := 0; j < 2; j++ {
+			_, err := ns.nodeStageVolume(context.TODO(), req, probeMounts)
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("case %v: expected error, but succeeded", i+1)
+				}
+				break
+			}
+			if err != nil {
+				t.Fatalf("case %v: run %v: unexpected error %v", i+1, j+1, err)
+			}
+		}
+		if testCase.expectErr {
+			continue
+		}
+
+		expectedArgs := []string{testCase.expectedSource, filepath.Join(testMountPointDir, "clone-volume")}
+		if !reflect.DeepEqual(copyArgs, expectedArgs) {
+			t.Fatalf("case %v: expected copy arguments: %v, got: %v", i+1, expectedArgs, copyArgs)
+		}
+
+		volume, err := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes().Get(context.TODO(), "clone-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: volume fetch error %v", i+1, err)
+		}
+		if !volume.Status.ContentPopulated {
+			t.Fatalf("case %v: expected volume content to be populated", i+1)
+		}
+	}
+}
+
+func TestStagePublishBlockVolume(t *testing.T) {
+	testDir, err := os.MkdirTemp("", "test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-drive",
+			Finalizers: []string{directcsi.DirectCSIDriveFinalizerPrefix + "block-volume"},
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:      testNodeName,
+			DriveStatus:   directcsi.DriveStatusInUse,
+			BlockMode:     true,
+			TotalCapacity: mb100,
+		},
+	}
+	volume := &directcsi.DirectCSIVolume{
+		TypeMeta:   utils.DirectCSIVolumeTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "block-volume"},
+		Status: directcsi.DirectCSIVolumeStatus{
+			NodeName:      testNodeName,
+			Drive:         "test-drive",
+			TotalCapacity: mb20,
+			BlockMode:     true,
+		},
+	}
+
+	mounter := &fakeVolumeMounter{}
+	quotaFuncs := &fakeQuotaFuncs{}
+	ns := createFakeNodeServer()
+	ns.mounter = mounter
+	ns.quotaFuncs = quotaFuncs
+	ns.directcsiClient = fakedirect.NewSimpleClientset(drive, volume)
+	probeMounts := func() (map[string][]sys.MountInfo, error) {
+		return nil, nil
+	}
+
+	stagingPath := filepath.Join(testDir, "staging")
+	_, err = ns.nodeStageVolume(context.TODO(), &csi.NodeStageVolumeRequest{VolumeId: "block-volume", StagingTargetPath: stagingPath}, probeMounts)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if mounter.mountArgs.source != "" || quotaFuncs.setQuotaArgs.projectID != 0 {
+		t.Fatalf("block volume must not be mounted or quota set; mount args: %+v, quota args: %+v", mounter.mountArgs, quotaFuncs.setQuotaArgs)
+	}
+
+	vclient := ns.directcsiClient.DirectV1beta3().DirectCSIVolumes()
+	volume, err = vclient.Get(context.TODO(), "block-volume", metav1.GetOptions{TypeMeta: utils.DirectCSIVolumeTypeMeta()})
+	if err != nil {
+		t.Fatalf("volume fetch error %v", err)
+	}
+	device := sys.GetDirectCSIPath("block-volume")
+	if volume.Status.HostPath != device || volume.Status.PartitionNum != 1 || volume.Status.StagingPath != stagingPath {
+		t.Fatalf("unexpected volume status %+v", volume.Status)
+	}
+
+	targetPath := filepath.Join(testDir, "pod", "block-volume")
+	_, err = ns.nodePublishVolume(context.TODO(), &csi.NodePublishVolumeRequest{
+		VolumeId:          "block-volume",
+		StagingTargetPath: stagingPath,
+		TargetPath:        targetPath,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+		Readonly: true,
+	}, probeMounts)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if mounter.mountArgs.source != device || mounter.mountArgs.destination != targetPath || !mounter.mountArgs.readOnly {
+		t.Fatalf("unexpected mount args %+v", mounter.mountArgs)
+	}
+	if info, err := os.Stat(targetPath); err != nil || info.IsDir() {
+		t.Fatalf("expected target file to be created; %v", err)
+	}
+}
+
+func TestStageVolumeInvalidMountGroup(t *testing.T) {
+	req := &csi.NodeStageVolumeRequest{
+		VolumeId:          "test-volume",
+		StagingTargetPath: "/path/to/target",
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{
+				Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs", VolumeMountGroup: "staff"},
+			},
+		},
+	}
+	_, err := createFakeNodeServer().nodeStageVolume(context.TODO(), req, nil)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected error code %v, got %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestSetVolumeMountGroup(t *testing.T) {
+	path := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(path, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "dir", "file"), []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setVolumeMountGroup(path, os.Getgid()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		expectedMode os.FileMode
+	}{
+		{path, os.ModeSetgid | 0770},
+		{filepath.Join(path, "dir"), os.ModeSetgid | 0770},
+		{filepath.Join(path, "dir", "file"), 0660},
+	}
+	for _, testCase := range testCases {
+		info, err := os.Stat(testCase.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode() & (os.ModePerm | os.ModeSetgid); mode != testCase.expectedMode {
+			t.Fatalf("%v: expected mode %v, got %v", testCase.name, testCase.expectedMode, mode)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/listener"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// nodeTopology holds topology segments of this node. Segments of configured
+// node label keys are refreshed whenever labels of the Node object change.
+type nodeTopology struct {
+	mutex        sync.RWMutex
+	baseSegments map[string]string
+	labelKeys    []string
+	segments     map[string]string
+}
+
+func newNodeTopology(baseSegments map[string]string, labelKeys []string) *nodeTopology {
+	return &nodeTopology{
+		baseSegments: baseSegments,
+		labelKeys:    labelKeys,
+		segments:     getTopologySegments(baseSegments, labelKeys, nil),
+	}
+}
+
+// getTopologySegments returns base segments along with values of label keys
+// found in node labels. Identity and node segments are never overridden.
+func getTopologySegments(baseSegments map[string]string, labelKeys []string, nodeLabels map[string]string) map[string]string {
+	segments := map[string]string{}
+	for key, value := range baseSegments {
+		segments[key] = value
+	}
+
+	for _, key := range labelKeys {
+		if key == utils.TopologyDriverIdentity || key == utils.TopologyDriverNode {
+			continue
+		}
+		if value, found := nodeLabels[key]; found {
+			segments[key] = value
+		}
+	}
+
+	return segments
+}
+
+// get returns a copy of current topology segments.
+func (topology *nodeTopology) get() map[string]string {
+	topology.mutex.RLock()
+	defer topology.mutex.RUnlock()
+
+	segments := map[string]string{}
+	for key, value := range topology.segments {
+		segments[key] = value
+	}
+	return segments
+}
+
+// update refreshes topology segments by node labels and returns whether they are changed.
+func (topology *nodeTopology) update(nodeLabels map[string]string) bool {
+	topology.mutex.Lock()
+	defer topology.mutex.Unlock()
+
+	segments := getTopologySegments(topology.baseSegments, topology.labelKeys, nodeLabels)
+	if reflect.DeepEqual(segments, topology.segments) {
+		return false
+	}
+
+	topology.segments = segments
+	return true
+}
+
+// syncDriveTopology sets current topology segments to all drives of this node.
+func syncDriveTopology(ctx context.Context, directCSIClient clientset.Interface, nodeID string, topology *nodeTopology) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(nodeID)
+	if err != nil {
+		return err
+	}
+
+	driveInterface := directCSIClient.DirectV1beta3().DirectCSIDrives()
+	resultCh, err := utils.ListDrives(ctx, driveInterface, []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return err
+	}
+
+	segments := topology.get()
+	for result := range resultCh {
+		if result.Err != nil {
+			return result.Err
+		}
+
+		if reflect.DeepEqual(result.Drive.Status.Topology, segments) {
+			continue
+		}
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			drive, err := driveInterface.Get(ctx, result.Drive.Name, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			if err != nil {
+				return err
+			}
+			drive.Status.Topology = segments
+			_, err = driveInterface.Update(ctx, drive, metav1.UpdateOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+			return err
+		}); err != nil {
+			return err
+		}
+		klog.V(3).InfoS("Updated drive topology", "Drive", result.Drive.Name, "Topology", segments)
+	}
+
+	return nil
+}
+
+type nodeEventHandler struct {
+	kubeClient      kubernetes.Interface
+	directCSIClient clientset.Interface
+	nodeID          string
+	topology        *nodeTopology
+}
+
+func (handler *nodeEventHandler) ListerWatcher() cache.ListerWatcher {
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", handler.nodeID).String()
+	}
+
+	return cache.NewFilteredListWatchFromClient(
+		handler.kubeClient.CoreV1().RESTClient(),
+		"nodes",
+		"",
+		optionsModifier,
+	)
+}
+
+func (handler *nodeEventHandler) KubeClient() kubernetes.Interface {
+	return handler.kubeClient
+}
+
+func (handler *nodeEventHandler) Name() string {
+	return "node-topology"
+}
+
+func (handler *nodeEventHandler) ObjectType() runtime.Object {
+	return &corev1.Node{}
+}
+
+func (handler *nodeEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent:
+		node := args.Object.(*corev1.Node)
+		if handler.topology.update(node.GetLabels()) {
+			klog.V(3).InfoS("Node topology changed", "NodeID", handler.nodeID, "Topology", handler.topology.get())
+		} else if args.Event == listener.UpdateEvent {
+			return nil
+		}
+		return syncDriveTopology(ctx, handler.directCSIClient, handler.nodeID, handler.topology)
+	}
+	return nil
+}
+
+// startTopologyController reads topology segments from node labels and keeps
+// this node topology and its drives in sync with label changes.
+func startTopologyController(ctx context.Context, nodeID string, topology *nodeTopology) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	handler := &nodeEventHandler{
+		kubeClient:      utils.GetKubeClient(),
+		directCSIClient: utils.GetDirectClientset(),
+		nodeID:          nodeID,
+		topology:        topology,
+	}
+	return listener.NewListener(handler, "node-topology-controller", hostname, 1).Run(ctx)
+}
@@ -0,0 +1,134 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeTopologyUpdate(t *testing.T) {
+	baseSegments := map[string]string{
+		utils.TopologyDriverIdentity: "test-identity",
+		utils.TopologyDriverZone:     "default",
+		utils.TopologyDriverNode:     testNodeName,
+	}
+	labelKeys := []string{"topology.kubernetes.io/zone", utils.TopologyDriverZone, utils.TopologyDriverNode}
+	topology := newNodeTopology(baseSegments, labelKeys)
+
+	testCases := []struct {
+		nodeLabels       map[string]string
+		expectedChanged  bool
+		expectedSegments map[string]string
+	}{
+		{nil, false, baseSegments},
+		{
+			map[string]string{"topology.kubernetes.io/zone": "zone-a", "unknown": "value"},
+			true,
+			map[string]string{
+				utils.TopologyDriverIdentity:  "test-identity",
+				utils.TopologyDriverZone:      "default",
+				utils.TopologyDriverNode:      testNodeName,
+				"topology.kubernetes.io/zone": "zone-a",
+			},
+		},
+		{
+			map[string]string{"topology.kubernetes.io/zone": "zone-a", "unknown": "other-value"},
+			false,
+			map[string]string{
+				utils.TopologyDriverIdentity:  "test-identity",
+				utils.TopologyDriverZone:      "default",
+				utils.TopologyDriverNode:      testNodeName,
+				"topology.kubernetes.io/zone": "zone-a",
+			},
+		},
+		{
+			map[string]string{"topology.kubernetes.io/zone": "zone-b", utils.TopologyDriverZone: "Z1", utils.TopologyDriverNode: "other-node"},
+			true,
+			map[string]string{
+				utils.TopologyDriverIdentity:  "test-identity",
+				utils.TopologyDriverZone:      "Z1",
+				utils.TopologyDriverNode:      testNodeName,
+				"topology.kubernetes.io/zone": "zone-b",
+			},
+		},
+		{map[string]string{}, true, baseSegments},
+	}
+
+	for i, testCase := range testCases {
+		changed := topology.update(testCase.nodeLabels)
+		if changed != testCase.expectedChanged {
+			t.Fatalf("case %v: changed: expected: %v, got: %v", i+1, testCase.expectedChanged, changed)
+		}
+		if segments := topology.get(); !reflect.DeepEqual(segments, testCase.expectedSegments) {
+			t.Fatalf("case %v: segments: expected: %v, got: %v", i+1, testCase.expectedSegments, segments)
+		}
+	}
+}
+
+func TestSyncDriveTopology(t *testing.T) {
+	newDrive := func(name, node string, topology map[string]string) *directcsi.DirectCSIDrive {
+		return &directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(node)},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName: node,
+				Topology: topology,
+			},
+		}
+	}
+
+	oldSegments := map[string]string{utils.TopologyDriverNode: testNodeName}
+	topology := newNodeTopology(oldSegments, []string{"topology.kubernetes.io/zone"})
+	topology.update(map[string]string{"topology.kubernetes.io/zone": "zone-a"})
+
+	client := fakedirect.NewSimpleClientset(
+		newDrive("drive-1", testNodeName, oldSegments),
+		newDrive("drive-2", testNodeName, nil),
+		newDrive("drive-3", "other-node", oldSegments),
+	)
+	if err := syncDriveTopology(context.TODO(), client, testNodeName, topology); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		driveName        string
+		expectedTopology map[string]string
+	}{
+		{"drive-1", topology.get()},
+		{"drive-2", topology.get()},
+		{"drive-3", oldSegments},
+	}
+	for i, testCase := range testCases {
+		drive, err := client.DirectV1beta3().DirectCSIDrives().Get(context.TODO(), testCase.driveName, metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(drive.Status.Topology, testCase.expectedTopology) {
+			t.Fatalf("case %v: topology: expected: %v, got: %v", i+1, testCase.expectedTopology, drive.Status.Topology)
+		}
+	}
+}
@@ -38,12 +38,12 @@ import (
 type ueventHandler struct {
 	listener        *uevent.Listener
 	nodeID          string
-	topology        map[string]string
+	topology        *nodeTopology
 	directCSIClient clientset.Interface
 	syncMu          sync.Mutex
 }
 
-func startUeventHandler(ctx context.Context, nodeID string, topology map[string]string) {
+func startUeventHandler(ctx context.Context, nodeID string, topology *nodeTopology) {
 	klog.V(3).Info("Starting uevent handler")
 	handler := &ueventHandler{
 		nodeID:          nodeID,
@@ -147,7 +147,7 @@ func (handler *ueventHandler) syncDrives(ctx context.Context) {
 	for _, device := range devices {
 		drive := utils.NewDirectCSIDrive(
 			uuid.New().String(),
-			utils.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology),
+			utils.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology.get()),
 		)
 		if err := utils.CreateDrive(ctx, handler.directCSIClient.DirectV1beta3().DirectCSIDrives(), drive); err != nil {
 			klog.ErrorS(err, "unable to create drive", "Status.Path", drive.Status.Path)
@@ -255,7 +255,7 @@ func (handler *ueventHandler) processEvent(ctx context.Context, device *sys.Devi
 
 	drive := utils.NewDirectCSIDrive(
 		uuid.New().String(),
-		utils.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology),
+		utils.NewDirectCSIDriveStatus(device, handler.nodeID, handler.topology.get()),
 	)
 	if err := utils.CreateDrive(ctx, handler.directCSIClient.DirectV1beta3().DirectCSIDrives(), drive); err != nil {
 		klog.ErrorS(err, "unable to create drive", "Status.Path", drive.Status.Path)
@@ -19,10 +19,12 @@ package node
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientset "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/matcher"
 	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
@@ -113,6 +115,11 @@ func checkDrive(drive *directcsi.DirectCSIDrive, volumeID string, probeMounts fu
 		return fmt.Errorf("drive %v does not have volume finalizer %v", drive.Name, finalizer)
 	}
 
+	// Block mode drive is not mounted.
+	if drive.Status.BlockMode {
+		return nil
+	}
+
 	mounts, err := probeMounts()
 	if err != nil {
 		return err
@@ -134,6 +141,108 @@ func checkDrive(drive *directcsi.DirectCSIDrive, volumeID string, probeMounts fu
 	return fmt.Errorf("drive %v is not mounted at mount point %v", drive.Name, mountPoint)
 }
 
+// makeBlockDevice creates device file of the block volume; a GPT partition
+// is carved for the volume unless the whole drive is handed over.
+func makeBlockDevice(drive *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (device string, partNum int, err error) {
+	driveDevice := sys.GetDirectCSIPath(drive.Name)
+	if err = sys.MakeBlockFile(driveDevice, drive.Status.MajorNumber, drive.Status.MinorNumber); err != nil {
+		return "", 0, err
+	}
+
+	if utils.IsWholeDriveVolume(volume, drive) {
+		return driveDevice, 0, nil
+	}
+
+	// Partition is placed at the range reserved for the volume by the controller.
+	var offset int64
+	if extent := utils.GetBlockExtent(drive, volume.Name); extent != nil {
+		offset = extent.Offset
+	}
+	if partNum, err = sys.CreatePartition(driveDevice, utils.GetSectorSize(drive), volume.Name, offset, volume.Status.TotalCapacity); err != nil {
+		return "", 0, err
+	}
+
+	major, minor, err := sys.GetPartitionMajorMinor(drive.Status.MajorNumber, drive.Status.MinorNumber, partNum)
+	if err != nil {
+		return "", 0, err
+	}
+
+	device = sys.GetDirectCSIPath(volume.Name)
+	if err = sys.MakeBlockFile(device, major, minor); err != nil {
+		return "", 0, err
+	}
+
+	return device, partNum, nil
+}
+
+// defaultMaxVolumesPerDrive is the volume limit of a drive not having spec.maxVolumes set.
+const defaultMaxVolumesPerDrive = 100
+
+// getDriveMaxVolumes returns the number of volumes the drive can hold. A drive not accepting
+// new volumes, for its state or lack of free capacity, holds only its existing volumes.
+func getDriveMaxVolumes(drive *directcsi.DirectCSIDrive) int64 {
+	volumes := int64(len(utils.GetDriveVolumes(drive)))
+
+	switch drive.Status.DriveStatus {
+	case directcsi.DriveStatusReady, directcsi.DriveStatusInUse:
+	default:
+		return volumes
+	}
+
+	if drive.Spec.Unschedulable {
+		return volumes
+	}
+
+	if utils.IsConditionStatus(drive.Status.Conditions, string(directcsi.DirectCSIDriveConditionHealthy), metav1.ConditionFalse) {
+		return volumes
+	}
+
+	if drive.Status.FreeCapacity <= 0 {
+		return volumes
+	}
+
+	maxVolumes := int64(defaultMaxVolumesPerDrive)
+	if drive.Spec.MaxVolumes > 0 {
+		maxVolumes = int64(drive.Spec.MaxVolumes)
+	}
+	if maxVolumes < volumes {
+		return volumes
+	}
+	return maxVolumes
+}
+
+// getMaxVolumesPerNode returns the number of volumes the drives of the node can hold. It is
+// computed from the current drive inventory, so drives added, released or formatted are
+// accounted on every call; it is never zero.
+func getMaxVolumesPerNode(ctx context.Context, directCSIClient clientset.DirectV1beta3Interface, nodeID string) (int64, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	nodeLabelValue, err := utils.NewLabelValue(nodeID)
+	if err != nil {
+		return 0, err
+	}
+
+	resultCh, err := utils.ListDrives(ctx, directCSIClient.DirectCSIDrives(), []utils.LabelValue{nodeLabelValue}, nil, nil, utils.MaxThreadCount)
+	if err != nil {
+		return 0, err
+	}
+
+	maxVolumes := int64(0)
+	for result := range resultCh {
+		if result.Err != nil {
+			return 0, result.Err
+		}
+		maxVolumes += getDriveMaxVolumes(&result.Drive)
+	}
+
+	// Zero means unlimited in CSI, hence a node without any drive holds one volume.
+	if maxVolumes < 1 {
+		maxVolumes = 1
+	}
+	return maxVolumes, nil
+}
+
 func checkStagingTargetPath(stagingPath string, probeMounts func() (map[string][]sys.MountInfo, error)) error {
 	mounts, err := probeMounts()
 	if err != nil {
@@ -150,3 +259,26 @@ func checkStagingTargetPath(stagingPath string, probeMounts func() (map[string][
 
 	return fmt.Errorf("stagingPath %v is not mounted", stagingPath)
 }
+
+// setVolumeMountGroup changes group of the files of the volume to the group and makes them
+// accessible by the group. Directories get setgid bit for new files to inherit the group,
+// as kubelet does for fsGroup of a pod.
+func setVolumeMountGroup(path string, gid int) error {
+	return filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := os.Lchown(name, -1, gid); err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+
+		mode := info.Mode()&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky) | 0660
+		if info.IsDir() {
+			mode |= 0110 | os.ModeSetgid
+		}
+		return os.Chmod(name, mode)
+	})
+}
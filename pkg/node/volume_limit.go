@@ -0,0 +1,137 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/listener"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// syncVolumeLimit publishes max volumes of the node to its CSINode object. kubelet
+// records the limit only when the driver registers, hence changes of drives after
+// registration are published here for the scheduler.
+func syncVolumeLimit(ctx context.Context, kubeClient kubernetes.Interface, directCSIClient clientset.Interface, identity, nodeID string) error {
+	maxVolumes, err := getMaxVolumesPerNode(ctx, directCSIClient.DirectV1beta3(), nodeID)
+	if err != nil {
+		return err
+	}
+	count := int32(maxVolumes)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		csiNode, err := kubeClient.StorageV1().CSINodes().Get(ctx, nodeID, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				// kubelet creates the object and records the limit on registration.
+				return nil
+			}
+			return err
+		}
+
+		for i := range csiNode.Spec.Drivers {
+			driver := &csiNode.Spec.Drivers[i]
+			if driver.Name != identity {
+				continue
+			}
+			if driver.Allocatable != nil && driver.Allocatable.Count != nil && *driver.Allocatable.Count == count {
+				return nil
+			}
+
+			driver.Allocatable = &storagev1.VolumeNodeResources{Count: &count}
+			if _, err := kubeClient.StorageV1().CSINodes().Update(ctx, csiNode, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+			klog.V(3).InfoS("Updated max volumes per node", "NodeID", nodeID, "MaxVolumesPerNode", count)
+			return nil
+		}
+
+		// The driver is not registered yet.
+		return nil
+	})
+}
+
+type volumeLimitEventHandler struct {
+	kubeClient      kubernetes.Interface
+	directCSIClient clientset.Interface
+	identity        string
+	nodeID          string
+}
+
+func (handler *volumeLimitEventHandler) ListerWatcher() cache.ListerWatcher {
+	labelSelector := fmt.Sprintf("%s=%s", utils.NodeLabel, utils.SanitizeLabelV(handler.nodeID))
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+	}
+
+	return cache.NewFilteredListWatchFromClient(
+		handler.directCSIClient.DirectV1beta3().RESTClient(),
+		"DirectCSIDrives",
+		"",
+		optionsModifier,
+	)
+}
+
+func (handler *volumeLimitEventHandler) KubeClient() kubernetes.Interface {
+	return handler.kubeClient
+}
+
+func (handler *volumeLimitEventHandler) Name() string {
+	return "volume-limit"
+}
+
+func (handler *volumeLimitEventHandler) ObjectType() runtime.Object {
+	return &directcsi.DirectCSIDrive{}
+}
+
+func (handler *volumeLimitEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent, listener.DeleteEvent:
+		return syncVolumeLimit(ctx, handler.kubeClient, handler.directCSIClient, handler.identity, handler.nodeID)
+	}
+	return nil
+}
+
+// startVolumeLimitController keeps max volumes of the node published to
+// the scheduler in sync with the drives of the node.
+func startVolumeLimitController(ctx context.Context, identity, nodeID string) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	handler := &volumeLimitEventHandler{
+		kubeClient:      utils.GetKubeClient(),
+		directCSIClient: utils.GetDirectClientset(),
+		identity:        identity,
+		nodeID:          nodeID,
+	}
+	return listener.NewListener(handler, "volume-limit-controller", hostname, 1).Run(ctx)
+}
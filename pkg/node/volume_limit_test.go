@@ -0,0 +1,93 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	fakedirect "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/utils"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSyncVolumeLimit(t *testing.T) {
+	const identity = "direct-csi-min-io"
+	count := int32(10)
+	csiNode := &storagev1.CSINode{
+		ObjectMeta: metav1.ObjectMeta{Name: testNodeName},
+		Spec: storagev1.CSINodeSpec{
+			Drivers: []storagev1.CSINodeDriver{
+				{Name: "other-driver", NodeID: testNodeName, Allocatable: &storagev1.VolumeNodeResources{Count: &count}},
+				{Name: identity, NodeID: testNodeName, Allocatable: &storagev1.VolumeNodeResources{Count: &count}},
+			},
+		},
+	}
+	drive := &directcsi.DirectCSIDrive{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "ready-drive",
+			Labels: map[string]string{utils.NodeLabel: utils.SanitizeLabelV(testNodeName)},
+		},
+		Spec: directcsi.DirectCSIDriveSpec{MaxVolumes: 4},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:     testNodeName,
+			DriveStatus:  directcsi.DriveStatusReady,
+			FreeCapacity: mb100,
+		},
+	}
+
+	getCounts := func(kubeClient *fake.Clientset) (counts []int32) {
+		result, err := kubeClient.StorageV1().CSINodes().Get(context.TODO(), testNodeName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unable to get CSINode; %v", err)
+		}
+		for _, driver := range result.Spec.Drivers {
+			counts = append(counts, *driver.Allocatable.Count)
+		}
+		return counts
+	}
+
+	kubeClient := fake.NewSimpleClientset(csiNode)
+	directCSIClient := fakedirect.NewSimpleClientset(drive)
+	if err := syncVolumeLimit(context.TODO(), kubeClient, directCSIClient, identity, testNodeName); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if counts := getCounts(kubeClient); counts[0] != 10 || counts[1] != 4 {
+		t.Fatalf("expected counts [10 4], got %v", counts)
+	}
+
+	// A cordoned drive holds only its existing volumes; the node still holds one.
+	drive.Spec.Unschedulable = true
+	if _, err := directCSIClient.DirectV1beta3().DirectCSIDrives().Update(context.TODO(), drive, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unable to update drive; %v", err)
+	}
+	if err := syncVolumeLimit(context.TODO(), kubeClient, directCSIClient, identity, testNodeName); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if counts := getCounts(kubeClient); counts[0] != 10 || counts[1] != 1 {
+		t.Fatalf("expected counts [10 1], got %v", counts)
+	}
+
+	// Nothing to update before kubelet registers the driver.
+	if err := syncVolumeLimit(context.TODO(), fake.NewSimpleClientset(), directCSIClient, identity, testNodeName); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/listener"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// snapshotDir is the directory in the drive mountpoint holding the snapshots.
+const snapshotDir = ".snapshots"
+
+type snapshotEventHandler struct {
+	kubeClient      kubernetes.Interface
+	directCSIClient clientset.Interface
+	nodeID          string
+	reflinkCopy     func(ctx context.Context, source, target string) error
+}
+
+func newSnapshotEventHandler(nodeID string) *snapshotEventHandler {
+	return &snapshotEventHandler{
+		directCSIClient: utils.GetDirectClientset(),
+		kubeClient:      utils.GetKubeClient(),
+		nodeID:          nodeID,
+		reflinkCopy:     sys.ReflinkCopy,
+	}
+}
+
+func (handler *snapshotEventHandler) ListerWatcher() cache.ListerWatcher {
+	labelSelector := ""
+	if handler.nodeID != "" {
+		labelSelector = fmt.Sprintf("%s=%s", utils.NodeLabel, utils.SanitizeLabelV(handler.nodeID))
+	}
+
+	optionsModifier := func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+	}
+
+	return cache.NewFilteredListWatchFromClient(
+		handler.directCSIClient.DirectV1beta3().RESTClient(),
+		"DirectCSISnapshots",
+		"",
+		optionsModifier,
+	)
+}
+
+func (handler *snapshotEventHandler) KubeClient() kubernetes.Interface {
+	return handler.kubeClient
+}
+
+func (handler *snapshotEventHandler) Name() string {
+	return "snapshot"
+}
+
+func (handler *snapshotEventHandler) ObjectType() runtime.Object {
+	return &directcsi.DirectCSISnapshot{}
+}
+
+func (handler *snapshotEventHandler) updateStatus(ctx context.Context, name, hostPath string, reason directcsi.DirectCSISnapshotReason, message string) error {
+	snapshotClient := handler.directCSIClient.DirectV1beta3().DirectCSISnapshots()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		snapshot, err := snapshotClient.Get(
+			ctx, name, metav1.GetOptions{
+				TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		snapshot.Status.HostPath = hostPath
+		utils.UpdateCondition(
+			snapshot.Status.Conditions,
+			string(directcsi.DirectCSISnapshotConditionReady),
+			utils.BoolToCondition(reason == directcsi.DirectCSISnapshotReasonReady),
+			string(reason),
+			message,
+		)
+		_, err = snapshotClient.Update(
+			ctx, snapshot, metav1.UpdateOptions{
+				TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+			},
+		)
+		return err
+	})
+}
+
+func (handler *snapshotEventHandler) create(ctx context.Context, snapshot *directcsi.DirectCSISnapshot) error {
+	for _, condition := range snapshot.Status.Conditions {
+		if condition.Type == string(directcsi.DirectCSISnapshotConditionReady) &&
+			condition.Reason != string(directcsi.DirectCSISnapshotReasonNotReady) {
+			// Snapshot is either taken or failed already.
+			return nil
+		}
+	}
+
+	drive, err := handler.directCSIClient.DirectV1beta3().DirectCSIDrives().Get(
+		ctx, snapshot.Status.Drive, metav1.GetOptions{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	source := filepath.Join(drive.Status.Mountpoint, snapshot.Status.SourceVolume)
+	target := filepath.Join(drive.Status.Mountpoint, snapshotDir, snapshot.Name)
+
+	copyErr := os.RemoveAll(target)
+	if copyErr == nil {
+		copyErr = os.MkdirAll(target, 0755)
+	}
+	if copyErr == nil {
+		copyErr = handler.reflinkCopy(ctx, source, target)
+	}
+
+	if copyErr != nil {
+		utils.Eventf(snapshot, corev1.EventTypeWarning, "SnapshotFailed", "unable to snapshot volume %v; %v", snapshot.Status.SourceVolume, copyErr)
+		return handler.updateStatus(ctx, snapshot.Name, "", directcsi.DirectCSISnapshotReasonFailed, copyErr.Error())
+	}
+
+	utils.Eventf(snapshot, corev1.EventTypeNormal, "SnapshotSucceeded", "snapshot of volume %v is taken at %v", snapshot.Status.SourceVolume, target)
+	return handler.updateStatus(ctx, snapshot.Name, target, directcsi.DirectCSISnapshotReasonReady, "")
+}
+
+func (handler *snapshotEventHandler) releaseSnapshot(ctx context.Context, driveName, snapshotName string, capacity int64) error {
+	driveClient := handler.directCSIClient.DirectV1beta3().DirectCSIDrives()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveClient.Get(
+			ctx, driveName, metav1.GetOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		finalizers, found := utils.ExcludeFinalizer(
+			drive.GetFinalizers(), directcsi.DirectCSIDriveFinalizerSnapshotPrefix+snapshotName,
+		)
+		if !found {
+			return nil
+		}
+
+		if len(finalizers) == 1 {
+			if finalizers[0] == directcsi.DirectCSIDriveFinalizerDataProtection {
+				drive.Status.DriveStatus = directcsi.DriveStatusReady
+			}
+		}
+
+		drive.SetFinalizers(finalizers)
+		drive.Status.FreeCapacity += capacity
+		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
+
+		_, err = driveClient.Update(
+			ctx, drive, metav1.UpdateOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			},
+		)
+		return err
+	})
+}
+
+func (handler *snapshotEventHandler) delete(ctx context.Context, snapshot *directcsi.DirectCSISnapshot) error {
+	finalizers, _ := utils.ExcludeFinalizer(
+		snapshot.GetFinalizers(), directcsi.DirectCSISnapshotFinalizerPurgeProtection,
+	)
+	if len(finalizers) > 0 {
+		return fmt.Errorf("waiting for the snapshot to be released before cleaning up")
+	}
+
+	// Remove associated directory of the snapshot.
+	if snapshot.Status.HostPath != "" {
+		if err := os.RemoveAll(snapshot.Status.HostPath); err != nil {
+			return err
+		}
+	}
+
+	// Release snapshot from associated drive.
+	if err := handler.releaseSnapshot(ctx, snapshot.Status.Drive, snapshot.Name, snapshot.Status.TotalCapacity); err != nil {
+		return err
+	}
+
+	snapshot.SetFinalizers(finalizers)
+	_, err := handler.directCSIClient.DirectV1beta3().DirectCSISnapshots().Update(
+		ctx, snapshot, metav1.UpdateOptions{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+		},
+	)
+
+	return err
+}
+
+func (handler *snapshotEventHandler) Handle(ctx context.Context, args listener.EventArgs) error {
+	snapshot := args.Object.(*directcsi.DirectCSISnapshot)
+	switch args.Event {
+	case listener.AddEvent, listener.UpdateEvent:
+		return handler.create(ctx, snapshot)
+	case listener.DeleteEvent:
+		return handler.delete(ctx, snapshot)
+	}
+
+	return nil
+}
+
+// StartController starts snapshot controller.
+func StartController(ctx context.Context, nodeID string) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	listener := listener.NewListener(newSnapshotEventHandler(nodeID), "snapshot-controller", hostname, 40)
+	return listener.Run(ctx)
+}
@@ -0,0 +1,177 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/direct-csi/pkg/listener"
+	"github.com/minio/direct-csi/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
+	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	KB = 1 << 10
+	MB = KB << 10
+
+	mb50  = 50 * MB
+	mb100 = 100 * MB
+
+	testNodeName = "test-node"
+)
+
+func init() {
+	utils.FakeInit()
+}
+
+func createFakeSnapshotEventListener(objects ...runtime.Object) *snapshotEventHandler {
+	return &snapshotEventHandler{
+		kubeClient:      kubernetesfake.NewSimpleClientset(),
+		directCSIClient: clientsetfake.NewSimpleClientset(objects...),
+		nodeID:          testNodeName,
+		reflinkCopy: func(ctx context.Context, source, target string) error {
+			return nil
+		},
+	}
+}
+
+func TestSnapshotEventHandlerHandle(t *testing.T) {
+	testDriveName := "test_drive"
+	testVolumeName := "test_volume"
+	testSnapshotName := "test_snapshot"
+
+	testMountPointDir, err := os.MkdirTemp("", "test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testMountPointDir)
+
+	testObjects := []runtime.Object{
+		&directcsi.DirectCSIDrive{
+			TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testDriveName,
+				Finalizers: []string{
+					string(directcsi.DirectCSIDriveFinalizerDataProtection),
+					directcsi.DirectCSIDriveFinalizerSnapshotPrefix + testSnapshotName,
+				},
+			},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          testNodeName,
+				Mountpoint:        testMountPointDir,
+				DriveStatus:       directcsi.DriveStatusInUse,
+				FreeCapacity:      mb50,
+				AllocatedCapacity: mb50,
+				TotalCapacity:     mb100,
+			},
+		},
+		&directcsi.DirectCSISnapshot{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testSnapshotName,
+				Finalizers: []string{
+					directcsi.DirectCSISnapshotFinalizerPurgeProtection,
+				},
+			},
+			Status: directcsi.DirectCSISnapshotStatus{
+				SourceVolume:  testVolumeName,
+				Drive:         testDriveName,
+				NodeName:      testNodeName,
+				TotalCapacity: mb50,
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(directcsi.DirectCSISnapshotConditionReady),
+						Status:             metav1.ConditionFalse,
+						Message:            "",
+						Reason:             string(directcsi.DirectCSISnapshotReasonNotReady),
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		},
+	}
+
+	sl := createFakeSnapshotEventListener(testObjects...)
+	ctx := context.TODO()
+	directCSIClient := sl.directCSIClient.DirectV1beta3()
+	getSnapshot := func() *directcsi.DirectCSISnapshot {
+		snapshot, err := directCSIClient.DirectCSISnapshots().Get(ctx, testSnapshotName, metav1.GetOptions{
+			TypeMeta: utils.DirectCSISnapshotTypeMeta(),
+		})
+		if err != nil {
+			t.Fatalf("Error while getting the snapshot object: %+v", err)
+		}
+		return snapshot
+	}
+
+	if err := sl.Handle(ctx, listener.EventArgs{Event: listener.AddEvent, Object: getSnapshot()}); err != nil {
+		t.Fatalf("Error while invoking the snapshot add listener: %+v", err)
+	}
+
+	snapshot := getSnapshot()
+	expectedHostPath := filepath.Join(testMountPointDir, snapshotDir, testSnapshotName)
+	if snapshot.Status.HostPath != expectedHostPath {
+		t.Errorf("Unexpected host path set. Expected: %s, Got: %s", expectedHostPath, snapshot.Status.HostPath)
+	}
+	if !utils.IsConditionStatus(snapshot.Status.Conditions, string(directcsi.DirectCSISnapshotConditionReady), metav1.ConditionTrue) {
+		t.Errorf("Snapshot is not ready: %+v", snapshot.Status.Conditions)
+	}
+	if _, err := os.Stat(expectedHostPath); err != nil {
+		t.Errorf("Snapshot directory is not created: %v", err)
+	}
+
+	now := metav1.Now()
+	snapshot.DeletionTimestamp = &now
+	if err := sl.Handle(ctx, listener.EventArgs{Event: listener.DeleteEvent, Object: snapshot}); err != nil {
+		t.Fatalf("Error while invoking the snapshot delete listener: %+v", err)
+	}
+	if len(snapshot.GetFinalizers()) != 0 {
+		t.Errorf("Snapshot finalizers are not empty: %v", snapshot.GetFinalizers())
+	}
+	if _, err := os.Stat(expectedHostPath); !os.IsNotExist(err) {
+		t.Errorf("Snapshot directory is not removed: %v", err)
+	}
+
+	driveObj, dErr := directCSIClient.DirectCSIDrives().Get(ctx, testDriveName, metav1.GetOptions{
+		TypeMeta: utils.DirectCSIDriveTypeMeta(),
+	})
+	if dErr != nil {
+		t.Fatalf("Error while getting the drive object: %+v", dErr)
+	}
+
+	driveFinalizers := driveObj.GetFinalizers()
+	if len(driveFinalizers) != 1 || driveFinalizers[0] != directcsi.DirectCSIDriveFinalizerDataProtection {
+		t.Fatalf("Unexpected drive finalizers set after clean-up: %+v", driveFinalizers)
+	}
+	if driveObj.Status.DriveStatus != directcsi.DriveStatusReady {
+		t.Errorf("Unexpected drive status set. Expected: %s, Got: %s", string(directcsi.DriveStatusReady), string(driveObj.Status.DriveStatus))
+	}
+	if driveObj.Status.FreeCapacity != mb100 {
+		t.Errorf("Unexpected free capacity set. Expected: %d, Got: %d", mb100, driveObj.Status.FreeCapacity)
+	}
+	if driveObj.Status.AllocatedCapacity != 0 {
+		t.Errorf("Unexpected allocated capacity set. Expected: 0, Got: %d", driveObj.Status.AllocatedCapacity)
+	}
+}
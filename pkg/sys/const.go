@@ -33,12 +33,18 @@ const (
 	HostPartitionInfix = "p"
 )
 
+// BlockVolumePartitionType is GPT partition type GUID of block volume partitions.
+const BlockVolumePartitionType = "e6d6d379-f507-44c2-a23c-238f2a3df928"
+
 // FSType is filesystem type.
 type FSType string
 
 const (
 	// FSTypeXFS is XFS filesystem type.
 	FSTypeXFS FSType = "xfs"
+
+	// FSTypeExt4 is EXT4 filesystem type.
+	FSTypeExt4 FSType = "ext4"
 )
 
 // MountOption denotes device mount options.
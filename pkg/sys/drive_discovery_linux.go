@@ -431,6 +431,7 @@ func newDevice(event map[string]string, name string, major, minor int, virtual b
 	device.PTUUID = event["ID_PART_TABLE_UUID"]
 	device.PTType = event["ID_PART_TABLE_TYPE"]
 	device.PartUUID = event["ID_PART_ENTRY_UUID"]
+	device.PartTypeUUID = event["ID_PART_ENTRY_TYPE"]
 	device.UeventFSUUID = event["ID_FS_UUID"]
 	device.FSType = event["ID_FS_TYPE"]
 
@@ -27,34 +27,46 @@ import (
 )
 
 // formatDrive - Idempotent function to format a DirectCSIDrive
-func formatDrive(ctx context.Context, uuid, path string, force bool) error {
-	output, err := format(ctx, path, string(FSTypeXFS), []string{"-i", "maxpct=50"}, force)
-	if err != nil {
-		klog.Errorf("failed to format drive: %s", output)
-		return fmt.Errorf("error while formatting: %v output: %s", err, output)
-	}
-	if uuid != "" {
-		output, err = setXFSUUID(ctx, uuid, path)
+func formatDrive(ctx context.Context, uuid, path string, fsType FSType, force bool, options FormatOptions) error {
+	switch fsType {
+	case FSTypeXFS:
+		output, err := format(ctx, path, string(FSTypeXFS), getXFSFormatArgs(options), force)
 		if err != nil {
-			klog.Errorf("failed to set uuid after formatting: %s", output)
-			return fmt.Errorf("error while setting uuid: %v output: %s", err, output)
+			klog.Errorf("failed to format drive: %s", output)
+			return fmt.Errorf("error while formatting: %v output: %s", err, output)
 		}
+		if uuid != "" {
+			output, err = setXFSUUID(ctx, uuid, path)
+			if err != nil {
+				klog.Errorf("failed to set uuid after formatting: %s", output)
+				return fmt.Errorf("error while setting uuid: %v output: %s", err, output)
+			}
+		}
+	case FSTypeExt4:
+		output, err := format(ctx, path, string(FSTypeExt4), getExt4FormatArgs(uuid, options), force)
+		if err != nil {
+			klog.Errorf("failed to format drive: %s", output)
+			return fmt.Errorf("error while formatting: %v output: %s", err, output)
+		}
+	default:
+		return fmt.Errorf("unsupported filesystem type %v", fsType)
 	}
 	return nil
 }
 
 // DriveFormatter denotes filesystem making interface.
 type DriveFormatter interface {
-	FormatDrive(ctx context.Context, uuid, path string, force bool) error
+	FormatDrive(ctx context.Context, uuid, path string, fsType FSType, force bool, options FormatOptions) error
 	MakeBlockFile(path string, major, minor uint32) error
+	MakeGPT(path string, sectorSize uint64) (int64, error)
 }
 
 // DefaultDriveFormatter is a default filesystem making interface.
 type DefaultDriveFormatter struct{}
 
-// FormatDrive makes XFS filesystem on given device.
-func (c *DefaultDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, force bool) error {
-	return formatDrive(ctx, uuid, path, force)
+// FormatDrive makes XFS or EXT4 filesystem on given device.
+func (c *DefaultDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, fsType FSType, force bool, options FormatOptions) error {
+	return formatDrive(ctx, uuid, path, fsType, force, options)
 }
 
 // MakeBlockFile creates device file by it's major/minor number.
@@ -88,3 +100,8 @@ func MakeBlockFile(path string, major, minor uint32) error {
 func (c *DefaultDriveFormatter) MakeBlockFile(path string, major, minor uint32) error {
 	return MakeBlockFile(path, major, minor)
 }
+
+// MakeGPT writes empty GPT partition table on given device.
+func (c *DefaultDriveFormatter) MakeGPT(path string, sectorSize uint64) (int64, error) {
+	return MakeGPT(path, sectorSize)
+}
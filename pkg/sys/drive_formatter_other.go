@@ -23,15 +23,21 @@ import (
 )
 
 type DriveFormatter interface {
-	FormatDrive(ctx context.Context, uuid, path string, force bool) error
+	FormatDrive(ctx context.Context, uuid, path string, fsType FSType, force bool, options FormatOptions) error
+	MakeBlockFile(path string, major, minor uint32) error
+	MakeGPT(path string, sectorSize uint64) (int64, error)
 }
 
 type DefaultDriveFormatter struct{}
 
-func (c *DefaultDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, force bool) error {
+func (c *DefaultDriveFormatter) FormatDrive(ctx context.Context, uuid, path string, fsType FSType, force bool, options FormatOptions) error {
 	return nil
 }
 
 func (c *DefaultDriveFormatter) MakeBlockFile(path string, major, minor uint32) error {
 	return nil
 }
+
+func (c *DefaultDriveFormatter) MakeGPT(path string, sectorSize uint64) (int64, error) {
+	return 0, nil
+}
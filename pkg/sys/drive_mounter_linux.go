@@ -27,14 +27,14 @@ const (
 )
 
 // mountDrive - Idempotent function to mount a DirectCSIDrive
-func mountDrive(source, target string, mountOpts []string) error {
+func mountDrive(source, target string, fsType FSType, mountOpts []string) error {
 	// Since pods will be consuming this target, be permissive
 	if err := os.MkdirAll(target, 0777); err != nil {
 		return err
 	}
 
 	klog.V(3).Infof("mounting drive %s at %s", source, target)
-	return safeMount(source, target, string(FSTypeXFS), func(opts []string) []MountOption {
+	return safeMount(source, target, string(fsType), func(opts []string) []MountOption {
 		newOpts := []MountOption{}
 		for _, opt := range opts {
 			newOpts = append(newOpts, MountOption(opt))
@@ -60,7 +60,7 @@ func unmountDrive(path string) error {
 
 // DriveMounter is mount/unmount drive interface.
 type DriveMounter interface {
-	MountDrive(source, target string, mountOpts []string) error
+	MountDrive(source, target string, fsType FSType, mountOpts []string) error
 	UnmountDrive(path string) error
 }
 
@@ -68,8 +68,8 @@ type DriveMounter interface {
 type DefaultDriveMounter struct{}
 
 // MountDrive mounts a drive into given mountpoint.
-func (c *DefaultDriveMounter) MountDrive(source, target string, mountOpts []string) error {
-	return mountDrive(source, target, mountOpts)
+func (c *DefaultDriveMounter) MountDrive(source, target string, fsType FSType, mountOpts []string) error {
+	return mountDrive(source, target, fsType, mountOpts)
 }
 
 // UnmountDrive unmounts given mountpoint.
@@ -19,13 +19,13 @@
 package sys
 
 type DriveMounter interface {
-	MountDrive(source, target string, mountOpts []string) error
+	MountDrive(source, target string, fsType FSType, mountOpts []string) error
 	UnmountDrive(path string) error
 }
 
 type DefaultDriveMounter struct{}
 
-func (c *DefaultDriveMounter) MountDrive(source, target string, mountOpts []string) error {
+func (c *DefaultDriveMounter) MountDrive(source, target string, fsType FSType, mountOpts []string) error {
 	return nil
 }
 
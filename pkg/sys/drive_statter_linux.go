@@ -18,6 +18,8 @@ package sys
 
 import (
 	"syscall"
+
+	"golang.org/x/sys/unix"
 )
 
 func getFreeCapacityFromStatfs(path string) (freeCapacity int64, err error) {
@@ -30,9 +32,18 @@ func getFreeCapacityFromStatfs(path string) (freeCapacity int64, err error) {
 	return
 }
 
+func isReadOnlyFromStatfs(path string) (bool, error) {
+	stat := &unix.Statfs_t{}
+	if err := unix.Statfs(path, stat); err != nil {
+		return false, err
+	}
+	return stat.Flags&unix.ST_RDONLY != 0, nil
+}
+
 // DriveStatter denotes function to get free capacity of a drive.
 type DriveStatter interface {
 	GetFreeCapacityFromStatfs(path string) (freeCapacity int64, err error)
+	IsReadOnly(path string) (readOnly bool, err error)
 }
 
 // DefaultDriveStatter is a default interface to get free capacity of a drive.
@@ -42,3 +53,8 @@ type DefaultDriveStatter struct{}
 func (c *DefaultDriveStatter) GetFreeCapacityFromStatfs(path string) (int64, error) {
 	return getFreeCapacityFromStatfs(path)
 }
+
+// IsReadOnly checks whether the filesystem mounted at path is read-only.
+func (c *DefaultDriveStatter) IsReadOnly(path string) (bool, error) {
+	return isReadOnlyFromStatfs(path)
+}
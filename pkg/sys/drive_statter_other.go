@@ -20,6 +20,7 @@ package sys
 
 type DriveStatter interface {
 	GetFreeCapacityFromStatfs(path string) (freeCapacity int64, err error)
+	IsReadOnly(path string) (readOnly bool, err error)
 }
 
 type DefaultDriveStatter struct{}
@@ -27,3 +28,7 @@ type DefaultDriveStatter struct{}
 func (c *DefaultDriveStatter) GetFreeCapacityFromStatfs(path string) (int64, error) {
 	return 0, nil
 }
+
+func (c *DefaultDriveStatter) IsReadOnly(path string) (bool, error) {
+	return false, nil
+}
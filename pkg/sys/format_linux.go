@@ -29,7 +29,12 @@ func format(ctx context.Context, path, fs string, options []string, force bool)
 	args := func() []string {
 		args := options
 		if force {
-			args = append(args, "-f")
+			// mkfs.ext4 uses -F to force; -f is only understood by mkfs.xfs.
+			if fs == string(FSTypeExt4) {
+				args = append(args, "-F")
+			} else {
+				args = append(args, "-f")
+			}
 		}
 		return append(args, path)
 	}()
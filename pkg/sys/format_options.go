@@ -0,0 +1,85 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatOptions denotes options to make the filesystem with; zero value takes the default.
+type FormatOptions struct {
+	BlockSize  int64
+	SectorSize int64
+	Reflink    *bool
+	CRC        *bool
+	AGCount    int32
+	LogSize    int64
+}
+
+func boolToFlag(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// getXFSFormatArgs returns mkfs.xfs arguments of the options; reflink is enabled by default
+// for volume snapshots.
+func getXFSFormatArgs(options FormatOptions) []string {
+	args := []string{"-i", "maxpct=50"}
+
+	metadata := []string{"reflink=1"}
+	if options.Reflink != nil {
+		metadata[0] = "reflink=" + boolToFlag(*options.Reflink)
+	}
+	if options.CRC != nil {
+		metadata = append(metadata, "crc="+boolToFlag(*options.CRC))
+	}
+	args = append(args, "-m", strings.Join(metadata, ","))
+
+	if options.BlockSize > 0 {
+		args = append(args, "-b", "size="+strconv.FormatInt(options.BlockSize, 10))
+	}
+	if options.SectorSize > 0 {
+		args = append(args, "-s", "size="+strconv.FormatInt(options.SectorSize, 10))
+	}
+	if options.AGCount > 0 {
+		args = append(args, "-d", "agcount="+strconv.FormatInt(int64(options.AGCount), 10))
+	}
+	if options.LogSize > 0 {
+		args = append(args, "-l", "size="+strconv.FormatInt(options.LogSize, 10))
+	}
+	return args
+}
+
+// getExt4FormatArgs returns mkfs.ext4 arguments of the options; ext4 project quotas need the
+// project and quota features enabled at mkfs time.
+func getExt4FormatArgs(uuid string, options FormatOptions) []string {
+	args := []string{"-O", "project,quota", "-E", "quotatype=prjquota"}
+	if options.BlockSize > 0 {
+		args = append(args, "-b", strconv.FormatInt(options.BlockSize, 10))
+	}
+	if options.LogSize > 0 {
+		// Journal size is in MiB.
+		args = append(args, "-J", "size="+strconv.FormatInt(options.LogSize/(1024*1024), 10))
+	}
+	if uuid != "" {
+		args = append(args, "-U", uuid)
+	}
+	return args
+}
@@ -0,0 +1,66 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetXFSFormatArgs(t *testing.T) {
+	enabled, disabled := true, false
+	testCases := []struct {
+		options      FormatOptions
+		expectedArgs []string
+	}{
+		{FormatOptions{}, []string{"-i", "maxpct=50", "-m", "reflink=1"}},
+		{FormatOptions{Reflink: &disabled, CRC: &disabled}, []string{"-i", "maxpct=50", "-m", "reflink=0,crc=0"}},
+		{FormatOptions{CRC: &enabled}, []string{"-i", "maxpct=50", "-m", "reflink=1,crc=1"}},
+		{
+			FormatOptions{BlockSize: 4096, SectorSize: 4096, AGCount: 8, LogSize: 64 * 1024 * 1024},
+			[]string{"-i", "maxpct=50", "-m", "reflink=1", "-b", "size=4096", "-s", "size=4096", "-d", "agcount=8", "-l", "size=67108864"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if args := getXFSFormatArgs(testCase.options); !reflect.DeepEqual(args, testCase.expectedArgs) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedArgs, args)
+		}
+	}
+}
+
+func TestGetExt4FormatArgs(t *testing.T) {
+	testCases := []struct {
+		uuid         string
+		options      FormatOptions
+		expectedArgs []string
+	}{
+		{"", FormatOptions{}, []string{"-O", "project,quota", "-E", "quotatype=prjquota"}},
+		{"uuid", FormatOptions{}, []string{"-O", "project,quota", "-E", "quotatype=prjquota", "-U", "uuid"}},
+		{
+			"uuid",
+			FormatOptions{BlockSize: 2048, LogSize: 128 * 1024 * 1024},
+			[]string{"-O", "project,quota", "-E", "quotatype=prjquota", "-b", "2048", "-J", "size=128", "-U", "uuid"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if args := getExt4FormatArgs(testCase.uuid, testCase.options); !reflect.DeepEqual(args, testCase.expectedArgs) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedArgs, args)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"fmt"
+	"strings"
+)
+
+// volumeMountFlags is the allowlist of mount flags applicable to bind mounts of a volume.
+var volumeMountFlags = map[string]MountOption{
+	string(MountOptionMSReadOnly):    MountOptionMSReadOnly,
+	string(MountOptionMSNoExec):      MountOptionMSNoExec,
+	string(MountOptionMSNoSUID):      MountOptionMSNoSUID,
+	string(MountOptionMSNoDev):       MountOptionMSNoDev,
+	string(MountOptionMSNoATime):     MountOptionMSNoATime,
+	string(MountOptionMSNoDirATime):  MountOptionMSNoDirATime,
+	string(MountOptionMSRelatime):    MountOptionMSRelatime,
+	string(MountOptionMSStrictATime): MountOptionMSStrictATime,
+}
+
+// ParseVolumeMountFlags validates mount flags of a volume, as set in mountOptions of StorageClass,
+// against the allowlist and returns their mount options.
+func ParseVolumeMountFlags(flags []string) ([]MountOption, error) {
+	var mountOpts []MountOption
+	found := map[MountOption]struct{}{}
+	atimeFlag := MountOption("")
+	for _, flag := range flags {
+		for _, value := range strings.Split(flag, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			mountOpt, ok := volumeMountFlags[value]
+			if !ok {
+				return nil, fmt.Errorf("unsupported mount flag %v", value)
+			}
+
+			switch mountOpt {
+			case MountOptionMSNoATime, MountOptionMSRelatime, MountOptionMSStrictATime:
+				if atimeFlag != "" && atimeFlag != mountOpt {
+					return nil, fmt.Errorf("conflicting mount flags %v and %v", atimeFlag, mountOpt)
+				}
+				atimeFlag = mountOpt
+			}
+
+			if _, ok := found[mountOpt]; !ok {
+				found[mountOpt] = struct{}{}
+				mountOpts = append(mountOpts, mountOpt)
+			}
+		}
+	}
+	return mountOpts, nil
+}
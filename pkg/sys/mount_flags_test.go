@@ -0,0 +1,57 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVolumeMountFlags(t *testing.T) {
+	testCases := []struct {
+		flags          []string
+		expectedResult []MountOption
+		expectErr      bool
+	}{
+		{nil, nil, false},
+		{[]string{""}, nil, false},
+		{[]string{"noexec", "nosuid", "nodev", "noatime"}, []MountOption{MountOptionMSNoExec, MountOptionMSNoSUID, MountOptionMSNoDev, MountOptionMSNoATime}, false},
+		{[]string{"noexec,nosuid", " ro "}, []MountOption{MountOptionMSNoExec, MountOptionMSNoSUID, MountOptionMSReadOnly}, false},
+		{[]string{"noexec", "noexec"}, []MountOption{MountOptionMSNoExec}, false},
+		{[]string{"relatime", "nodiratime"}, []MountOption{MountOptionMSRelatime, MountOptionMSNoDirATime}, false},
+		{[]string{"noatime", "strictatime"}, nil, true},
+		{[]string{"bind"}, nil, true},
+		{[]string{"noexec", "uid=1000"}, nil, true},
+		{[]string{"sync"}, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := ParseVolumeMountFlags(testCase.flags)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("case %v: expected error; but succeeded", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
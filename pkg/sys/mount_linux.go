@@ -140,6 +140,17 @@ func mount(source, target, fsType string, mountOpts []MountOption, superblockOpt
 	if err := verifyFlags(mountOpts); err != nil {
 		return err
 	}
+	flags, err := getMountFlags(mountOpts)
+	if err != nil {
+		return err
+	}
+
+	klog.V(5).Infof("mounting %s at %s", source, target)
+	return syscall.Mount(source, target, fsType, flags, strings.Join(superblockOpts, ","))
+}
+
+// getMountFlags returns mount(2) flags of mount options.
+func getMountFlags(mountOpts []MountOption) (uintptr, error) {
 	flags := uintptr(0)
 	for _, opt := range mountOpts {
 		switch opt {
@@ -184,12 +195,10 @@ func mount(source, target, fsType string, mountOpts []MountOption, superblockOpt
 		case MountOptionMSSynchronous:
 			flags = flags | syscall.MS_SYNCHRONOUS
 		default:
-			return fmt.Errorf("unsupported mount flag: %s", opt)
+			return 0, fmt.Errorf("unsupported mount flag: %s", opt)
 		}
 	}
-
-	klog.V(5).Infof("mounting %s at %s", source, target)
-	return syscall.Mount(source, target, fsType, flags, strings.Join(superblockOpts, ","))
+	return flags, nil
 }
 
 // SafeUnmount unmounts of a target directory if it is a mountpoint.
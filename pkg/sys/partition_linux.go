@@ -0,0 +1,246 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/google/uuid"
+	"github.com/minio/direct-csi/pkg/blockdev/gpt"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+// blkpg is BLKPG ioctl request number.
+const blkpg = 0x1269
+
+// partTableMu serializes partition table modifications.
+var partTableMu sync.Mutex
+
+func newGUID() ([16]byte, error) {
+	return gpt.String2UUID(uuid.New().String())
+}
+
+func blkpgPartition(file *os.File, op int32, partNum int, start, length int64) error {
+	partition := unix.BlkpgPartition{
+		Start:  start,
+		Length: length,
+		Pno:    int32(partNum),
+	}
+	arg := unix.BlkpgIoctlArg{
+		Op:      op,
+		Datalen: int32(unsafe.Sizeof(partition)),
+		Data:    (*byte)(unsafe.Pointer(&partition)),
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, file.Fd(), blkpg, uintptr(unsafe.Pointer(&arg))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func openPartTable(device string, sectorSize uint64) (*os.File, uint64, error) {
+	if sectorSize == 0 {
+		return nil, 0, fmt.Errorf("invalid sector size of device %v", device)
+	}
+	file, err := os.OpenFile(device, os.O_RDWR, os.ModeDevice)
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, uint64(size) / sectorSize, nil
+}
+
+// MakeGPT wipes the device and writes an empty GPT partition table; returns
+// the capacity usable for partitions.
+func MakeGPT(device string, sectorSize uint64) (int64, error) {
+	partTableMu.Lock()
+	defer partTableMu.Unlock()
+
+	file, totalSectors, err := openPartTable(device, sectorSize)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	diskGUID, err := newGUID()
+	if err != nil {
+		return 0, err
+	}
+	table, err := gpt.NewTable(diskGUID, totalSectors, sectorSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Wipe existing partition table and filesystem signatures.
+	if _, err := file.WriteAt(make([]byte, 1024*1024), 0); err != nil {
+		return 0, err
+	}
+	if err := gpt.Write(file, table, sectorSize); err != nil {
+		return 0, err
+	}
+	if err := file.Sync(); err != nil {
+		return 0, err
+	}
+
+	if err := unix.IoctlSetInt(int(file.Fd()), unix.BLKRRPART, 0); err != nil {
+		klog.V(3).InfoS("unable to reread partition table", "device", device, "err", err)
+	}
+
+	return int64(table.UsableSize(sectorSize)), nil
+}
+
+// CreatePartition creates a partition of size bytes named name at byte offset
+// if it does not exist and returns the partition number; zero offset places
+// the partition in the first free space.
+func CreatePartition(device string, sectorSize uint64, name string, offset, size int64) (int, error) {
+	partTableMu.Lock()
+	defer partTableMu.Unlock()
+
+	file, _, err := openPartTable(device, sectorSize)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	table, err := gpt.ReadTable(file, sectorSize)
+	if err != nil {
+		return 0, err
+	}
+
+	partNum := table.FindPartition(name)
+	if partNum == 0 {
+		typeGUID, err := gpt.String2UUID(BlockVolumePartitionType)
+		if err != nil {
+			return 0, err
+		}
+		guid, err := newGUID()
+		if err != nil {
+			return 0, err
+		}
+		if offset > 0 {
+			partNum, err = table.AddPartitionAt(typeGUID, guid, name, uint64(offset), uint64(size), sectorSize)
+		} else {
+			partNum, err = table.AddPartition(typeGUID, guid, name, uint64(size), sectorSize)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := gpt.Write(file, table, sectorSize); err != nil {
+			return 0, err
+		}
+		if err := file.Sync(); err != nil {
+			return 0, err
+		}
+	}
+
+	entry := table.Entries[partNum-1]
+	start := int64(entry.FirstLBA * sectorSize)
+	length := int64((entry.LastLBA - entry.FirstLBA + 1) * sectorSize)
+	if err := blkpgPartition(file, unix.BLKPG_ADD_PARTITION, partNum, start, length); err != nil && !errors.Is(err, unix.EBUSY) {
+		return 0, fmt.Errorf("unable to add partition %v of %v to kernel; %w", partNum, device, err)
+	}
+
+	return partNum, nil
+}
+
+// DeletePartition deletes the partition named name if it exists.
+func DeletePartition(device string, sectorSize uint64, name string) error {
+	partTableMu.Lock()
+	defer partTableMu.Unlock()
+
+	file, _, err := openPartTable(device, sectorSize)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	table, err := gpt.ReadTable(file, sectorSize)
+	if err != nil {
+		return err
+	}
+
+	partNum := table.FindPartition(name)
+	if partNum == 0 {
+		return nil
+	}
+
+	if err := blkpgPartition(file, unix.BLKPG_DEL_PARTITION, partNum, 0, 0); err != nil && !errors.Is(err, unix.ENXIO) {
+		return fmt.Errorf("unable to delete partition %v of %v from kernel; %w", partNum, device, err)
+	}
+
+	if err := table.DeletePartition(partNum); err != nil {
+		return err
+	}
+	if err := gpt.Write(file, table, sectorSize); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// GetPartitionMajorMinor returns major/minor number of the partition partNum
+// of the device by it's major/minor number.
+func GetPartitionMajorMinor(major, minor uint32, partNum int) (uint32, uint32, error) {
+	dir := fmt.Sprintf("/sys/dev/block/%v:%v", major, minor)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name(), "partition"))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return 0, 0, err
+		}
+		if strings.TrimSpace(string(data)) != strconv.Itoa(partNum) {
+			continue
+		}
+
+		if data, err = os.ReadFile(filepath.Join(dir, entry.Name(), "dev")); err != nil {
+			return 0, 0, err
+		}
+		tokens := strings.SplitN(strings.TrimSpace(string(data)), ":", 2)
+		if len(tokens) != 2 {
+			return 0, 0, fmt.Errorf("unknown device number format %v", string(data))
+		}
+		partMajor, err := strconv.ParseUint(tokens[0], 10, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+		partMinor, err := strconv.ParseUint(tokens[1], 10, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+		return uint32(partMajor), uint32(partMinor), nil
+	}
+
+	return 0, 0, fmt.Errorf("partition %v of device %v:%v not found", partNum, major, minor)
+}
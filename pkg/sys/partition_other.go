@@ -0,0 +1,44 @@
+//go:build !linux
+
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// MakeGPT is unsupported on this operating system.
+func MakeGPT(device string, sectorSize uint64) (int64, error) {
+	return 0, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// CreatePartition is unsupported on this operating system.
+func CreatePartition(device string, sectorSize uint64, name string, offset, size int64) (int, error) {
+	return 0, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// DeletePartition is unsupported on this operating system.
+func DeletePartition(device string, sectorSize uint64, name string) error {
+	return fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
+
+// GetPartitionMajorMinor is unsupported on this operating system.
+func GetPartitionMajorMinor(major, minor uint32, partNum int) (uint32, uint32, error) {
+	return 0, 0, fmt.Errorf("unsupported operating system %v", runtime.GOOS)
+}
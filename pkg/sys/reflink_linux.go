@@ -0,0 +1,44 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// ReflinkCopy copies the content of source directory into target directory
+// by sharing the data extents (reflink); target directory must exist.
+func ReflinkCopy(ctx context.Context, source, target string) error {
+	if _, err := os.Stat(source); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Nothing to copy.
+			return nil
+		}
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "cp", "-a", "--reflink=always", source+string(os.PathSeparator)+".", target)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to reflink copy %v to %v; %w; output: %s", source, target, err, string(output))
+	}
+
+	return nil
+}
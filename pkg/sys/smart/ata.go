@@ -0,0 +1,202 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ataPassThrough16      = 0x85
+	ataProtocolPIODataIn  = 4
+	ataTransferFromDevice = 0x0e // T_DIR=1, BYTE_BLOCK=1, T_LENGTH=sector count
+
+	ataCmdSMART            = 0xb0
+	ataSMARTReadData       = 0xd0
+	ataSMARTReadThresholds = 0xd1
+	ataSMARTLBAMid         = 0x4f
+	ataSMARTLBAHigh        = 0xc2
+
+	ataSMARTPageLen   = 512
+	ataSMARTAttrCount = 30
+	ataSMARTAttrLen   = 12
+	ataAttrPrefailure = 0x1
+
+	inquiryLen = 36
+	ataVendor  = "ATA"
+)
+
+// ATA S.M.A.R.T. attribute IDs; they are vendor specific but these are widely followed.
+const (
+	ataAttrReallocatedSectors   = 5
+	ataAttrPowerOnHours         = 9
+	ataAttrWearLevelingCount    = 177
+	ataAttrReportedUncorrect    = 187
+	ataAttrAirflowTemperature   = 190
+	ataAttrTemperature          = 194
+	ataAttrPendingSectors       = 197
+	ataAttrOfflineUncorrectable = 198
+	ataAttrSSDLifeLeft          = 231
+	ataAttrMediaWearout         = 233
+)
+
+var standardInquiry = []byte{0x12, 0x00, 0x00, 0x00, inquiryLen, 0x00}
+
+type ataSMARTAttr struct {
+	id    uint8
+	flags uint16
+	value uint8
+	raw   int64
+}
+
+func checkATASMARTPage(page []byte) error {
+	if len(page) < ataSMARTPageLen {
+		return errors.New("short ATA SMART page")
+	}
+	var sum byte
+	for _, b := range page[:ataSMARTPageLen] {
+		sum += b
+	}
+	if sum != 0 {
+		return fmt.Errorf("invalid ATA SMART page checksum %#02x", page[ataSMARTPageLen-1])
+	}
+	return nil
+}
+
+// parseATASMART parses SMART READ DATA and SMART READ THRESHOLDS pages.
+func parseATASMART(data, thresholds []byte) (*Health, error) {
+	if err := checkATASMARTPage(data); err != nil {
+		return nil, err
+	}
+	if err := checkATASMARTPage(thresholds); err != nil {
+		return nil, err
+	}
+
+	thresholdMap := map[uint8]uint8{}
+	for i := 0; i < ataSMARTAttrCount; i++ {
+		entry := thresholds[2+i*ataSMARTAttrLen:]
+		if entry[0] != 0 {
+			thresholdMap[entry[0]] = entry[1]
+		}
+	}
+
+	attrs := map[uint8]ataSMARTAttr{}
+	for i := 0; i < ataSMARTAttrCount; i++ {
+		entry := data[2+i*ataSMARTAttrLen:]
+		if entry[0] == 0 {
+			continue
+		}
+		raw := make([]byte, 8)
+		copy(raw, entry[5:11])
+		attrs[entry[0]] = ataSMARTAttr{
+			id:    entry[0],
+			flags: binary.LittleEndian.Uint16(entry[1:3]),
+			value: entry[3],
+			raw:   int64(binary.LittleEndian.Uint64(raw)),
+		}
+	}
+
+	health := &Health{}
+	failurePredicted := false
+	for id, attr := range attrs {
+		switch id {
+		case ataAttrReallocatedSectors:
+			health.ReallocatedSectors = attr.raw
+		case ataAttrPowerOnHours:
+			health.PowerOnHours = attr.raw & 0xffffffff
+		case ataAttrPendingSectors:
+			health.PendingSectors = attr.raw
+		case ataAttrWearLevelingCount, ataAttrSSDLifeLeft, ataAttrMediaWearout:
+			if attr.value <= 100 {
+				health.PercentageUsed = 100 - int64(attr.value)
+			}
+		}
+
+		if threshold := thresholdMap[id]; attr.flags&ataAttrPrefailure != 0 && threshold != 0 && attr.value <= threshold {
+			failurePredicted = true
+		}
+	}
+
+	// Prefer temperature and uncorrectable errors attributes over their alternatives.
+	if attr, found := attrs[ataAttrTemperature]; found {
+		health.Temperature = attr.raw & 0xff
+	} else if attr, found := attrs[ataAttrAirflowTemperature]; found {
+		health.Temperature = attr.raw & 0xff
+	}
+	if attr, found := attrs[ataAttrReportedUncorrect]; found {
+		health.MediaErrors = attr.raw & 0xffffffff
+	} else if attr, found := attrs[ataAttrOfflineUncorrectable]; found {
+		health.MediaErrors = attr.raw & 0xffffffff
+	}
+
+	if failurePredicted {
+		health.CriticalWarnings = []string{WarningFailurePredicted}
+	}
+
+	return health, nil
+}
+
+// isATA checks whether the device is an ATA device behind SCSI/ATA translation layer.
+func (d *scsiDevice) isATA() (bool, error) {
+	respBuf := make([]byte, inquiryLen)
+	if err := d.sendCDB(standardInquiry, &respBuf); err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(respBuf[8:16])) == ataVendor, nil
+}
+
+func (d *scsiDevice) readATASMART(feature byte) ([]byte, error) {
+	respBuf := make([]byte, ataSMARTPageLen)
+
+	cdb := []byte{
+		ataPassThrough16,
+		ataProtocolPIODataIn << 1,
+		ataTransferFromDevice,
+		0x00, feature, // features
+		0x00, 0x01, // sector count
+		0x00, 0x00, // LBA low
+		0x00, ataSMARTLBAMid,
+		0x00, ataSMARTLBAHigh,
+		0x00,        // device
+		ataCmdSMART, // command
+		0x00,        // control
+	}
+
+	if err := d.sendCDB(cdb, &respBuf); err != nil {
+		return nil, err
+	}
+
+	return respBuf, nil
+}
+
+func (d *scsiDevice) ataHealth() (*Health, error) {
+	data, err := d.readATASMART(ataSMARTReadData)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := d.readATASMART(ataSMARTReadThresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseATASMART(data, thresholds)
+}
@@ -0,0 +1,179 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readTestPage(t *testing.T, name string) []byte {
+	page, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("unable to read test page %v; %v", name, err)
+	}
+	return page
+}
+
+func TestParseNVMeSMARTLog(t *testing.T) {
+	testCases := []struct {
+		page           string
+		expectedResult *Health
+	}{
+		{
+			"nvme-smart-log.bin",
+			&Health{Temperature: 38, PowerOnHours: 4521, PercentageUsed: 3},
+		},
+		{
+			"nvme-smart-log-failing.bin",
+			&Health{
+				Temperature:      85,
+				PowerOnHours:     38211,
+				PercentageUsed:   104,
+				MediaErrors:      17,
+				CriticalWarnings: []string{WarningAvailableSpare, WarningReliabilityDegraded},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		result, err := parseNVMeSMARTLog(readTestPage(t, testCase.page))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	if _, err := parseNVMeSMARTLog(readTestPage(t, "nvme-smart-log.bin")[:256]); err == nil {
+		t.Fatalf("expected error for short page, but succeeded")
+	}
+}
+
+func TestParseATASMART(t *testing.T) {
+	thresholds := readTestPage(t, "ata-smart-thresholds.bin")
+	testCases := []struct {
+		page           string
+		expectedResult *Health
+	}{
+		{
+			"ata-smart-data.bin",
+			&Health{
+				Temperature:        36,
+				PowerOnHours:       8123,
+				MediaErrors:        2,
+				ReallocatedSectors: 8,
+				PendingSectors:     3,
+			},
+		},
+		{
+			"ata-smart-data-failing.bin",
+			&Health{
+				Temperature:        36,
+				PowerOnHours:       8123,
+				MediaErrors:        2,
+				ReallocatedSectors: 3912,
+				PendingSectors:     72,
+				CriticalWarnings:   []string{WarningFailurePredicted},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		result, err := parseATASMART(readTestPage(t, testCase.page), thresholds)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	corrupted := readTestPage(t, "ata-smart-data.bin")
+	corrupted[100]++
+	if _, err := parseATASMART(corrupted, thresholds); err == nil {
+		t.Fatalf("expected checksum error, but succeeded")
+	}
+}
+
+func TestParseSCSIHealth(t *testing.T) {
+	supportedPages, err := parseSCSISupportedLogPages(readTestPage(t, "scsi-log-page-00.bin"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, pageCode := range []byte{logPageWriteErrors, logPageReadErrors, logPageVerifyErrors, logPageTemperature, logPageBackgroundScan, logPageInfoExceptions} {
+		if !supportedPages[pageCode] {
+			t.Fatalf("page %#02x: expected to be supported", pageCode)
+		}
+	}
+	if supportedPages[logPageSolidStateMedia] {
+		t.Fatalf("page %#02x: expected to be not supported", logPageSolidStateMedia)
+	}
+
+	testCases := []struct {
+		pages          map[byte]string
+		expectedResult *Health
+	}{
+		{
+			map[byte]string{
+				logPageWriteErrors:    "scsi-log-page-02.bin",
+				logPageReadErrors:     "scsi-log-page-03.bin",
+				logPageVerifyErrors:   "scsi-log-page-05.bin",
+				logPageTemperature:    "scsi-log-page-0d.bin",
+				logPageBackgroundScan: "scsi-log-page-15.bin",
+				logPageInfoExceptions: "scsi-log-page-2f.bin",
+			},
+			&Health{Temperature: 34, PowerOnHours: 45275, MediaErrors: 2},
+		},
+		{
+			map[byte]string{
+				logPageSolidStateMedia: "scsi-log-page-11.bin",
+				logPageInfoExceptions:  "scsi-log-page-2f-failing.bin",
+			},
+			&Health{Temperature: 41, PercentageUsed: 7, CriticalWarnings: []string{WarningFailurePredicted}},
+		},
+	}
+
+	for i, testCase := range testCases {
+		pages := map[byte][]byte{}
+		for pageCode, name := range testCase.pages {
+			pages[pageCode] = readTestPage(t, name)
+		}
+		result, err := parseSCSIHealth(pages)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	if _, err := parseSCSIHealth(map[byte][]byte{logPageReadErrors: readTestPage(t, "scsi-log-page-03.bin")[:40]}); err == nil {
+		t.Fatalf("expected error for truncated page, but succeeded")
+	}
+
+	defects, err := parseSCSIDefectListHeader(readTestPage(t, "scsi-defect-list-header.bin"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if defects != 27 {
+		t.Fatalf("defects: expected: 27, got: %v", defects)
+	}
+}
@@ -19,6 +19,8 @@ package smart
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
+	"math"
 	"strings"
 	"unsafe"
 
@@ -27,9 +29,24 @@ import (
 )
 
 const (
-	nvmeAdminIdentify = 0x06
+	nvmeAdminGetLogPage = 0x02
+	nvmeAdminIdentify   = 0x06
+
+	nvmeLogSMART    = 0x02
+	nvmeSMARTLogLen = 512
+	nvmeNSIDAll     = 0xffffffff
+	kelvinOffset    = 273
 )
 
+var nvmeCriticalWarnings = []string{
+	WarningAvailableSpare,
+	WarningTemperature,
+	WarningReliabilityDegraded,
+	WarningReadOnly,
+	WarningVolatileMemoryBackup,
+	WarningPersistentMemory,
+}
+
 var (
 	nvmeIoctlAdminCmd = ioctl.Iowr('N', 0x41, unsafe.Sizeof(nvmePassthruCommand{}))
 )
@@ -121,6 +138,68 @@ type nvmeIdentController struct {
 	// Vs           [1024]byte              // Vendor Specific
 } // 4096 bytes
 
+// SMART / Health Information log page, defined in section 5.14.1.2 of NVMe 1.4 spec.
+type nvmeSMARTLog struct {
+	CritWarning      uint8     // Critical Warning
+	Temperature      uint16    // Composite Temperature in Kelvin
+	AvailSpare       uint8     // Available Spare
+	SpareThresh      uint8     // Available Spare Threshold
+	PercentUsed      uint8     // Percentage Used
+	EnduranceCritWrn uint8     // Endurance Group Critical Warning Summary
+	Rsvd7            [25]byte  // ...
+	DataUnitsRead    [16]byte  // Data Units Read
+	DataUnitsWritten [16]byte  // Data Units Written
+	HostReads        [16]byte  // Host Read Commands
+	HostWrites       [16]byte  // Host Write Commands
+	CtrlBusyTime     [16]byte  // Controller Busy Time
+	PowerCycles      [16]byte  // Power Cycles
+	PowerOnHours     [16]byte  // Power On Hours
+	UnsafeShutdowns  [16]byte  // Unsafe Shutdowns
+	MediaErrors      [16]byte  // Media and Data Integrity Errors
+	NumErrLogEntries [16]byte  // Number of Error Information Log Entries
+	WarningTempTime  uint32    // Warning Composite Temperature Time
+	CritCompTime     uint32    // Critical Composite Temperature Time
+	TempSensor       [8]uint16 // Temperature Sensors
+	Rsvd216          [296]byte // ...
+} // 512 bytes
+
+// uint128ToInt64 converts little endian 128-bit counter to int64 saturating on overflow.
+func uint128ToInt64(b [16]byte) int64 {
+	low := binary.LittleEndian.Uint64(b[:8])
+	if binary.LittleEndian.Uint64(b[8:]) != 0 || low > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(low)
+}
+
+// parseNVMeSMARTLog parses SMART / Health Information log page.
+func parseNVMeSMARTLog(buf []byte) (*Health, error) {
+	if len(buf) < nvmeSMARTLogLen {
+		return nil, errors.New("short NVMe SMART log page")
+	}
+
+	var smartLog nvmeSMARTLog
+	if err := binary.Read(bytes.NewReader(buf[:nvmeSMARTLogLen]), binary.LittleEndian, &smartLog); err != nil {
+		return nil, err
+	}
+
+	health := &Health{
+		PowerOnHours:   uint128ToInt64(smartLog.PowerOnHours),
+		PercentageUsed: int64(smartLog.PercentUsed),
+		MediaErrors:    uint128ToInt64(smartLog.MediaErrors),
+	}
+	if smartLog.Temperature > 0 {
+		health.Temperature = int64(smartLog.Temperature) - kelvinOffset
+	}
+	for bit, warning := range nvmeCriticalWarnings {
+		if smartLog.CritWarning&(1<<bit) != 0 {
+			health.CriticalWarnings = append(health.CriticalWarnings, warning)
+		}
+	}
+
+	return health, nil
+}
+
 func (d *nvmeDevice) open() (int, error) {
 	return unix.Open(d.Name, unix.O_RDWR, 0600)
 }
@@ -159,3 +238,27 @@ func (d *nvmeDevice) SerialNumber() (string, error) {
 
 	return string(controller.SerialNumber[:]), nil
 }
+
+func (d *nvmeDevice) Health() (*Health, error) {
+	fd, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	defer d.close(fd)
+
+	buf := make([]byte, nvmeSMARTLogLen)
+
+	cmd := nvmePassthruCommand{
+		opcode:  nvmeAdminGetLogPage,
+		nsid:    nvmeNSIDAll, // Controller wide log
+		addr:    uint64(uintptr(unsafe.Pointer(&buf[0]))),
+		dataLen: uint32(len(buf)),
+		cdw10:   uint32(len(buf)/4-1)<<16 | nvmeLogSMART, // Number of dwords and log page identifier
+	}
+
+	if err := sysIOCTL(uintptr(fd), nvmeIoctlAdminCmd, uintptr(unsafe.Pointer(&cmd))); err != nil {
+		return nil, err
+	}
+
+	return parseNVMeSMARTLog(buf)
+}
@@ -19,6 +19,7 @@ package smart
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"unsafe"
 
@@ -34,8 +35,36 @@ const (
 
 	sgIO           = 0x2285 //scsi generic ioctl command
 	sgDxferFromDev = -3
+
+	logSense          = 0x4d
+	logSenseLen       = 1024
+	logPageCumulative = 0x40 // PC=01b, cumulative values
+	readDefectData10  = 0x37
+	grownDefectList   = 0x08 // REQ_GLIST=1, short block format
+
+	logPageSupported       = 0x00
+	logPageWriteErrors     = 0x02
+	logPageReadErrors      = 0x03
+	logPageVerifyErrors    = 0x05
+	logPageTemperature     = 0x0d
+	logPageSolidStateMedia = 0x11
+	logPageBackgroundScan  = 0x15
+	logPageInfoExceptions  = 0x2f
+
+	errorCounterUncorrected = 0x0006
+	unknownTemperature      = 0xff
 )
 
+var healthLogPages = []byte{
+	logPageWriteErrors,
+	logPageReadErrors,
+	logPageVerifyErrors,
+	logPageTemperature,
+	logPageSolidStateMedia,
+	logPageBackgroundScan,
+	logPageInfoExceptions,
+}
+
 var (
 	serialInquiry = []byte{0x12, 0x01, 0x80, 0x00, 0x60, 0x00}
 )
@@ -165,3 +194,186 @@ func (d *scsiDevice) SerialNumber() (string, error) {
 	}
 	return string(inquiryR[4:]), nil
 }
+
+// beInt converts big endian value of log parameter to int64.
+func beInt(b []byte) int64 {
+	var value uint64
+	for _, c := range b {
+		value = value<<8 | uint64(c)
+	}
+	return int64(value)
+}
+
+// parseSCSILogPage parses parameters of log page by their parameter codes.
+func parseSCSILogPage(buf []byte, pageCode byte) (map[uint16][]byte, error) {
+	if len(buf) < 4 {
+		return nil, errors.New("short SCSI log page")
+	}
+	if buf[0]&0x3f != pageCode {
+		return nil, fmt.Errorf("SCSI log page %#02x received for page %#02x", buf[0]&0x3f, pageCode)
+	}
+	end := 4 + int(binary.BigEndian.Uint16(buf[2:4]))
+	if end > len(buf) {
+		return nil, fmt.Errorf("truncated SCSI log page %#02x", pageCode)
+	}
+
+	params := map[uint16][]byte{}
+	for offset := 4; offset+4 <= end; {
+		code := binary.BigEndian.Uint16(buf[offset : offset+2])
+		valueEnd := offset + 4 + int(buf[offset+3])
+		if valueEnd > end {
+			return nil, fmt.Errorf("truncated parameter %#04x of SCSI log page %#02x", code, pageCode)
+		}
+		params[code] = buf[offset+4 : valueEnd]
+		offset = valueEnd
+	}
+
+	return params, nil
+}
+
+// parseSCSISupportedLogPages parses page codes listed in supported log pages page.
+func parseSCSISupportedLogPages(buf []byte) (map[byte]bool, error) {
+	if len(buf) < 4 || buf[0]&0x3f != logPageSupported {
+		return nil, errors.New("invalid supported SCSI log pages page")
+	}
+	end := 4 + int(binary.BigEndian.Uint16(buf[2:4]))
+	if end > len(buf) {
+		return nil, errors.New("truncated supported SCSI log pages page")
+	}
+
+	pages := map[byte]bool{}
+	for _, pageCode := range buf[4:end] {
+		pages[pageCode&0x3f] = true
+	}
+	return pages, nil
+}
+
+// parseSCSIDefectListHeader returns number of defects in the grown defect list.
+func parseSCSIDefectListHeader(buf []byte) (int64, error) {
+	if len(buf) < 4 {
+		return 0, errors.New("short SCSI defect list header")
+	}
+	entryLen := 8
+	if buf[1]&0x07 == 0 { // short block format
+		entryLen = 4
+	}
+	return int64(binary.BigEndian.Uint16(buf[2:4])) / int64(entryLen), nil
+}
+
+// parseSCSIHealth parses health from log pages by their page codes.
+func parseSCSIHealth(pages map[byte][]byte) (*Health, error) {
+	health := &Health{}
+	for pageCode, page := range pages {
+		params, err := parseSCSILogPage(page, pageCode)
+		if err != nil {
+			return nil, err
+		}
+
+		switch pageCode {
+		case logPageWriteErrors, logPageReadErrors, logPageVerifyErrors:
+			health.MediaErrors += beInt(params[errorCounterUncorrected])
+		case logPageTemperature:
+			if value := params[0x0000]; len(value) >= 2 && value[1] != unknownTemperature {
+				health.Temperature = int64(value[1])
+			}
+		case logPageSolidStateMedia:
+			if value := params[0x0001]; len(value) >= 4 {
+				health.PercentageUsed = int64(value[3])
+			}
+		case logPageBackgroundScan:
+			if value := params[0x0000]; len(value) >= 4 {
+				health.PowerOnHours = beInt(value[:4]) / 60
+			}
+		case logPageInfoExceptions:
+			if value := params[0x0000]; len(value) >= 1 && value[0] != 0 {
+				health.CriticalWarnings = []string{WarningFailurePredicted}
+			}
+		}
+	}
+
+	// Fallback to most recent temperature reading of informational exceptions page.
+	if health.Temperature == 0 {
+		if params, err := parseSCSILogPage(pages[logPageInfoExceptions], logPageInfoExceptions); err == nil {
+			if value := params[0x0000]; len(value) >= 3 && value[2] != unknownTemperature {
+				health.Temperature = int64(value[2])
+			}
+		}
+	}
+
+	return health, nil
+}
+
+func (d *scsiDevice) logSense(pageCode byte) ([]byte, error) {
+	respBuf := make([]byte, logSenseLen)
+
+	cdb := []byte{logSense, 0x00, logPageCumulative | pageCode, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	binary.BigEndian.PutUint16(cdb[7:], uint16(len(respBuf)))
+
+	if err := d.sendCDB(cdb, &respBuf); err != nil {
+		return nil, err
+	}
+
+	return respBuf, nil
+}
+
+func (d *scsiDevice) grownDefects() (int64, error) {
+	respBuf := make([]byte, 4)
+
+	cdb := []byte{readDefectData10, 0x00, grownDefectList, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	binary.BigEndian.PutUint16(cdb[7:], uint16(len(respBuf)))
+
+	if err := d.sendCDB(cdb, &respBuf); err != nil {
+		return 0, err
+	}
+
+	return parseSCSIDefectListHeader(respBuf)
+}
+
+func (d *scsiDevice) scsiHealth() (*Health, error) {
+	buf, err := d.logSense(logPageSupported)
+	if err != nil {
+		return nil, err
+	}
+	supportedPages, err := parseSCSISupportedLogPages(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := map[byte][]byte{}
+	for _, pageCode := range healthLogPages {
+		if !supportedPages[pageCode] {
+			continue
+		}
+		if pages[pageCode], err = d.logSense(pageCode); err != nil {
+			return nil, err
+		}
+	}
+
+	health, err := parseSCSIHealth(pages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Grown defect list is optional, hence its error is ignored.
+	if defects, err := d.grownDefects(); err == nil {
+		health.ReallocatedSectors = defects
+	}
+
+	return health, nil
+}
+
+func (d *scsiDevice) Health() (*Health, error) {
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	defer d.close()
+
+	isATA, err := d.isATA()
+	if err != nil {
+		return nil, err
+	}
+	if isATA {
+		return d.ataHealth()
+	}
+	return d.scsiHealth()
+}
@@ -16,8 +16,44 @@
 
 package smart
 
+// Critical warnings of a device.
+const (
+	WarningAvailableSpare       = "AvailableSpareBelowThreshold"
+	WarningTemperature          = "TemperatureThreshold"
+	WarningReliabilityDegraded  = "ReliabilityDegraded"
+	WarningReadOnly             = "ReadOnly"
+	WarningVolatileMemoryBackup = "VolatileMemoryBackupFailed"
+	WarningPersistentMemory     = "PersistentMemoryRegionReadOnly"
+	WarningFailurePredicted     = "FailurePredicted"
+)
+
+// Health denotes health information of a device read from its S.M.A.R.T. data, SCSI log pages
+// or NVMe SMART/health log page. Counters not reported by the device are zero.
+type Health struct {
+	// Temperature is current temperature in Celsius.
+	Temperature int64
+	// PowerOnHours is number of hours the device is powered on.
+	PowerOnHours int64
+	// PercentageUsed is estimated percentage of device life used; it may exceed 100.
+	PercentageUsed int64
+	// MediaErrors is number of unrecovered data integrity errors.
+	MediaErrors int64
+	// ReallocatedSectors is number of sectors remapped to spare area.
+	ReallocatedSectors int64
+	// PendingSectors is number of unstable sectors waiting to be remapped.
+	PendingSectors int64
+	// CriticalWarnings is list of critical warnings raised by the device.
+	CriticalWarnings []string
+}
+
 // GetSerialNumber fetches device serial number from S.M.A.R.T.
 func GetSerialNumber(devicePath string) (string, error) {
 	sd := getSmartDevice(devicePath)
 	return sd.SerialNumber()
 }
+
+// GetHealth fetches device health from S.M.A.R.T.
+func GetHealth(devicePath string) (*Health, error) {
+	sd := getSmartDevice(devicePath)
+	return sd.Health()
+}
@@ -55,6 +55,7 @@ func sysIOCTL(fd, cmd, ptr uintptr) error {
 
 type smartDevice interface {
 	SerialNumber() (string, error)
+	Health() (*Health, error)
 }
 
 func getSmartDevice(devicePath string) smartDevice {
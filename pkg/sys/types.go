@@ -31,20 +31,21 @@ type Device struct {
 	Virtual   bool
 
 	// Populated from /run/udev/data/b<Major>:<Minor>
-	Size      uint64
-	Partition int
-	WWID      string
-	Model     string
-	Serial    string
-	Vendor    string
-	DMName    string
-	DMUUID    string
-	MDUUID    string
-	PTUUID    string
-	PTType    string
-	PartUUID  string
-	FSUUID    string
-	FSType    string
+	Size         uint64
+	Partition    int
+	WWID         string
+	Model        string
+	Serial       string
+	Vendor       string
+	DMName       string
+	DMUUID       string
+	MDUUID       string
+	PTUUID       string
+	PTType       string
+	PartUUID     string
+	PartTypeUUID string
+	FSUUID       string
+	FSType       string
 
 	UeventSerial string
 	UeventFSUUID string
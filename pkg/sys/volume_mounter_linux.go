@@ -18,26 +18,55 @@ package sys
 
 import (
 	"context"
+	"syscall"
 
 	"k8s.io/klog/v2"
 )
 
 // Idempotent function to bind mount a xfs filesystem with limits
-func mountVolume(ctx context.Context, src, dest string, readOnly bool) error {
-	klog.V(5).Infof("[mountVolume] source: %v destination: %v", src, dest)
-	if err := safeMount(src, dest, string(FSTypeXFS),
-		func() []MountOption {
-			mOpts := []MountOption{
-				MountOptionMSBind,
-			}
-			if readOnly {
-				mOpts = append(mOpts, MountOptionMSReadOnly)
-			}
-			return mOpts
-		}(), []string{quotaOption}); err != nil {
+func mountVolume(ctx context.Context, src, dest string, readOnly bool, mountOpts []MountOption) error {
+	klog.V(5).Infof("[mountVolume] source: %v destination: %v mountOptions: %v", src, dest, mountOpts)
+	if err := safeMount(src, dest, string(FSTypeXFS), []MountOption{MountOptionMSBind}, []string{quotaOption}); err != nil {
+		return err
+	}
+
+	if readOnly {
+		mountOpts = append(append([]MountOption{}, mountOpts...), MountOptionMSReadOnly)
+	}
+	if len(mountOpts) == 0 {
+		return nil
+	}
+
+	// Flags of a bind mount are applied only by remounting it.
+	flags, err := getMountFlags(mountOpts)
+	if err != nil {
 		return err
 	}
+	return syscall.Mount("", dest, "", syscall.MS_REMOUNT|syscall.MS_BIND|flags, "")
+}
 
+// Idempotent function to bind mount a block device file
+func mountBlockVolume(ctx context.Context, src, dest string, readOnly bool) error {
+	klog.V(5).Infof("[mountBlockVolume] source: %v destination: %v", src, dest)
+	mountInfos, err := ProbeMounts()
+	if err != nil {
+		return err
+	}
+	for _, mounts := range mountInfos {
+		for _, mount := range mounts {
+			if mount.MountPoint == dest {
+				klog.V(3).Infof("block volume already mounted: %s", dest)
+				return nil
+			}
+		}
+	}
+
+	if err := mount(src, dest, "", []MountOption{MountOptionMSBind}, nil); err != nil {
+		return err
+	}
+	if readOnly {
+		return syscall.Mount("", dest, "", syscall.MS_REMOUNT|syscall.MS_BIND|syscall.MS_RDONLY, "")
+	}
 	return nil
 }
 
@@ -47,16 +76,22 @@ func unmountVolume(targetPath string) error {
 
 // VolumeMounter is mount/unmount of volume interface.
 type VolumeMounter interface {
-	MountVolume(ctx context.Context, src, dest string, readOnly bool) error
+	MountVolume(ctx context.Context, src, dest string, readOnly bool, mountOpts []MountOption) error
+	MountBlockVolume(ctx context.Context, src, dest string, readOnly bool) error
 	UnmountVolume(targetPath string) error
 }
 
 // DefaultVolumeMounter is a default mount/unmount of volume interface.
 type DefaultVolumeMounter struct{}
 
-// MountVolume mounts a volume.
-func (c *DefaultVolumeMounter) MountVolume(ctx context.Context, src, dest string, readOnly bool) error {
-	return mountVolume(ctx, src, dest, readOnly)
+// MountVolume bind mounts a volume with mount options.
+func (c *DefaultVolumeMounter) MountVolume(ctx context.Context, src, dest string, readOnly bool, mountOpts []MountOption) error {
+	return mountVolume(ctx, src, dest, readOnly, mountOpts)
+}
+
+// MountBlockVolume bind mounts a block device file.
+func (c *DefaultVolumeMounter) MountBlockVolume(ctx context.Context, src, dest string, readOnly bool) error {
+	return mountBlockVolume(ctx, src, dest, readOnly)
 }
 
 // UnmountVolume unmounts a volume.
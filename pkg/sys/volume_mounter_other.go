@@ -23,13 +23,18 @@ import (
 )
 
 type VolumeMounter interface {
-	MountVolume(ctx context.Context, src, dest string, readOnly bool) error
+	MountVolume(ctx context.Context, src, dest string, readOnly bool, mountOpts []MountOption) error
+	MountBlockVolume(ctx context.Context, src, dest string, readOnly bool) error
 	UnmountVolume(targetPath string) error
 }
 
 type DefaultVolumeMounter struct{}
 
-func (c *DefaultVolumeMounter) MountVolume(ctx context.Context, src, dest string, readOnly bool) error {
+func (c *DefaultVolumeMounter) MountVolume(ctx context.Context, src, dest string, readOnly bool, mountOpts []MountOption) error {
+	return nil
+}
+
+func (c *DefaultVolumeMounter) MountBlockVolume(ctx context.Context, src, dest string, readOnly bool) error {
 	return nil
 }
 
@@ -43,6 +43,7 @@ var (
 
 	NodeLabel       = NewDirectCSILabel("node")
 	DriveLabel      = NewDirectCSILabel("drive")
+	VolumeLabel     = NewDirectCSILabel("volume")
 	DrivePathLabel  = NewDirectCSILabel("path")
 	AccessTierLabel = NewDirectCSILabel("access-tier")
 
@@ -51,6 +52,9 @@ var (
 
 	ReservedDrivePathLabel = NewDirectCSILabel("drive-path")
 
+	SpreadGroupLabel = NewDirectCSILabel("spread-group")
+	VolumeGroupLabel = NewDirectCSILabel("volume-group")
+
 	DirectCSIGroupVersion = SanitizeLabelK(directcsi.Group + "/" + directcsi.Version)
 )
 
@@ -97,3 +101,8 @@ func DirectCSIDriveTypeMeta() metav1.TypeMeta {
 func DirectCSIVolumeTypeMeta() metav1.TypeMeta {
 	return NewTypeMeta(DirectCSIGroupVersion, "DirectCSIVolume")
 }
+
+// DirectCSISnapshotTypeMeta gets new direct-csi snapshot meta.
+func DirectCSISnapshotTypeMeta() metav1.TypeMeta {
+	return NewTypeMeta(DirectCSIGroupVersion, "DirectCSISnapshot")
+}
@@ -24,6 +24,7 @@ import (
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	clientset "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3"
+	"github.com/minio/direct-csi/pkg/fs/xfs"
 	"github.com/minio/direct-csi/pkg/sys"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,6 +50,11 @@ func NewDirectCSIDriveStatus(device *sys.Device, nodeID string, topology map[str
 		driveStatus = directcsi.DriveStatusUnavailable
 	}
 
+	// Partitions of block volumes are managed by their parent drive.
+	if device.PartTypeUUID == sys.BlockVolumePartitionType {
+		driveStatus = directcsi.DriveStatusUnavailable
+	}
+
 	mounted := metav1.ConditionFalse
 	if device.FirstMountPoint != "" {
 		mounted = metav1.ConditionTrue
@@ -185,3 +191,45 @@ func DeleteDrive(ctx context.Context, driveInterface clientset.DirectCSIDriveInt
 		return fmt.Errorf("cannot delete drive in use")
 	}
 }
+
+// GetSectorSize returns logical sector size of the drive; defaults to 512 bytes.
+func GetSectorSize(drive *directcsi.DirectCSIDrive) uint64 {
+	if drive.Status.LogicalBlockSize > 0 {
+		return uint64(drive.Status.LogicalBlockSize)
+	}
+	return 512
+}
+
+// GetBlockExtent returns the range of the block mode drive reserved for the volume; nil if not found.
+func GetBlockExtent(drive *directcsi.DirectCSIDrive, volumeName string) *directcsi.BlockExtent {
+	for i := range drive.Status.BlockExtents {
+		if drive.Status.BlockExtents[i].Volume == volumeName {
+			return &drive.Status.BlockExtents[i]
+		}
+	}
+	return nil
+}
+
+// GetDriveVolumes returns names of volumes reserved on the drive.
+func GetDriveVolumes(drive *directcsi.DirectCSIDrive) (volumes []string) {
+	for _, finalizer := range drive.GetFinalizers() {
+		if strings.HasPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix) {
+			volumes = append(volumes, strings.TrimPrefix(finalizer, directcsi.DirectCSIDriveFinalizerPrefix))
+		}
+	}
+	return volumes
+}
+
+// IsWholeDriveVolume returns whether the block volume is handed over the whole drive.
+func IsWholeDriveVolume(volume *directcsi.DirectCSIVolume, drive *directcsi.DirectCSIDrive) bool {
+	return volume.Status.BlockMode && volume.Status.TotalCapacity == drive.Status.TotalCapacity
+}
+
+// GetProjectID returns quota project ID of the volume. A volume without allocated
+// project ID falls back to the project ID hashed from its name.
+func GetProjectID(volume *directcsi.DirectCSIVolume) uint32 {
+	if volume.Status.ProjectID != 0 {
+		return uint32(volume.Status.ProjectID)
+	}
+	return xfs.GetProjectID(volume.Name)
+}
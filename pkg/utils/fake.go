@@ -20,7 +20,7 @@ import (
 	clientsetfake "github.com/minio/direct-csi/pkg/clientset/fake"
 	directcsifake "github.com/minio/direct-csi/pkg/clientset/typed/direct.csi.min.io/v1beta3/fake"
 
-	apiextensionsv1fake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1/fake"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	discoveryfake "k8s.io/client-go/discovery/fake"
@@ -33,7 +33,7 @@ func FakeInit() {
 	kubeClient = kubernetesfake.NewSimpleClientset()
 	directClientset = clientsetfake.NewSimpleClientset()
 	directCSIClient = directClientset.DirectV1beta3()
-	apiextensionsClient = &apiextensionsv1fake.FakeApiextensionsV1{}
+	apiextensionsClient = apiextensionsfake.NewSimpleClientset().ApiextensionsV1()
 	crdClient = apiextensionsClient.CustomResourceDefinitions()
 	discoveryClient = &discoveryfake.FakeDiscovery{}
 
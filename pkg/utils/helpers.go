@@ -60,6 +60,23 @@ func UpdateCondition(statusConditions []metav1.Condition, condType string, condS
 	}
 }
 
+// SetCondition updates condition of type/status/reason/message or adds it if not found in conditions.
+func SetCondition(statusConditions []metav1.Condition, condType string, condStatus metav1.ConditionStatus, reason, msg string) []metav1.Condition {
+	for i := range statusConditions {
+		if statusConditions[i].Type == condType {
+			UpdateCondition(statusConditions, condType, condStatus, reason, msg)
+			return statusConditions
+		}
+	}
+	return append(statusConditions, metav1.Condition{
+		Type:               condType,
+		Status:             condStatus,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
 // IsCondition checks type/status/reason/message in conditions.
 func IsCondition(statusConditions []metav1.Condition, condType string, condStatus metav1.ConditionStatus, reason, msg string) bool {
 	for i := range statusConditions {
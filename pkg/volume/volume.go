@@ -24,6 +24,7 @@ import (
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta3"
 	"github.com/minio/direct-csi/pkg/clientset"
 	"github.com/minio/direct-csi/pkg/listener"
+	"github.com/minio/direct-csi/pkg/sys"
 	"github.com/minio/direct-csi/pkg/utils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,19 +37,46 @@ import (
 )
 
 type volumeEventHandler struct {
-	kubeClient      kubernetes.Interface
-	directCSIClient clientset.Interface
-	nodeID          string
+	kubeClient         kubernetes.Interface
+	directCSIClient    clientset.Interface
+	nodeID             string
+	releaseBlockDevice func(drive *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (int64, error)
 }
 
 func newVolumeEventHandler(nodeID string) *volumeEventHandler {
 	return &volumeEventHandler{
-		directCSIClient: utils.GetDirectClientset(),
-		kubeClient:      utils.GetKubeClient(),
-		nodeID:          nodeID,
+		directCSIClient:    utils.GetDirectClientset(),
+		kubeClient:         utils.GetKubeClient(),
+		nodeID:             nodeID,
+		releaseBlockDevice: releaseBlockDevice,
 	}
 }
 
+// releaseBlockDevice removes GPT partition of the block volume and returns
+// the capacity released on the drive. A whole drive handed over to the
+// volume gets a new empty GPT.
+func releaseBlockDevice(drive *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (int64, error) {
+	device := sys.GetDirectCSIPath(drive.Name)
+	if err := sys.MakeBlockFile(device, drive.Status.MajorNumber, drive.Status.MinorNumber); err != nil {
+		return 0, err
+	}
+
+	if utils.IsWholeDriveVolume(volume, drive) {
+		return sys.MakeGPT(device, utils.GetSectorSize(drive))
+	}
+
+	if volume.Status.PartitionNum > 0 {
+		if err := sys.DeletePartition(device, utils.GetSectorSize(drive), volume.Name); err != nil {
+			return 0, err
+		}
+		if err := os.Remove(volume.Status.HostPath); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+
+	return volume.Status.TotalCapacity, nil
+}
+
 func (handler *volumeEventHandler) ListerWatcher() cache.ListerWatcher {
 	labelSelector := ""
 	if handler.nodeID != "" {
@@ -101,6 +129,13 @@ func (handler *volumeEventHandler) releaseVolume(ctx context.Context, driveName,
 		}
 
 		drive.SetFinalizers(finalizers)
+		var extents []directcsi.BlockExtent
+		for _, extent := range drive.Status.BlockExtents {
+			if extent.Volume != volumeName {
+				extents = append(extents, extent)
+			}
+		}
+		drive.Status.BlockExtents = extents
 		drive.Status.FreeCapacity += capacity
 		drive.Status.AllocatedCapacity = drive.Status.TotalCapacity - drive.Status.FreeCapacity
 
@@ -122,13 +157,28 @@ func (handler *volumeEventHandler) delete(ctx context.Context, volume *directcsi
 		return fmt.Errorf("waiting for the volume to be released before cleaning up")
 	}
 
-	// Remove associated directory of the volume.
-	if err := os.RemoveAll(volume.Status.HostPath); err != nil {
+	capacity := volume.Status.TotalCapacity
+	if volume.Status.BlockMode {
+		drive, err := handler.directCSIClient.DirectV1beta3().DirectCSIDrives().Get(
+			ctx, volume.Status.Drive, metav1.GetOptions{
+				TypeMeta: utils.DirectCSIDriveTypeMeta(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		// Remove associated partition of the volume.
+		if capacity, err = handler.releaseBlockDevice(drive, volume); err != nil {
+			return err
+		}
+	} else if err := os.RemoveAll(volume.Status.HostPath); err != nil {
+		// Remove associated directory of the volume.
 		return err
 	}
 
 	// Release volume from associated drive.
-	if err := handler.releaseVolume(ctx, volume.Status.Drive, volume.Name, volume.Status.TotalCapacity); err != nil {
+	if err := handler.releaseVolume(ctx, volume.Status.Drive, volume.Name, capacity); err != nil {
 		return err
 	}
 
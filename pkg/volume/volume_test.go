@@ -18,6 +18,7 @@ package volume
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/minio/direct-csi/pkg/listener"
@@ -266,3 +267,86 @@ func TestAbnormalDeleteEventHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteBlockVolume(t *testing.T) {
+	newDrive := func(freeCapacity int64, volumes ...string) *directcsi.DirectCSIDrive {
+		finalizers := []string{string(directcsi.DirectCSIDriveFinalizerDataProtection)}
+		var extents []directcsi.BlockExtent
+		for i, volume := range volumes {
+			finalizers = append(finalizers, directcsi.DirectCSIDriveFinalizerPrefix+volume)
+			extents = append(extents, directcsi.BlockExtent{Volume: volume, Offset: int64(i+1) * mb20, Size: mb20})
+		}
+		return &directcsi.DirectCSIDrive{
+			TypeMeta:   utils.DirectCSIDriveTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{Name: "test-drive", Finalizers: finalizers},
+			Status: directcsi.DirectCSIDriveStatus{
+				NodeName:          testNodeName,
+				DriveStatus:       directcsi.DriveStatusInUse,
+				BlockMode:         true,
+				TotalCapacity:     mb100,
+				FreeCapacity:      freeCapacity,
+				AllocatedCapacity: mb100 - freeCapacity,
+				BlockExtents:      extents,
+			},
+		}
+	}
+	newVolume := func(name string, capacity int64) *directcsi.DirectCSIVolume {
+		return &directcsi.DirectCSIVolume{
+			TypeMeta: utils.DirectCSIVolumeTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: []string{string(directcsi.DirectCSIVolumeFinalizerPurgeProtection)},
+			},
+			Status: directcsi.DirectCSIVolumeStatus{
+				NodeName:      testNodeName,
+				Drive:         "test-drive",
+				TotalCapacity: capacity,
+				BlockMode:     true,
+				PartitionNum:  1,
+			},
+		}
+	}
+
+	testCases := []struct {
+		drive                *directcsi.DirectCSIDrive
+		volume               *directcsi.DirectCSIVolume
+		releasedCapacity     int64
+		expectedFreeCapacity int64
+		expectedDriveStatus  directcsi.DriveStatus
+		expectedExtents      []directcsi.BlockExtent
+	}{
+		{newDrive(mb50, "volume-1", "volume-2"), newVolume("volume-1", mb20), mb20, mb50 + mb20, directcsi.DriveStatusInUse, []directcsi.BlockExtent{{Volume: "volume-2", Offset: 2 * mb20, Size: mb20}}},
+		{newDrive(0, "volume-1"), newVolume("volume-1", mb100), mb100 - 2*MB, mb100 - 2*MB, directcsi.DriveStatusReady, nil},
+	}
+
+	for i, testCase := range testCases {
+		var released []string
+		vl := createFakeVolumeEventListener(testCase.drive, testCase.volume)
+		vl.releaseBlockDevice = func(drive *directcsi.DirectCSIDrive, volume *directcsi.DirectCSIVolume) (int64, error) {
+			released = append(released, volume.Name)
+			return testCase.releasedCapacity, nil
+		}
+
+		ctx := context.TODO()
+		if err := vl.Handle(ctx, listener.EventArgs{Event: listener.DeleteEvent, Object: testCase.volume}); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if len(released) != 1 || released[0] != testCase.volume.Name {
+			t.Fatalf("case %v: expected block device of %v to be released, got %v", i+1, testCase.volume.Name, released)
+		}
+
+		drive, err := vl.directCSIClient.DirectV1beta3().DirectCSIDrives().Get(ctx, "test-drive", metav1.GetOptions{TypeMeta: utils.DirectCSIDriveTypeMeta()})
+		if err != nil {
+			t.Fatalf("case %v: drive fetch error %v", i+1, err)
+		}
+		if drive.Status.FreeCapacity != testCase.expectedFreeCapacity {
+			t.Fatalf("case %v: expected free capacity %v, got %v", i+1, testCase.expectedFreeCapacity, drive.Status.FreeCapacity)
+		}
+		if drive.Status.DriveStatus != testCase.expectedDriveStatus {
+			t.Fatalf("case %v: expected drive status %v, got %v", i+1, testCase.expectedDriveStatus, drive.Status.DriveStatus)
+		}
+		if !reflect.DeepEqual(drive.Status.BlockExtents, testCase.expectedExtents) {
+			t.Fatalf("case %v: expected extents %v, got %v", i+1, testCase.expectedExtents, drive.Status.BlockExtents)
+		}
+	}
+}